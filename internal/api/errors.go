@@ -0,0 +1,218 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/middleware"
+	"github.com/OPGLOL/opgl-data-service/internal/services"
+	"github.com/rs/zerolog/log"
+)
+
+// Machine-readable error codes returned in the error envelope
+const (
+	ErrorCodeInvalidRequestBody   = "INVALID_REQUEST_BODY"
+	ErrorCodeMissingField         = "MISSING_FIELD"
+	ErrorCodeInvalidField         = "INVALID_FIELD"
+	ErrorCodeInvalidRegion        = "INVALID_REGION"
+	ErrorCodeNotFound             = "NOT_FOUND"
+	ErrorCodeRateLimited          = "RATE_LIMITED"
+	ErrorCodeUpstreamUnauthorized = "UPSTREAM_UNAUTHORIZED"
+	ErrorCodeUpstreamBadRequest   = "UPSTREAM_BAD_REQUEST"
+	ErrorCodeUpstreamUnavailable  = "UPSTREAM_UNAVAILABLE"
+	ErrorCodeCircuitOpen          = "UPSTREAM_CIRCUIT_OPEN"
+	ErrorCodeUpstreamError        = "UPSTREAM_ERROR"
+	ErrorCodeUpstreamTimeout      = "UPSTREAM_TIMEOUT"
+	ErrorCodeUpstreamUnreachable  = "UPSTREAM_UNREACHABLE"
+	ErrorCodePartialMatchHistory  = "PARTIAL_MATCH_HISTORY"
+	ErrorCodeBackfillJobLimit     = "BACKFILL_JOB_LIMIT"
+	ErrorCodeInternalError        = "INTERNAL_ERROR"
+	ErrorCodeRouteNotFound        = "ROUTE_NOT_FOUND"
+	ErrorCodeMethodNotAllowed     = "METHOD_NOT_ALLOWED"
+)
+
+// ErrorResponse is the JSON envelope returned for every error
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes a single error
+type ErrorDetail struct {
+	// Stable machine-readable error code
+	Code string `json:"code"`
+	// Human-readable description of the error
+	Message string `json:"message"`
+	// Request field that failed validation (validation errors only)
+	Field string `json:"field,omitempty"`
+	// ID of the request, for correlating with logs
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// notFoundMessages maps Riot API services to client-facing not-found messages
+var notFoundMessages = map[string]string{
+	"account-v1":          "Riot account not found",
+	"summoner-v4":         "summoner not found",
+	"match-v5":            "match not found",
+	"league-v4":           "ranked entries not found",
+	"champion-mastery-v4": "champion mastery not found",
+	"league-exp-v4":       "league entries not found",
+	"challenges-v1":       "challenge data not found",
+}
+
+// requiredField pairs a request field name with its value for validation
+type requiredField struct {
+	name  string
+	value string
+}
+
+// firstMissingField returns the name of the first empty field, or "" if all are set
+func firstMissingField(fields ...requiredField) string {
+	for _, field := range fields {
+		if field.value == "" {
+			return field.name
+		}
+	}
+	return ""
+}
+
+// writeError writes a JSON error envelope with the given status code
+func writeError(writer http.ResponseWriter, request *http.Request, statusCode int, code string, message string, field string) {
+	response := ErrorResponse{
+		Error: ErrorDetail{
+			Code:      code,
+			Message:   message,
+			Field:     field,
+			RequestID: middleware.RequestIDFromContext(request.Context()),
+		},
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	json.NewEncoder(writer).Encode(response)
+}
+
+// writeInvalidBody writes the error for a request body that is not valid JSON
+func writeInvalidBody(writer http.ResponseWriter, request *http.Request) {
+	writeError(writer, request, http.StatusBadRequest, ErrorCodeInvalidRequestBody, "Invalid request body", "")
+}
+
+// writeMissingField writes the validation error for a missing required field
+func writeMissingField(writer http.ResponseWriter, request *http.Request, field string, message string) {
+	writeError(writer, request, http.StatusBadRequest, ErrorCodeMissingField, message, field)
+}
+
+// writeInvalidField writes the validation error for a field with an unacceptable value
+func writeInvalidField(writer http.ResponseWriter, request *http.Request, field string, message string) {
+	writeError(writer, request, http.StatusBadRequest, ErrorCodeInvalidField, message, field)
+}
+
+// writeInvalidRegion writes the validation error for a region that is not in the registry
+func writeInvalidRegion(writer http.ResponseWriter, request *http.Request, region string) {
+	message := fmt.Sprintf("unknown region %q; supported regions: %s", region, strings.Join(services.RegionCodes(), ", "))
+	writeError(writer, request, http.StatusBadRequest, ErrorCodeInvalidRegion, message, "region")
+}
+
+// writeServiceError translates an error from the Riot service into an HTTP response
+// Riot API errors are mapped to meaningful status codes; anything else is an internal error
+func writeServiceError(writer http.ResponseWriter, request *http.Request, err error) {
+	var circuitOpenError *services.CircuitOpenError
+	if errors.As(err, &circuitOpenError) {
+		setRetryAfter(writer, circuitOpenError.RetryAfter)
+		writeError(writer, request, http.StatusServiceUnavailable, ErrorCodeCircuitOpen, "Riot API is temporarily unavailable for this region", "")
+		return
+	}
+
+	var invalidOptionError *services.InvalidMatchListOptionError
+	if errors.As(err, &invalidOptionError) {
+		writeInvalidField(writer, request, invalidOptionError.Field, invalidOptionError.Error())
+		return
+	}
+
+	var invalidLeaderboardOptionError *services.InvalidLeaderboardOptionError
+	if errors.As(err, &invalidLeaderboardOptionError) {
+		writeInvalidField(writer, request, invalidLeaderboardOptionError.Field, invalidLeaderboardOptionError.Error())
+		return
+	}
+
+	var jobLimitError *services.BackfillJobLimitError
+	if errors.As(err, &jobLimitError) {
+		writeError(writer, request, http.StatusTooManyRequests, ErrorCodeBackfillJobLimit, "too many backfill jobs are running; try again later", "")
+		return
+	}
+
+	var unknownRegionError *services.UnknownRegionError
+	if errors.As(err, &unknownRegionError) {
+		writeInvalidRegion(writer, request, unknownRegionError.Region)
+		return
+	}
+
+	// Riot never answered, which is an upstream failure rather than a bug in this service
+	var transportError *services.RiotTransportError
+	if errors.As(err, &transportError) {
+		log.Warn().
+			Err(err).
+			Str("request_id", middleware.RequestIDFromContext(request.Context())).
+			Msg("Riot API request got no response")
+		if transportError.Timeout {
+			writeError(writer, request, http.StatusGatewayTimeout, ErrorCodeUpstreamTimeout, "Riot API did not respond in time", "")
+		} else {
+			writeError(writer, request, http.StatusBadGateway, ErrorCodeUpstreamUnreachable, "Riot API could not be reached", "")
+		}
+		return
+	}
+
+	var riotAPIError *services.RiotAPIError
+	if !errors.As(err, &riotAPIError) {
+		log.Error().
+			Err(err).
+			Str("request_id", middleware.RequestIDFromContext(request.Context())).
+			Msg("Unexpected service error")
+		writeError(writer, request, http.StatusInternalServerError, ErrorCodeInternalError, "internal server error", "")
+		return
+	}
+
+	switch statusCode := riotAPIError.StatusCode; {
+	case statusCode == http.StatusNotFound:
+		message, exists := notFoundMessages[riotAPIError.Service]
+		if !exists {
+			message = "resource not found"
+		}
+		writeError(writer, request, http.StatusNotFound, ErrorCodeNotFound, message, "")
+	case statusCode == http.StatusTooManyRequests:
+		setRetryAfter(writer, riotAPIError.RetryAfter)
+		writeError(writer, request, http.StatusTooManyRequests, ErrorCodeRateLimited, "Riot API rate limit exceeded", "")
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		// Riot returns 401 for a missing key and 403 for an expired or revoked one
+		writeError(writer, request, http.StatusUnauthorized, ErrorCodeUpstreamUnauthorized, "Riot API key is invalid or expired", "")
+	case statusCode == http.StatusBadRequest:
+		writeError(writer, request, http.StatusBadRequest, ErrorCodeUpstreamBadRequest, "Riot API rejected the request parameters", "")
+	case statusCode == http.StatusServiceUnavailable:
+		writeError(writer, request, http.StatusServiceUnavailable, ErrorCodeUpstreamUnavailable, "Riot API is unavailable", "")
+	default:
+		writeError(writer, request, http.StatusBadGateway, ErrorCodeUpstreamError, "Riot API request failed", "")
+	}
+}
+
+// setRetryAfter sets the Retry-After header in whole seconds, rounding up (no header for zero)
+func setRetryAfter(writer http.ResponseWriter, retryAfter time.Duration) {
+	if retryAfter > 0 {
+		retryAfterSeconds := int(math.Ceil(retryAfter.Seconds()))
+		writer.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+	}
+}
+
+// RouteNotFound handles requests to unknown routes
+func RouteNotFound(writer http.ResponseWriter, request *http.Request) {
+	writeError(writer, request, http.StatusNotFound, ErrorCodeRouteNotFound, "route not found", "")
+}
+
+// MethodNotAllowed handles requests using an unsupported HTTP method
+func MethodNotAllowed(writer http.ResponseWriter, request *http.Request) {
+	writeError(writer, request, http.StatusMethodNotAllowed, ErrorCodeMethodNotAllowed, "method not allowed", "")
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/middleware"
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+	"github.com/OPGLOL/opgl-data-service/internal/services"
+)
+
+// decodeErrorResponse decodes a JSON error envelope from a response recorder
+func decodeErrorResponse(t *testing.T, responseRecorder *httptest.ResponseRecorder) ErrorResponse {
+	t.Helper()
+
+	if contentType := responseRecorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Expected Content-Type 'application/json', got '%s'", contentType)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+
+	return response
+}
+
+// TestWriteError_IncludesRequestID tests that the error envelope carries the request ID
+func TestWriteError_IncludesRequestID(t *testing.T) {
+	errorHandler := middleware.RequestIDMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writeMissingField(writer, request, "region", "region is required")
+	}))
+
+	request, _ := http.NewRequest("POST", "/api/v1/matches", nil)
+	request.Header.Set(middleware.RequestIDHeader, "test-request-id")
+	responseRecorder := httptest.NewRecorder()
+	errorHandler.ServeHTTP(responseRecorder, request)
+
+	response := decodeErrorResponse(t, responseRecorder)
+
+	if response.Error.RequestID != "test-request-id" {
+		t.Errorf("Expected requestId 'test-request-id', got '%s'", response.Error.RequestID)
+	}
+
+	if response.Error.Code != ErrorCodeMissingField || response.Error.Field != "region" {
+		t.Errorf("Expected MISSING_FIELD on region, got %s on '%s'", response.Error.Code, response.Error.Field)
+	}
+
+	if response.Error.Message != "region is required" {
+		t.Errorf("Expected message 'region is required', got '%s'", response.Error.Message)
+	}
+}
+
+// TestWriteServiceError_StatusMapping tests translation of Riot API errors into HTTP statuses
+func TestWriteServiceError_StatusMapping(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"not found", &services.RiotAPIError{StatusCode: http.StatusNotFound, Service: "account-v1"}, http.StatusNotFound, ErrorCodeNotFound},
+		{"rate limited", &services.RiotAPIError{StatusCode: http.StatusTooManyRequests}, http.StatusTooManyRequests, ErrorCodeRateLimited},
+		{"missing key", &services.RiotAPIError{StatusCode: http.StatusUnauthorized}, http.StatusUnauthorized, ErrorCodeUpstreamUnauthorized},
+		{"expired key", &services.RiotAPIError{StatusCode: http.StatusForbidden}, http.StatusUnauthorized, ErrorCodeUpstreamUnauthorized},
+		{"bad request", &services.RiotAPIError{StatusCode: http.StatusBadRequest}, http.StatusBadRequest, ErrorCodeUpstreamBadRequest},
+		{"unavailable", &services.RiotAPIError{StatusCode: http.StatusServiceUnavailable}, http.StatusServiceUnavailable, ErrorCodeUpstreamUnavailable},
+		{"internal error", &services.RiotAPIError{StatusCode: http.StatusInternalServerError}, http.StatusBadGateway, ErrorCodeUpstreamError},
+		{"gateway timeout", &services.RiotAPIError{StatusCode: http.StatusGatewayTimeout}, http.StatusBadGateway, ErrorCodeUpstreamError},
+		{"wrapped", fmt.Errorf("failed to get account info: %w", &services.RiotAPIError{StatusCode: http.StatusNotFound}), http.StatusNotFound, ErrorCodeNotFound},
+		{"circuit open", fmt.Errorf("failed to get summoner: %w", &services.CircuitOpenError{Host: "na1.api.riotgames.com"}), http.StatusServiceUnavailable, ErrorCodeCircuitOpen},
+		{"unknown region", &services.UnknownRegionError{Region: "eu"}, http.StatusBadRequest, ErrorCodeInvalidRegion},
+		{"attempt timeout", fmt.Errorf("failed to get active game: %w", &services.RiotTransportError{Timeout: true, Err: context.DeadlineExceeded}), http.StatusGatewayTimeout, ErrorCodeUpstreamTimeout},
+		{"unreachable", fmt.Errorf("failed to get active game: %w", &services.RiotTransportError{Err: errors.New("connection refused")}), http.StatusBadGateway, ErrorCodeUpstreamUnreachable},
+		{"untyped", errors.New("something broke"), http.StatusInternalServerError, ErrorCodeInternalError},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request, _ := http.NewRequest("POST", "/api/v1/summoner", nil)
+			responseRecorder := httptest.NewRecorder()
+			writeServiceError(responseRecorder, request, testCase.err)
+
+			if responseRecorder.Code != testCase.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", testCase.expectedStatus, responseRecorder.Code)
+			}
+
+			response := decodeErrorResponse(t, responseRecorder)
+			if response.Error.Code != testCase.expectedCode {
+				t.Errorf("Expected error code '%s', got '%s'", testCase.expectedCode, response.Error.Code)
+			}
+		})
+	}
+}
+
+// TestWriteServiceError_RetryAfter tests that the Retry-After header is forwarded on rate limits
+func TestWriteServiceError_RetryAfter(t *testing.T) {
+	request, _ := http.NewRequest("POST", "/api/v1/ranked", nil)
+	responseRecorder := httptest.NewRecorder()
+	writeServiceError(responseRecorder, request, &services.RiotAPIError{
+		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: 1500 * time.Millisecond,
+	})
+
+	if retryAfter := responseRecorder.Header().Get("Retry-After"); retryAfter != "2" {
+		t.Errorf("Expected Retry-After '2', got '%s'", retryAfter)
+	}
+}
+
+// TestWriteServiceError_CircuitOpenRetryAfter tests that an open circuit tells clients when to retry
+func TestWriteServiceError_CircuitOpenRetryAfter(t *testing.T) {
+	request, _ := http.NewRequest("POST", "/api/v1/matches", nil)
+	responseRecorder := httptest.NewRecorder()
+	writeServiceError(responseRecorder, request, &services.CircuitOpenError{
+		Host:       "europe.api.riotgames.com",
+		RetryAfter: 12300 * time.Millisecond,
+	})
+
+	if retryAfter := responseRecorder.Header().Get("Retry-After"); retryAfter != "13" {
+		t.Errorf("Expected Retry-After '13', got '%s'", retryAfter)
+	}
+}
+
+// TestGetSummonerByRiotID_UnknownAccount tests that an unknown Riot ID returns 404 without Riot's raw body
+func TestGetSummonerByRiotID_UnknownAccount(t *testing.T) {
+	mockService := &MockRiotService{
+		GetSummonerByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Summoner, error) {
+			return nil, fmt.Errorf("failed to get account info: %w", &services.RiotAPIError{
+				StatusCode: http.StatusNotFound,
+				Service:    "account-v1",
+				Endpoint:   "getByRiotId",
+				Message:    `{"status":{"message":"Data not found"}}`,
+			})
+		},
+	}
+
+	handler := NewHandler(mockService)
+
+	bodyBytes, _ := json.Marshal(map[string]string{
+		"region":   "na",
+		"gameName": "NonExistent",
+		"tagLine":  "NA1",
+	})
+
+	request, _ := http.NewRequest("POST", "/api/v1/summoner", bytes.NewBuffer(bodyBytes))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseRecorder := httptest.NewRecorder()
+	handler.GetSummonerByRiotID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, responseRecorder.Code)
+	}
+
+	if bytes.Contains(responseRecorder.Body.Bytes(), []byte("Data not found")) {
+		t.Error("Expected Riot's raw response body not to be exposed")
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeNotFound {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeNotFound, response.Error.Code)
+	}
+}
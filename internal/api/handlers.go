@@ -2,29 +2,115 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/OPGLOL/opgl-data-service/internal/models"
 	"github.com/OPGLOL/opgl-data-service/internal/services"
 )
 
+// CircuitBreakerReporter reports the circuit breaker state of each Riot routing host
+type CircuitBreakerReporter interface {
+	CircuitBreakerStatus() map[string]services.CircuitBreakerStatus
+}
+
+// CoalescedCallReporter reports how many calls were served by an identical in-flight Riot request
+type CoalescedCallReporter interface {
+	CoalescedCalls() map[string]int64
+}
+
+// CacheStatsReporter reports cache hit and miss counters for each cached method
+type CacheStatsReporter interface {
+	Stats() map[string]services.CacheCounters
+}
+
 // Handler manages HTTP request handlers for the data service
 type Handler struct {
 	riotService services.RiotServiceInterface
+	// Source of circuit breaker state for the health endpoint (optional)
+	circuitBreakerReporter CircuitBreakerReporter
+	// Source of coalesced call counts for the health endpoint (optional)
+	coalescedCallReporter CoalescedCallReporter
+	// Source of cache hit and miss counters for the health endpoint (optional)
+	cacheStatsReporter CacheStatsReporter
+	// Background match history backfills started through the API
+	backfillJobs *services.BackfillJobManager
+	// Number of Account-V1 lookups run concurrently when filling in participants' Riot IDs
+	riotIDLookupParallelism int
 }
 
 // NewHandler creates a new Handler instance
 func NewHandler(riotService services.RiotServiceInterface) *Handler {
 	return &Handler{
-		riotService: riotService,
+		riotService:             riotService,
+		backfillJobs:            services.NewBackfillJobManager(riotService),
+		riotIDLookupParallelism: services.DefaultRiotIDLookupParallelism,
 	}
 }
 
+// SetMatchFetchParallelism sets how many match details each backfill job fetches concurrently
+func (handler *Handler) SetMatchFetchParallelism(parallelism int) {
+	handler.backfillJobs.SetParallelism(parallelism)
+}
+
+// SetRiotIDLookupParallelism sets how many Account-V1 lookups run concurrently when includeRiotIds is set
+func (handler *Handler) SetRiotIDLookupParallelism(parallelism int) {
+	handler.riotIDLookupParallelism = parallelism
+}
+
+// SetCircuitBreakerReporter makes the health endpoint include circuit breaker state
+func (handler *Handler) SetCircuitBreakerReporter(reporter CircuitBreakerReporter) {
+	handler.circuitBreakerReporter = reporter
+}
+
+// SetCoalescedCallReporter makes the health endpoint include coalesced call counts
+func (handler *Handler) SetCoalescedCallReporter(reporter CoalescedCallReporter) {
+	handler.coalescedCallReporter = reporter
+}
+
+// SetCacheStatsReporter makes the health endpoint include cache hit and miss counters
+func (handler *Handler) SetCacheStatsReporter(reporter CacheStatsReporter) {
+	handler.cacheStatsReporter = reporter
+}
+
+// HealthResponse is the body returned by the health endpoint
+type HealthResponse struct {
+	// "healthy", or "degraded" while any Riot routing host's circuit is not closed
+	Status  string `json:"status"`
+	Service string `json:"service"`
+	// Circuit breaker state keyed by Riot routing host
+	CircuitBreakers map[string]services.CircuitBreakerStatus `json:"circuitBreakers,omitempty"`
+	// Calls served by an identical in-flight Riot request, keyed by endpoint
+	CoalescedCalls map[string]int64 `json:"coalescedCalls,omitempty"`
+	// Cache hit and miss counters keyed by cached method
+	Cache map[string]services.CacheCounters `json:"cache,omitempty"`
+}
+
 // HealthCheck handles health check requests
 func (handler *Handler) HealthCheck(writer http.ResponseWriter, request *http.Request) {
-	response := map[string]string{
-		"status":  "healthy",
-		"service": "opgl-data",
+	response := HealthResponse{
+		Status:  "healthy",
+		Service: "opgl-data",
 	}
+
+	if handler.circuitBreakerReporter != nil {
+		response.CircuitBreakers = handler.circuitBreakerReporter.CircuitBreakerStatus()
+		for _, status := range response.CircuitBreakers {
+			if status.State != services.CircuitClosed {
+				response.Status = "degraded"
+			}
+		}
+	}
+
+	if handler.coalescedCallReporter != nil {
+		response.CoalescedCalls = handler.coalescedCallReporter.CoalescedCalls()
+	}
+
+	if handler.cacheStatsReporter != nil {
+		response.Cache = handler.cacheStatsReporter.Stats()
+	}
+
 	writer.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(writer).Encode(response)
 }
@@ -39,19 +125,29 @@ func (handler *Handler) GetSummonerByRiotID(writer http.ResponseWriter, request
 	}
 
 	if err := json.NewDecoder(request.Body).Decode(&summonerRequest); err != nil {
-		http.Error(writer, "Invalid request body", http.StatusBadRequest)
+		writeInvalidBody(writer, request)
 		return
 	}
 
 	// Validate required fields
-	if summonerRequest.Region == "" || summonerRequest.GameName == "" || summonerRequest.TagLine == "" {
-		http.Error(writer, "region, gameName, and tagLine are required", http.StatusBadRequest)
+	if field := firstMissingField(
+		requiredField{"region", summonerRequest.Region},
+		requiredField{"gameName", summonerRequest.GameName},
+		requiredField{"tagLine", summonerRequest.TagLine},
+	); field != "" {
+		writeMissingField(writer, request, field, "region, gameName, and tagLine are required")
+		return
+	}
+
+	region, exists := services.LookupRegion(summonerRequest.Region)
+	if !exists {
+		writeInvalidRegion(writer, request, summonerRequest.Region)
 		return
 	}
 
-	summoner, err := handler.riotService.GetSummonerByRiotID(summonerRequest.Region, summonerRequest.GameName, summonerRequest.TagLine)
+	summoner, err := handler.riotService.GetSummonerByRiotID(request.Context(), region.Code, summonerRequest.GameName, summonerRequest.TagLine)
 	if err != nil {
-		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		writeServiceError(writer, request, err)
 		return
 	}
 
@@ -59,6 +155,44 @@ func (handler *Handler) GetSummonerByRiotID(writer http.ResponseWriter, request
 	json.NewEncoder(writer).Encode(summoner)
 }
 
+// GetAccountByPUUID handles Riot ID lookup by PUUID with JSON body
+func (handler *Handler) GetAccountByPUUID(writer http.ResponseWriter, request *http.Request) {
+	// Parse JSON request body
+	var accountRequest struct {
+		Region string `json:"region"`
+		PUUID  string `json:"puuid"`
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(&accountRequest); err != nil {
+		writeInvalidBody(writer, request)
+		return
+	}
+
+	// Validate required fields
+	if field := firstMissingField(
+		requiredField{"region", accountRequest.Region},
+		requiredField{"puuid", accountRequest.PUUID},
+	); field != "" {
+		writeMissingField(writer, request, field, "region and puuid are required")
+		return
+	}
+
+	region, exists := services.LookupRegion(accountRequest.Region)
+	if !exists {
+		writeInvalidRegion(writer, request, accountRequest.Region)
+		return
+	}
+
+	account, err := handler.riotService.GetAccountByPUUID(request.Context(), region.Code, accountRequest.PUUID)
+	if err != nil {
+		writeServiceError(writer, request, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(account)
+}
+
 // GetMatchesByRiotID handles match history requests using Riot ID or PUUID with JSON body
 func (handler *Handler) GetMatchesByRiotID(writer http.ResponseWriter, request *http.Request) {
 	// Parse JSON request body
@@ -67,86 +201,563 @@ func (handler *Handler) GetMatchesByRiotID(writer http.ResponseWriter, request *
 		GameName string `json:"gameName"`
 		TagLine  string `json:"tagLine"`
 		PUUID    string `json:"puuid"`
-		Count    int    `json:"count"`
+		// Match list filters; ignored when a cursor is given
+		Start     int    `json:"start"`
+		Count     int    `json:"count"`
+		Queue     int    `json:"queue"`
+		Type      string `json:"type"`
+		StartTime int64  `json:"startTime"`
+		EndTime   int64  `json:"endTime"`
+		// Opaque cursor from a previous response's nextCursor
+		Cursor string `json:"cursor"`
+		// Fail the whole request if any match cannot be retrieved
+		Strict bool `json:"strict"`
+		// Look up the Riot ID of participants whose match data does not include one
+		IncludeRiotIDs bool `json:"includeRiotIds"`
 	}
 
 	if err := json.NewDecoder(request.Body).Decode(&matchRequest); err != nil {
-		http.Error(writer, "Invalid request body", http.StatusBadRequest)
+		writeInvalidBody(writer, request)
 		return
 	}
 
 	// Validate required fields - either (gameName + tagLine) OR puuid must be provided
 	if matchRequest.Region == "" {
-		http.Error(writer, "region is required", http.StatusBadRequest)
+		writeMissingField(writer, request, "region", "region is required")
 		return
 	}
 
-	var puuid string
+	region, exists := services.LookupRegion(matchRequest.Region)
+	if !exists {
+		writeInvalidRegion(writer, request, matchRequest.Region)
+		return
+	}
 
-	// If PUUID is provided, use it directly (for internal gateway use)
-	if matchRequest.PUUID != "" {
-		puuid = matchRequest.PUUID
-	} else if matchRequest.GameName != "" && matchRequest.TagLine != "" {
-		// Otherwise, look up PUUID using Riot ID
-		summoner, err := handler.riotService.GetSummonerByRiotID(matchRequest.Region, matchRequest.GameName, matchRequest.TagLine)
+	// Resolve the page before any lookups so invalid filters cost no Riot API calls
+	var options services.MatchListOptions
+	if matchRequest.Cursor != "" {
+		decodedOptions, err := services.DecodeMatchCursor(matchRequest.Cursor)
 		if err != nil {
-			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			writeServiceError(writer, request, err)
 			return
 		}
-		puuid = summoner.PUUID
+		options = decodedOptions
 	} else {
-		http.Error(writer, "either (gameName and tagLine) or puuid is required", http.StatusBadRequest)
-		return
+		options = services.MatchListOptions{
+			Start:     matchRequest.Start,
+			Count:     matchRequest.Count,
+			Queue:     matchRequest.Queue,
+			Type:      matchRequest.Type,
+			StartTime: matchRequest.StartTime,
+			EndTime:   matchRequest.EndTime,
+		}
+		// Set default count if not provided
+		if options.Count == 0 {
+			options.Count = services.DefaultMatchCount
+		}
+		if err := options.Validate(); err != nil {
+			writeServiceError(writer, request, err)
+			return
+		}
 	}
 
-	// Set default count if not provided
-	count := matchRequest.Count
-	if count <= 0 {
-		count = 20
+	puuid, resolved := handler.resolvePUUID(writer, request, region.Code, matchRequest.GameName, matchRequest.TagLine, matchRequest.PUUID)
+	if !resolved {
+		return
 	}
 
 	// Get match history using PUUID
-	matches, err := handler.riotService.GetMatchHistory(matchRequest.Region, puuid, count)
+	matchHistory, err := handler.riotService.GetMatchHistory(request.Context(), region.Code, puuid, options)
 	if err != nil {
-		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		writeServiceError(writer, request, err)
 		return
 	}
 
+	// In strict mode a partial history is an error rather than a degraded success
+	if matchRequest.Strict && len(matchHistory.Failures) > 0 {
+		totalMatches := len(matchHistory.Matches) + len(matchHistory.Failures)
+		message := fmt.Sprintf("failed to retrieve %d of %d matches", len(matchHistory.Failures), totalMatches)
+		writeError(writer, request, http.StatusBadGateway, ErrorCodePartialMatchHistory, message, "")
+		return
+	}
+
+	if matchRequest.IncludeRiotIDs {
+		services.EnrichRiotIDs(request.Context(), handler.riotService, region.Code, matchHistory.Matches, handler.riotIDLookupParallelism)
+	}
+
 	writer.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(writer).Encode(matches)
+	json.NewEncoder(writer).Encode(matchHistory)
 }
 
-// GetRankedStats handles ranked statistics requests using Riot ID with JSON body
+// StartBackfill starts an asynchronous backfill of a player's full match history
+// The response is the new job; poll GetBackfillJob with its ID for progress and results
+func (handler *Handler) StartBackfill(writer http.ResponseWriter, request *http.Request) {
+	var backfillRequest struct {
+		Region   string `json:"region"`
+		GameName string `json:"gameName"`
+		TagLine  string `json:"tagLine"`
+		PUUID    string `json:"puuid"`
+		services.BackfillOptions
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(&backfillRequest); err != nil {
+		writeInvalidBody(writer, request)
+		return
+	}
+
+	if backfillRequest.Region == "" {
+		writeMissingField(writer, request, "region", "region is required")
+		return
+	}
+
+	region, exists := services.LookupRegion(backfillRequest.Region)
+	if !exists {
+		writeInvalidRegion(writer, request, backfillRequest.Region)
+		return
+	}
+
+	// Reject bad filters before spending a Riot ID lookup
+	if err := backfillRequest.BackfillOptions.Validate(); err != nil {
+		writeServiceError(writer, request, err)
+		return
+	}
+
+	puuid, resolved := handler.resolvePUUID(writer, request, region.Code, backfillRequest.GameName, backfillRequest.TagLine, backfillRequest.PUUID)
+	if !resolved {
+		return
+	}
+
+	job, err := handler.backfillJobs.Start(region.Code, puuid, backfillRequest.BackfillOptions)
+	if err != nil {
+		writeServiceError(writer, request, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(writer).Encode(job)
+}
+
+// GetBackfillJob reports a backfill job's progress, and a page of its matches when includeMatches is set
+func (handler *Handler) GetBackfillJob(writer http.ResponseWriter, request *http.Request) {
+	var jobRequest struct {
+		JobID          string `json:"jobId"`
+		IncludeMatches bool   `json:"includeMatches"`
+		services.BackfillResultsPage
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(&jobRequest); err != nil {
+		writeInvalidBody(writer, request)
+		return
+	}
+
+	if jobRequest.JobID == "" {
+		writeMissingField(writer, request, "jobId", "jobId is required")
+		return
+	}
+
+	if err := jobRequest.BackfillResultsPage.Validate(); err != nil {
+		writeServiceError(writer, request, err)
+		return
+	}
+
+	job, exists := handler.backfillJobs.Job(jobRequest.JobID, jobRequest.IncludeMatches, jobRequest.BackfillResultsPage)
+	if !exists {
+		writeError(writer, request, http.StatusNotFound, ErrorCodeNotFound, "backfill job not found", "")
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(job)
+}
+
+// CancelBackfill stops a running backfill job; its checkpoint can be used to resume it later
+func (handler *Handler) CancelBackfill(writer http.ResponseWriter, request *http.Request) {
+	var jobRequest struct {
+		JobID string `json:"jobId"`
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(&jobRequest); err != nil {
+		writeInvalidBody(writer, request)
+		return
+	}
+
+	if jobRequest.JobID == "" {
+		writeMissingField(writer, request, "jobId", "jobId is required")
+		return
+	}
+
+	job, exists := handler.backfillJobs.Cancel(jobRequest.JobID)
+	if !exists {
+		writeError(writer, request, http.StatusNotFound, ErrorCodeNotFound, "backfill job not found", "")
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(job)
+}
+
+// MasteryResponse is the body returned by the mastery endpoint
+type MasteryResponse struct {
+	PUUID string `json:"puuid"`
+	// Total mastery score (sum of champion mastery levels); only for full-list requests or when includeScore is set
+	Score *int `json:"score,omitempty"`
+	// Requested masteries, highest points first
+	Masteries []models.ChampionMastery `json:"masteries"`
+}
+
+// GetChampionMastery handles champion mastery requests using Riot ID or PUUID with JSON body
+// Returns one champion's mastery when championId is set, the top count masteries when count is set, and all otherwise
+// The mastery score costs an extra Riot call, so it is only fetched for the full list or when includeScore is set
+func (handler *Handler) GetChampionMastery(writer http.ResponseWriter, request *http.Request) {
+	var masteryRequest struct {
+		Region     string `json:"region"`
+		GameName   string `json:"gameName"`
+		TagLine    string `json:"tagLine"`
+		PUUID      string `json:"puuid"`
+		ChampionID int    `json:"championId"`
+		Count      int    `json:"count"`
+		// Include the total mastery score with a single-champion or top-N request
+		IncludeScore bool `json:"includeScore"`
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(&masteryRequest); err != nil {
+		writeInvalidBody(writer, request)
+		return
+	}
+
+	if masteryRequest.Region == "" {
+		writeMissingField(writer, request, "region", "region is required")
+		return
+	}
+
+	region, exists := services.LookupRegion(masteryRequest.Region)
+	if !exists {
+		writeInvalidRegion(writer, request, masteryRequest.Region)
+		return
+	}
+
+	if masteryRequest.ChampionID < 0 {
+		writeInvalidField(writer, request, "championId", "championId must not be negative")
+		return
+	}
+
+	if masteryRequest.Count < 0 {
+		writeInvalidField(writer, request, "count", "count must not be negative")
+		return
+	}
+
+	puuid, resolved := handler.resolvePUUID(writer, request, region.Code, masteryRequest.GameName, masteryRequest.TagLine, masteryRequest.PUUID)
+	if !resolved {
+		return
+	}
+
+	ctx := request.Context()
+	var masteries []models.ChampionMastery
+	includeScore := masteryRequest.IncludeScore
+	switch {
+	case masteryRequest.ChampionID > 0:
+		mastery, err := handler.riotService.GetChampionMastery(ctx, region.Code, puuid, masteryRequest.ChampionID)
+		if err != nil {
+			writeServiceError(writer, request, err)
+			return
+		}
+		masteries = []models.ChampionMastery{*mastery}
+	case masteryRequest.Count > 0:
+		topMasteries, err := handler.riotService.GetTopChampionMasteries(ctx, region.Code, puuid, masteryRequest.Count)
+		if err != nil {
+			writeServiceError(writer, request, err)
+			return
+		}
+		masteries = topMasteries
+	default:
+		allMasteries, err := handler.riotService.GetChampionMasteries(ctx, region.Code, puuid)
+		if err != nil {
+			writeServiceError(writer, request, err)
+			return
+		}
+		masteries = allMasteries
+		includeScore = true
+	}
+
+	if masteries == nil {
+		masteries = []models.ChampionMastery{}
+	}
+
+	response := MasteryResponse{
+		PUUID:     puuid,
+		Masteries: masteries,
+	}
+
+	if includeScore {
+		score, err := handler.riotService.GetMasteryScore(ctx, region.Code, puuid)
+		if err != nil {
+			writeServiceError(writer, request, err)
+			return
+		}
+		response.Score = &score
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(response)
+}
+
+// GetLiveGame handles active game requests using Riot ID or PUUID with JSON body
+// Players who are not in a game get a 200 response with inGame set to false
+func (handler *Handler) GetLiveGame(writer http.ResponseWriter, request *http.Request) {
+	var liveGameRequest struct {
+		Region   string `json:"region"`
+		GameName string `json:"gameName"`
+		TagLine  string `json:"tagLine"`
+		PUUID    string `json:"puuid"`
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(&liveGameRequest); err != nil {
+		writeInvalidBody(writer, request)
+		return
+	}
+
+	if liveGameRequest.Region == "" {
+		writeMissingField(writer, request, "region", "region is required")
+		return
+	}
+
+	region, exists := services.LookupRegion(liveGameRequest.Region)
+	if !exists {
+		writeInvalidRegion(writer, request, liveGameRequest.Region)
+		return
+	}
+
+	puuid, resolved := handler.resolvePUUID(writer, request, region.Code, liveGameRequest.GameName, liveGameRequest.TagLine, liveGameRequest.PUUID)
+	if !resolved {
+		return
+	}
+
+	game, err := handler.riotService.GetActiveGame(request.Context(), region.Code, puuid)
+	if err != nil {
+		writeServiceError(writer, request, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(game)
+}
+
+// GetLeaderboard handles ranked ladder requests with JSON body
+// Queue defaults to ranked solo, tier to CHALLENGER, page to 1 and pageSize to services.DefaultLeaderboardPageSize
+func (handler *Handler) GetLeaderboard(writer http.ResponseWriter, request *http.Request) {
+	var leaderboardRequest struct {
+		Region   string `json:"region"`
+		Queue    string `json:"queue"`
+		Tier     string `json:"tier"`
+		Division string `json:"division"`
+		Page     int    `json:"page"`
+		PageSize int    `json:"pageSize"`
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(&leaderboardRequest); err != nil {
+		writeInvalidBody(writer, request)
+		return
+	}
+
+	if leaderboardRequest.Region == "" {
+		writeMissingField(writer, request, "region", "region is required")
+		return
+	}
+
+	region, exists := services.LookupRegion(leaderboardRequest.Region)
+	if !exists {
+		writeInvalidRegion(writer, request, leaderboardRequest.Region)
+		return
+	}
+
+	options := services.LeaderboardOptions{
+		Queue:    strings.ToUpper(leaderboardRequest.Queue),
+		Tier:     strings.ToUpper(leaderboardRequest.Tier),
+		Division: strings.ToUpper(leaderboardRequest.Division),
+		Page:     leaderboardRequest.Page,
+		PageSize: leaderboardRequest.PageSize,
+	}
+	if options.Queue == "" {
+		options.Queue = services.RankedSoloQueue
+	}
+	if options.Tier == "" {
+		options.Tier = services.TierChallenger
+	}
+	if options.Page == 0 {
+		options.Page = 1
+	}
+	if options.PageSize == 0 && services.IsApexTier(options.Tier) {
+		options.PageSize = services.DefaultLeaderboardPageSize
+	}
+
+	leaderboard, err := services.GetLeaderboard(request.Context(), handler.riotService, region.Code, options)
+	if err != nil {
+		writeServiceError(writer, request, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(leaderboard)
+}
+
+// GetChallenges handles player challenge requests using Riot ID or PUUID with JSON body
+// Set includeConfig to join each challenge's name, descriptions (in locale, default en_US) and thresholds
+func (handler *Handler) GetChallenges(writer http.ResponseWriter, request *http.Request) {
+	var challengesRequest struct {
+		Region        string `json:"region"`
+		GameName      string `json:"gameName"`
+		TagLine       string `json:"tagLine"`
+		PUUID         string `json:"puuid"`
+		IncludeConfig bool   `json:"includeConfig"`
+		Locale        string `json:"locale"`
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(&challengesRequest); err != nil {
+		writeInvalidBody(writer, request)
+		return
+	}
+
+	if challengesRequest.Region == "" {
+		writeMissingField(writer, request, "region", "region is required")
+		return
+	}
+
+	region, exists := services.LookupRegion(challengesRequest.Region)
+	if !exists {
+		writeInvalidRegion(writer, request, challengesRequest.Region)
+		return
+	}
+
+	puuid, resolved := handler.resolvePUUID(writer, request, region.Code, challengesRequest.GameName, challengesRequest.TagLine, challengesRequest.PUUID)
+	if !resolved {
+		return
+	}
+
+	player, err := handler.riotService.GetPlayerChallenges(request.Context(), region.Code, puuid)
+	if err != nil {
+		writeServiceError(writer, request, err)
+		return
+	}
+
+	if challengesRequest.IncludeConfig {
+		configs, err := handler.riotService.GetChallengeConfigs(request.Context(), region.Code)
+		if err != nil {
+			writeServiceError(writer, request, err)
+			return
+		}
+
+		locale := challengesRequest.Locale
+		if locale == "" {
+			locale = services.DefaultChallengeLocale
+		}
+		services.JoinChallengeConfigs(player, configs, locale)
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(player)
+}
+
+// resolvePUUID returns the given PUUID, or looks it up from a Riot ID when no PUUID is given
+// On failure the error response has already been written and false is returned
+func (handler *Handler) resolvePUUID(writer http.ResponseWriter, request *http.Request, region string, gameName string, tagLine string, puuid string) (string, bool) {
+	// If PUUID is provided, use it directly (for internal gateway use)
+	if puuid != "" {
+		return puuid, true
+	}
+
+	if gameName == "" || tagLine == "" {
+		// Point at the missing half of a partial Riot ID, otherwise at puuid
+		field := "puuid"
+		if gameName != "" {
+			field = "tagLine"
+		} else if tagLine != "" {
+			field = "gameName"
+		}
+		writeMissingField(writer, request, field, "either (gameName and tagLine) or puuid is required")
+		return "", false
+	}
+
+	// Otherwise, look up PUUID using Riot ID; account-v1 alone is enough, so summoner-v4 is skipped
+	account, err := handler.riotService.GetAccountByRiotID(request.Context(), region, gameName, tagLine)
+	if err != nil {
+		writeServiceError(writer, request, err)
+		return "", false
+	}
+	return account.PUUID, true
+}
+
+// GetMatchTimeline handles match timeline requests by match ID with JSON body
+func (handler *Handler) GetMatchTimeline(writer http.ResponseWriter, request *http.Request) {
+	var timelineRequest struct {
+		Region  string `json:"region"`
+		MatchID string `json:"matchId"`
+	}
+
+	if err := json.NewDecoder(request.Body).Decode(&timelineRequest); err != nil {
+		writeInvalidBody(writer, request)
+		return
+	}
+
+	// Validate required fields
+	if field := firstMissingField(
+		requiredField{"region", timelineRequest.Region},
+		requiredField{"matchId", timelineRequest.MatchID},
+	); field != "" {
+		writeMissingField(writer, request, field, "region and matchId are required")
+		return
+	}
+
+	region, exists := services.LookupRegion(timelineRequest.Region)
+	if !exists {
+		writeInvalidRegion(writer, request, timelineRequest.Region)
+		return
+	}
+
+	timeline, err := handler.riotService.GetMatchTimeline(request.Context(), region.Code, timelineRequest.MatchID)
+	if err != nil {
+		writeServiceError(writer, request, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(timeline)
+}
+
+// GetRankedStats handles ranked statistics requests using Riot ID or PUUID with JSON body
 func (handler *Handler) GetRankedStats(writer http.ResponseWriter, request *http.Request) {
 	// Parse JSON request body
 	var rankedRequest struct {
 		Region   string `json:"region"`
 		GameName string `json:"gameName"`
 		TagLine  string `json:"tagLine"`
+		PUUID    string `json:"puuid"`
 	}
 
 	if err := json.NewDecoder(request.Body).Decode(&rankedRequest); err != nil {
-		http.Error(writer, "Invalid request body", http.StatusBadRequest)
+		writeInvalidBody(writer, request)
 		return
 	}
 
-	// Validate required fields
-	if rankedRequest.Region == "" || rankedRequest.GameName == "" || rankedRequest.TagLine == "" {
-		http.Error(writer, "region, gameName, and tagLine are required", http.StatusBadRequest)
+	if rankedRequest.Region == "" {
+		writeMissingField(writer, request, "region", "region is required")
 		return
 	}
 
-	// Get summoner to obtain encrypted summoner ID
-	summoner, err := handler.riotService.GetSummonerByRiotID(rankedRequest.Region, rankedRequest.GameName, rankedRequest.TagLine)
-	if err != nil {
-		http.Error(writer, err.Error(), http.StatusInternalServerError)
+	region, exists := services.LookupRegion(rankedRequest.Region)
+	if !exists {
+		writeInvalidRegion(writer, request, rankedRequest.Region)
+		return
+	}
+
+	puuid, resolved := handler.resolvePUUID(writer, request, region.Code, rankedRequest.GameName, rankedRequest.TagLine, rankedRequest.PUUID)
+	if !resolved {
 		return
 	}
 
-	// Get ranked stats using encrypted summoner ID
-	rankedStats, err := handler.riotService.GetRankedStats(rankedRequest.Region, summoner.ID)
+	rankedStats, err := handler.riotService.GetRankedStatsByPUUID(request.Context(), region.Code, puuid)
 	if err != nil {
-		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		writeServiceError(writer, request, err)
 		return
 	}
 
@@ -2,47 +2,178 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/OPGLOL/opgl-data-service/internal/models"
+	"github.com/OPGLOL/opgl-data-service/internal/services"
 )
 
 // MockRiotService is a mock implementation of RiotServiceInterface for testing
 type MockRiotService struct {
-	GetSummonerByRiotIDFunc func(region, gameName, tagLine string) (*models.Summoner, error)
-	GetSummonerByPUUIDFunc  func(region, puuid string) (*models.Summoner, error)
-	GetMatchHistoryFunc     func(region, puuid string, count int) ([]models.Match, error)
-	GetMatchDetailsFunc     func(region, matchID string) (*models.Match, error)
+	GetSummonerByRiotIDFunc     func(ctx context.Context, region, gameName, tagLine string) (*models.Summoner, error)
+	GetSummonerByPUUIDFunc      func(ctx context.Context, region, puuid string) (*models.Summoner, error)
+	GetAccountByPUUIDFunc       func(ctx context.Context, region, puuid string) (*models.Account, error)
+	GetAccountByRiotIDFunc      func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error)
+	GetMatchIDsFunc             func(ctx context.Context, region, puuid string, options services.MatchListOptions) ([]string, error)
+	GetMatchHistoryFunc         func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error)
+	GetMatchDetailsFunc         func(ctx context.Context, region, matchID string) (*models.Match, error)
+	GetMatchTimelineFunc        func(ctx context.Context, region, matchID string) (*models.MatchTimeline, error)
+	GetRankedStatsFunc          func(ctx context.Context, region, encryptedSummonerID string) ([]models.RankedStats, error)
+	GetRankedStatsByPUUIDFunc   func(ctx context.Context, region, puuid string) ([]models.RankedStats, error)
+	GetChampionMasteriesFunc    func(ctx context.Context, region, puuid string) ([]models.ChampionMastery, error)
+	GetTopChampionMasteriesFunc func(ctx context.Context, region, puuid string, count int) ([]models.ChampionMastery, error)
+	GetChampionMasteryFunc      func(ctx context.Context, region, puuid string, championID int) (*models.ChampionMastery, error)
+	GetMasteryScoreFunc         func(ctx context.Context, region, puuid string) (int, error)
+	GetActiveGameFunc           func(ctx context.Context, region, puuid string) (*models.ActiveGame, error)
+	GetApexLeagueFunc           func(ctx context.Context, region, queue, tier string) (*models.League, error)
+	GetLeagueEntriesFunc        func(ctx context.Context, region, queue, tier, division string, page int) ([]models.LeagueEntry, error)
+	GetPlayerChallengesFunc     func(ctx context.Context, region, puuid string) (*models.PlayerChallenges, error)
+	GetChallengeConfigsFunc     func(ctx context.Context, region string) ([]models.ChallengeConfig, error)
+	GetChallengeLeaderboardFunc func(ctx context.Context, region string, challengeID int64, level string, limit int) ([]models.ChallengeLeaderboardEntry, error)
 }
 
-func (m *MockRiotService) GetSummonerByRiotID(region, gameName, tagLine string) (*models.Summoner, error) {
+func (m *MockRiotService) GetSummonerByRiotID(ctx context.Context, region, gameName, tagLine string) (*models.Summoner, error) {
 	if m.GetSummonerByRiotIDFunc != nil {
-		return m.GetSummonerByRiotIDFunc(region, gameName, tagLine)
+		return m.GetSummonerByRiotIDFunc(ctx, region, gameName, tagLine)
 	}
 	return nil, nil
 }
 
-func (m *MockRiotService) GetSummonerByPUUID(region, puuid string) (*models.Summoner, error) {
+func (m *MockRiotService) GetSummonerByPUUID(ctx context.Context, region, puuid string) (*models.Summoner, error) {
 	if m.GetSummonerByPUUIDFunc != nil {
-		return m.GetSummonerByPUUIDFunc(region, puuid)
+		return m.GetSummonerByPUUIDFunc(ctx, region, puuid)
 	}
 	return nil, nil
 }
 
-func (m *MockRiotService) GetMatchHistory(region, puuid string, count int) ([]models.Match, error) {
+func (m *MockRiotService) GetAccountByPUUID(ctx context.Context, region, puuid string) (*models.Account, error) {
+	if m.GetAccountByPUUIDFunc != nil {
+		return m.GetAccountByPUUIDFunc(ctx, region, puuid)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetAccountByRiotID(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
+	if m.GetAccountByRiotIDFunc != nil {
+		return m.GetAccountByRiotIDFunc(ctx, region, gameName, tagLine)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetMatchIDs(ctx context.Context, region, puuid string, options services.MatchListOptions) ([]string, error) {
+	if m.GetMatchIDsFunc != nil {
+		return m.GetMatchIDsFunc(ctx, region, puuid, options)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetMatchHistory(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
 	if m.GetMatchHistoryFunc != nil {
-		return m.GetMatchHistoryFunc(region, puuid, count)
+		return m.GetMatchHistoryFunc(ctx, region, puuid, options)
 	}
 	return nil, nil
 }
 
-func (m *MockRiotService) GetMatchDetails(region, matchID string) (*models.Match, error) {
+func (m *MockRiotService) GetMatchDetails(ctx context.Context, region, matchID string) (*models.Match, error) {
 	if m.GetMatchDetailsFunc != nil {
-		return m.GetMatchDetailsFunc(region, matchID)
+		return m.GetMatchDetailsFunc(ctx, region, matchID)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetMatchTimeline(ctx context.Context, region, matchID string) (*models.MatchTimeline, error) {
+	if m.GetMatchTimelineFunc != nil {
+		return m.GetMatchTimelineFunc(ctx, region, matchID)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetRankedStats(ctx context.Context, region, encryptedSummonerID string) ([]models.RankedStats, error) {
+	if m.GetRankedStatsFunc != nil {
+		return m.GetRankedStatsFunc(ctx, region, encryptedSummonerID)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetRankedStatsByPUUID(ctx context.Context, region, puuid string) ([]models.RankedStats, error) {
+	if m.GetRankedStatsByPUUIDFunc != nil {
+		return m.GetRankedStatsByPUUIDFunc(ctx, region, puuid)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetChampionMasteries(ctx context.Context, region, puuid string) ([]models.ChampionMastery, error) {
+	if m.GetChampionMasteriesFunc != nil {
+		return m.GetChampionMasteriesFunc(ctx, region, puuid)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetTopChampionMasteries(ctx context.Context, region, puuid string, count int) ([]models.ChampionMastery, error) {
+	if m.GetTopChampionMasteriesFunc != nil {
+		return m.GetTopChampionMasteriesFunc(ctx, region, puuid, count)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetChampionMastery(ctx context.Context, region, puuid string, championID int) (*models.ChampionMastery, error) {
+	if m.GetChampionMasteryFunc != nil {
+		return m.GetChampionMasteryFunc(ctx, region, puuid, championID)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetMasteryScore(ctx context.Context, region, puuid string) (int, error) {
+	if m.GetMasteryScoreFunc != nil {
+		return m.GetMasteryScoreFunc(ctx, region, puuid)
+	}
+	return 0, nil
+}
+
+func (m *MockRiotService) GetActiveGame(ctx context.Context, region, puuid string) (*models.ActiveGame, error) {
+	if m.GetActiveGameFunc != nil {
+		return m.GetActiveGameFunc(ctx, region, puuid)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetApexLeague(ctx context.Context, region, queue, tier string) (*models.League, error) {
+	if m.GetApexLeagueFunc != nil {
+		return m.GetApexLeagueFunc(ctx, region, queue, tier)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetLeagueEntries(ctx context.Context, region, queue, tier, division string, page int) ([]models.LeagueEntry, error) {
+	if m.GetLeagueEntriesFunc != nil {
+		return m.GetLeagueEntriesFunc(ctx, region, queue, tier, division, page)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetPlayerChallenges(ctx context.Context, region, puuid string) (*models.PlayerChallenges, error) {
+	if m.GetPlayerChallengesFunc != nil {
+		return m.GetPlayerChallengesFunc(ctx, region, puuid)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetChallengeConfigs(ctx context.Context, region string) ([]models.ChallengeConfig, error) {
+	if m.GetChallengeConfigsFunc != nil {
+		return m.GetChallengeConfigsFunc(ctx, region)
+	}
+	return nil, nil
+}
+
+func (m *MockRiotService) GetChallengeLeaderboard(ctx context.Context, region string, challengeID int64, level string, limit int) ([]models.ChallengeLeaderboardEntry, error) {
+	if m.GetChallengeLeaderboardFunc != nil {
+		return m.GetChallengeLeaderboardFunc(ctx, region, challengeID, level, limit)
 	}
 	return nil, nil
 }
@@ -110,6 +241,112 @@ func TestHealthCheckContentType(t *testing.T) {
 	}
 }
 
+// mockCircuitBreakerReporter returns a fixed circuit breaker status
+type mockCircuitBreakerReporter struct {
+	status map[string]services.CircuitBreakerStatus
+}
+
+func (reporter *mockCircuitBreakerReporter) CircuitBreakerStatus() map[string]services.CircuitBreakerStatus {
+	return reporter.status
+}
+
+// TestHealthCheck_CircuitBreakers tests that the health check reports circuit breaker state
+func TestHealthCheck_CircuitBreakers(t *testing.T) {
+	handler := NewHandler(nil)
+	handler.SetCircuitBreakerReporter(&mockCircuitBreakerReporter{
+		status: map[string]services.CircuitBreakerStatus{
+			"na1.api.riotgames.com":    {State: services.CircuitClosed},
+			"europe.api.riotgames.com": {State: services.CircuitOpen, ConsecutiveFailures: 5},
+		},
+	})
+
+	request, _ := http.NewRequest("POST", "/health", nil)
+	responseRecorder := httptest.NewRecorder()
+	handler.HealthCheck(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var response HealthResponse
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if response.Status != "degraded" {
+		t.Errorf("Expected status 'degraded', got '%s'", response.Status)
+	}
+
+	europe := response.CircuitBreakers["europe.api.riotgames.com"]
+	if europe.State != services.CircuitOpen || europe.ConsecutiveFailures != 5 {
+		t.Errorf("Expected europe open with 5 failures, got %+v", europe)
+	}
+}
+
+// mockCoalescedCallReporter returns fixed coalesced call counts
+type mockCoalescedCallReporter struct {
+	calls map[string]int64
+}
+
+func (reporter *mockCoalescedCallReporter) CoalescedCalls() map[string]int64 {
+	return reporter.calls
+}
+
+// TestHealthCheck_CoalescedCalls tests that the health check reports coalesced call counts
+func TestHealthCheck_CoalescedCalls(t *testing.T) {
+	handler := NewHandler(nil)
+	handler.SetCoalescedCallReporter(&mockCoalescedCallReporter{
+		calls: map[string]int64{"summoner-v4.getByPUUID": 3},
+	})
+
+	request, _ := http.NewRequest("POST", "/health", nil)
+	responseRecorder := httptest.NewRecorder()
+	handler.HealthCheck(responseRecorder, request)
+
+	var response HealthResponse
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if response.Status != "healthy" {
+		t.Errorf("Expected status 'healthy', got '%s'", response.Status)
+	}
+
+	if calls := response.CoalescedCalls["summoner-v4.getByPUUID"]; calls != 3 {
+		t.Errorf("Expected 3 coalesced summoner calls, got %d", calls)
+	}
+}
+
+// mockCacheStatsReporter returns fixed cache counters
+type mockCacheStatsReporter struct {
+	stats map[string]services.CacheCounters
+}
+
+func (reporter *mockCacheStatsReporter) Stats() map[string]services.CacheCounters {
+	return reporter.stats
+}
+
+// TestHealthCheck_CacheStats tests that the health check reports cache hit and miss counters
+func TestHealthCheck_CacheStats(t *testing.T) {
+	handler := NewHandler(nil)
+	handler.SetCacheStatsReporter(&mockCacheStatsReporter{
+		stats: map[string]services.CacheCounters{"GetMatchDetails": {Hits: 7, Misses: 2}},
+	})
+
+	request, _ := http.NewRequest("POST", "/health", nil)
+	responseRecorder := httptest.NewRecorder()
+	handler.HealthCheck(responseRecorder, request)
+
+	var response HealthResponse
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if counters := response.Cache["GetMatchDetails"]; counters.Hits != 7 || counters.Misses != 2 {
+		t.Errorf("Expected 7 hits and 2 misses, got %+v", counters)
+	}
+}
+
 // TestGetSummonerByRiotID_Success tests successful summoner lookup
 func TestGetSummonerByRiotID_Success(t *testing.T) {
 	expectedSummoner := &models.Summoner{
@@ -122,7 +359,7 @@ func TestGetSummonerByRiotID_Success(t *testing.T) {
 	}
 
 	mockService := &MockRiotService{
-		GetSummonerByRiotIDFunc: func(region, gameName, tagLine string) (*models.Summoner, error) {
+		GetSummonerByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Summoner, error) {
 			if region != "na" || gameName != "TestPlayer" || tagLine != "NA1" {
 				t.Errorf("Unexpected parameters: region=%s, gameName=%s, tagLine=%s", region, gameName, tagLine)
 			}
@@ -178,20 +415,26 @@ func TestGetSummonerByRiotID_InvalidJSON(t *testing.T) {
 	if responseRecorder.Code != http.StatusBadRequest {
 		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
 	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeInvalidRequestBody {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeInvalidRequestBody, response.Error.Code)
+	}
 }
 
 // TestGetSummonerByRiotID_MissingFields tests missing required fields
 func TestGetSummonerByRiotID_MissingFields(t *testing.T) {
 	testCases := []struct {
-		name        string
-		requestBody map[string]string
+		name          string
+		requestBody   map[string]string
+		expectedField string
 	}{
-		{"missing region", map[string]string{"gameName": "Test", "tagLine": "NA1"}},
-		{"missing gameName", map[string]string{"region": "na", "tagLine": "NA1"}},
-		{"missing tagLine", map[string]string{"region": "na", "gameName": "Test"}},
-		{"empty region", map[string]string{"region": "", "gameName": "Test", "tagLine": "NA1"}},
-		{"empty gameName", map[string]string{"region": "na", "gameName": "", "tagLine": "NA1"}},
-		{"empty tagLine", map[string]string{"region": "na", "gameName": "Test", "tagLine": ""}},
+		{"missing region", map[string]string{"gameName": "Test", "tagLine": "NA1"}, "region"},
+		{"missing gameName", map[string]string{"region": "na", "tagLine": "NA1"}, "gameName"},
+		{"missing tagLine", map[string]string{"region": "na", "gameName": "Test"}, "tagLine"},
+		{"empty region", map[string]string{"region": "", "gameName": "Test", "tagLine": "NA1"}, "region"},
+		{"empty gameName", map[string]string{"region": "na", "gameName": "", "tagLine": "NA1"}, "gameName"},
+		{"empty tagLine", map[string]string{"region": "na", "gameName": "Test", "tagLine": ""}, "tagLine"},
 	}
 
 	handler := NewHandler(&MockRiotService{})
@@ -208,6 +451,15 @@ func TestGetSummonerByRiotID_MissingFields(t *testing.T) {
 			if responseRecorder.Code != http.StatusBadRequest {
 				t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
 			}
+
+			response := decodeErrorResponse(t, responseRecorder)
+			if response.Error.Code != ErrorCodeMissingField {
+				t.Errorf("Expected error code '%s', got '%s'", ErrorCodeMissingField, response.Error.Code)
+			}
+
+			if response.Error.Field != testCase.expectedField {
+				t.Errorf("Expected error field '%s', got '%s'", testCase.expectedField, response.Error.Field)
+			}
 		})
 	}
 }
@@ -215,7 +467,7 @@ func TestGetSummonerByRiotID_MissingFields(t *testing.T) {
 // TestGetSummonerByRiotID_ServiceError tests service error handling
 func TestGetSummonerByRiotID_ServiceError(t *testing.T) {
 	mockService := &MockRiotService{
-		GetSummonerByRiotIDFunc: func(region, gameName, tagLine string) (*models.Summoner, error) {
+		GetSummonerByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Summoner, error) {
 			return nil, errors.New("API error")
 		},
 	}
@@ -238,21 +490,29 @@ func TestGetSummonerByRiotID_ServiceError(t *testing.T) {
 	if responseRecorder.Code != http.StatusInternalServerError {
 		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, responseRecorder.Code)
 	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeInternalError {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeInternalError, response.Error.Code)
+	}
 }
 
 // TestGetMatchesByRiotID_Success tests successful match history lookup with Riot ID
 func TestGetMatchesByRiotID_Success(t *testing.T) {
 	expectedSummoner := &models.Summoner{PUUID: "test-puuid"}
-	expectedMatches := []models.Match{
-		{MatchID: "NA1_123", GameMode: "CLASSIC"},
-		{MatchID: "NA1_124", GameMode: "CLASSIC"},
+	expectedMatches := &models.MatchHistory{
+		Matches: []models.Match{
+			{MatchID: "NA1_123", GameMode: "CLASSIC"},
+			{MatchID: "NA1_124", GameMode: "CLASSIC"},
+		},
+		Failures: []models.MatchFailure{},
 	}
 
 	mockService := &MockRiotService{
-		GetSummonerByRiotIDFunc: func(region, gameName, tagLine string) (*models.Summoner, error) {
-			return expectedSummoner, nil
+		GetAccountByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
+			return &models.Account{PUUID: expectedSummoner.PUUID}, nil
 		},
-		GetMatchHistoryFunc: func(region, puuid string, count int) ([]models.Match, error) {
+		GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
 			if puuid != expectedSummoner.PUUID {
 				t.Errorf("Expected PUUID '%s', got '%s'", expectedSummoner.PUUID, puuid)
 			}
@@ -280,25 +540,27 @@ func TestGetMatchesByRiotID_Success(t *testing.T) {
 		t.Errorf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
 	}
 
-	var response []models.Match
+	var response models.MatchHistory
 	err := json.NewDecoder(responseRecorder.Body).Decode(&response)
 	if err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
-	if len(response) != len(expectedMatches) {
-		t.Errorf("Expected %d matches, got %d", len(expectedMatches), len(response))
+	if len(response.Matches) != len(expectedMatches.Matches) {
+		t.Errorf("Expected %d matches, got %d", len(expectedMatches.Matches), len(response.Matches))
 	}
 }
 
 // TestGetMatchesByRiotID_WithPUUID tests match history lookup with direct PUUID
 func TestGetMatchesByRiotID_WithPUUID(t *testing.T) {
-	expectedMatches := []models.Match{
-		{MatchID: "NA1_123", GameMode: "CLASSIC"},
+	expectedMatches := &models.MatchHistory{
+		Matches: []models.Match{
+			{MatchID: "NA1_123", GameMode: "CLASSIC"},
+		},
 	}
 
 	mockService := &MockRiotService{
-		GetMatchHistoryFunc: func(region, puuid string, count int) ([]models.Match, error) {
+		GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
 			if puuid != "direct-puuid" {
 				t.Errorf("Expected PUUID 'direct-puuid', got '%s'", puuid)
 			}
@@ -331,9 +593,9 @@ func TestGetMatchesByRiotID_DefaultCount(t *testing.T) {
 	var capturedCount int
 
 	mockService := &MockRiotService{
-		GetMatchHistoryFunc: func(region, puuid string, count int) ([]models.Match, error) {
-			capturedCount = count
-			return []models.Match{}, nil
+		GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
+			capturedCount = options.Count
+			return &models.MatchHistory{}, nil
 		},
 	}
 
@@ -356,6 +618,140 @@ func TestGetMatchesByRiotID_DefaultCount(t *testing.T) {
 	}
 }
 
+// TestGetMatchesByRiotID_Filters tests that match list filters are passed to the service
+func TestGetMatchesByRiotID_Filters(t *testing.T) {
+	var capturedOptions services.MatchListOptions
+
+	mockService := &MockRiotService{
+		GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
+			capturedOptions = options
+			return &models.MatchHistory{NextCursor: "next-page"}, nil
+		},
+	}
+
+	handler := NewHandler(mockService)
+
+	requestBody := map[string]interface{}{
+		"region":    "na",
+		"puuid":     "test-puuid",
+		"start":     100,
+		"count":     50,
+		"queue":     420,
+		"type":      "ranked",
+		"startTime": 1700000000,
+		"endTime":   1710000000,
+	}
+	bodyBytes, _ := json.Marshal(requestBody)
+
+	request, _ := http.NewRequest("POST", "/api/v1/matches", bytes.NewBuffer(bodyBytes))
+	responseRecorder := httptest.NewRecorder()
+	handler.GetMatchesByRiotID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	expectedOptions := services.MatchListOptions{Start: 100, Count: 50, Queue: 420, Type: "ranked", StartTime: 1700000000, EndTime: 1710000000}
+	if capturedOptions != expectedOptions {
+		t.Errorf("Expected options %+v, got %+v", expectedOptions, capturedOptions)
+	}
+
+	var response models.MatchHistory
+	json.NewDecoder(responseRecorder.Body).Decode(&response)
+	if response.NextCursor != "next-page" {
+		t.Errorf("Expected nextCursor 'next-page', got '%s'", response.NextCursor)
+	}
+}
+
+// TestGetMatchesByRiotID_Cursor tests that a cursor replaces the filters in the request body
+func TestGetMatchesByRiotID_Cursor(t *testing.T) {
+	cursorOptions := services.MatchListOptions{Start: 20, Count: 20, Queue: 440}
+	var capturedOptions services.MatchListOptions
+
+	mockService := &MockRiotService{
+		GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
+			capturedOptions = options
+			return &models.MatchHistory{}, nil
+		},
+	}
+
+	handler := NewHandler(mockService)
+
+	requestBody := map[string]interface{}{
+		"region": "na",
+		"puuid":  "test-puuid",
+		"count":  5,
+		"cursor": services.EncodeMatchCursor(cursorOptions),
+	}
+	bodyBytes, _ := json.Marshal(requestBody)
+
+	request, _ := http.NewRequest("POST", "/api/v1/matches", bytes.NewBuffer(bodyBytes))
+	responseRecorder := httptest.NewRecorder()
+	handler.GetMatchesByRiotID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	if capturedOptions != cursorOptions {
+		t.Errorf("Expected cursor options %+v, got %+v", cursorOptions, capturedOptions)
+	}
+}
+
+// TestGetMatchesByRiotID_InvalidFilters tests that invalid filters are rejected before any Riot API call
+func TestGetMatchesByRiotID_InvalidFilters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		requestBody   map[string]interface{}
+		expectedField string
+	}{
+		{"count too large", map[string]interface{}{"count": 101}, "count"},
+		{"negative start", map[string]interface{}{"start": -1}, "start"},
+		{"unknown type", map[string]interface{}{"type": "arena"}, "type"},
+		{"end before start", map[string]interface{}{"startTime": 200, "endTime": 100}, "endTime"},
+		{"malformed cursor", map[string]interface{}{"cursor": "not a cursor"}, "cursor"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockService := &MockRiotService{
+				GetAccountByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
+					t.Error("Expected no Riot ID lookup for invalid filters")
+					return nil, nil
+				},
+				GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
+					t.Error("Expected no match history lookup for invalid filters")
+					return nil, nil
+				},
+			}
+
+			handler := NewHandler(mockService)
+
+			testCase.requestBody["region"] = "na"
+			testCase.requestBody["gameName"] = "TestPlayer"
+			testCase.requestBody["tagLine"] = "NA1"
+			bodyBytes, _ := json.Marshal(testCase.requestBody)
+
+			request, _ := http.NewRequest("POST", "/api/v1/matches", bytes.NewBuffer(bodyBytes))
+			responseRecorder := httptest.NewRecorder()
+			handler.GetMatchesByRiotID(responseRecorder, request)
+
+			if responseRecorder.Code != http.StatusBadRequest {
+				t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+			}
+
+			response := decodeErrorResponse(t, responseRecorder)
+			if response.Error.Code != ErrorCodeInvalidField {
+				t.Errorf("Expected error code '%s', got '%s'", ErrorCodeInvalidField, response.Error.Code)
+			}
+
+			if response.Error.Field != testCase.expectedField {
+				t.Errorf("Expected error field '%s', got '%s'", testCase.expectedField, response.Error.Field)
+			}
+		})
+	}
+}
+
 // TestGetMatchesByRiotID_InvalidJSON tests invalid JSON request body
 func TestGetMatchesByRiotID_InvalidJSON(t *testing.T) {
 	handler := NewHandler(&MockRiotService{})
@@ -368,6 +764,11 @@ func TestGetMatchesByRiotID_InvalidJSON(t *testing.T) {
 	if responseRecorder.Code != http.StatusBadRequest {
 		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
 	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeInvalidRequestBody {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeInvalidRequestBody, response.Error.Code)
+	}
 }
 
 // TestGetMatchesByRiotID_MissingRegion tests missing region field
@@ -388,6 +789,15 @@ func TestGetMatchesByRiotID_MissingRegion(t *testing.T) {
 	if responseRecorder.Code != http.StatusBadRequest {
 		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
 	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeMissingField {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeMissingField, response.Error.Code)
+	}
+
+	if response.Error.Field != "region" {
+		t.Errorf("Expected error field 'region', got '%s'", response.Error.Field)
+	}
 }
 
 // TestGetMatchesByRiotID_MissingIdentifiers tests missing both PUUID and Riot ID
@@ -408,12 +818,21 @@ func TestGetMatchesByRiotID_MissingIdentifiers(t *testing.T) {
 	if responseRecorder.Code != http.StatusBadRequest {
 		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
 	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeMissingField {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeMissingField, response.Error.Code)
+	}
+
+	if response.Error.Field != "puuid" {
+		t.Errorf("Expected error field 'puuid', got '%s'", response.Error.Field)
+	}
 }
 
 // TestGetMatchesByRiotID_SummonerLookupError tests error during summoner lookup
 func TestGetMatchesByRiotID_SummonerLookupError(t *testing.T) {
 	mockService := &MockRiotService{
-		GetSummonerByRiotIDFunc: func(region, gameName, tagLine string) (*models.Summoner, error) {
+		GetAccountByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
 			return nil, errors.New("summoner not found")
 		},
 	}
@@ -436,12 +855,17 @@ func TestGetMatchesByRiotID_SummonerLookupError(t *testing.T) {
 	if responseRecorder.Code != http.StatusInternalServerError {
 		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, responseRecorder.Code)
 	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeInternalError {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeInternalError, response.Error.Code)
+	}
 }
 
 // TestGetMatchesByRiotID_MatchHistoryError tests error during match history lookup
 func TestGetMatchesByRiotID_MatchHistoryError(t *testing.T) {
 	mockService := &MockRiotService{
-		GetMatchHistoryFunc: func(region, puuid string, count int) ([]models.Match, error) {
+		GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
 			return nil, errors.New("match history error")
 		},
 	}
@@ -463,4 +887,1025 @@ func TestGetMatchesByRiotID_MatchHistoryError(t *testing.T) {
 	if responseRecorder.Code != http.StatusInternalServerError {
 		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, responseRecorder.Code)
 	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeInternalError {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeInternalError, response.Error.Code)
+	}
+}
+
+// TestGetMatchesByRiotID_PartialRiotID tests that a partial Riot ID points at the missing half
+func TestGetMatchesByRiotID_PartialRiotID(t *testing.T) {
+	testCases := []struct {
+		name          string
+		requestBody   map[string]interface{}
+		expectedField string
+	}{
+		{"missing tagLine", map[string]interface{}{"region": "na", "gameName": "TestPlayer"}, "tagLine"},
+		{"missing gameName", map[string]interface{}{"region": "na", "tagLine": "NA1"}, "gameName"},
+	}
+
+	handler := NewHandler(&MockRiotService{})
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bodyBytes, _ := json.Marshal(testCase.requestBody)
+			request, _ := http.NewRequest("POST", "/api/v1/matches", bytes.NewBuffer(bodyBytes))
+			request.Header.Set("Content-Type", "application/json")
+
+			responseRecorder := httptest.NewRecorder()
+			handler.GetMatchesByRiotID(responseRecorder, request)
+
+			response := decodeErrorResponse(t, responseRecorder)
+			if response.Error.Field != testCase.expectedField {
+				t.Errorf("Expected error field '%s', got '%s'", testCase.expectedField, response.Error.Field)
+			}
+		})
+	}
+}
+
+// TestGetRankedStats_MissingFields tests ranked stats validation errors
+func TestGetRankedStats_MissingFields(t *testing.T) {
+	handler := NewHandler(&MockRiotService{})
+
+	bodyBytes, _ := json.Marshal(map[string]string{"region": "na", "gameName": "TestPlayer"})
+	request, _ := http.NewRequest("POST", "/api/v1/ranked", bytes.NewBuffer(bodyBytes))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseRecorder := httptest.NewRecorder()
+	handler.GetRankedStats(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeMissingField {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeMissingField, response.Error.Code)
+	}
+
+	if response.Error.Field != "tagLine" {
+		t.Errorf("Expected error field 'tagLine', got '%s'", response.Error.Field)
+	}
+}
+
+// TestGetRankedStats_InvalidJSON tests invalid JSON request body for ranked stats
+func TestGetRankedStats_InvalidJSON(t *testing.T) {
+	handler := NewHandler(&MockRiotService{})
+
+	request, _ := http.NewRequest("POST", "/api/v1/ranked", bytes.NewBufferString("invalid json"))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.GetRankedStats(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeInvalidRequestBody {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeInvalidRequestBody, response.Error.Code)
+	}
+}
+
+// TestGetMatchesByRiotID_PropagatesContext tests that the request context reaches the service
+func TestGetMatchesByRiotID_PropagatesContext(t *testing.T) {
+	type contextKey struct{}
+
+	mockService := &MockRiotService{
+		GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
+			if ctx.Value(contextKey{}) != "request-scoped" {
+				t.Error("Expected request context to be passed to the service")
+			}
+			return &models.MatchHistory{}, nil
+		},
+	}
+
+	handler := NewHandler(mockService)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"region": "na", "puuid": "test-puuid"})
+	request, _ := http.NewRequest("POST", "/api/v1/matches", bytes.NewBuffer(bodyBytes))
+	request = request.WithContext(context.WithValue(request.Context(), contextKey{}, "request-scoped"))
+
+	responseRecorder := httptest.NewRecorder()
+	handler.GetMatchesByRiotID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+}
+
+// newPartialMatchHistoryService returns a mock whose match history has one failed match
+func newPartialMatchHistoryService() *MockRiotService {
+	return &MockRiotService{
+		GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
+			return &models.MatchHistory{
+				Matches: []models.Match{{MatchID: "NA1_123"}},
+				Failures: []models.MatchFailure{
+					{MatchID: "NA1_124", Reason: services.FailureReasonUpstreamUnavailable, StatusCode: http.StatusServiceUnavailable},
+				},
+			}, nil
+		},
+	}
+}
+
+// TestGetMatchesByRiotID_ReportsFailures tests that failed matches are returned alongside the matches
+func TestGetMatchesByRiotID_ReportsFailures(t *testing.T) {
+	handler := NewHandler(newPartialMatchHistoryService())
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"region": "na", "puuid": "test-puuid", "count": 2})
+	request, _ := http.NewRequest("POST", "/api/v1/matches", bytes.NewBuffer(bodyBytes))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseRecorder := httptest.NewRecorder()
+	handler.GetMatchesByRiotID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var response models.MatchHistory
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(response.Matches) != 1 {
+		t.Errorf("Expected 1 match, got %d", len(response.Matches))
+	}
+
+	if len(response.Failures) != 1 || response.Failures[0].MatchID != "NA1_124" {
+		t.Errorf("Expected NA1_124 to be reported as failed, got %+v", response.Failures)
+	}
+}
+
+// TestGetMatchesByRiotID_StrictModeFailure tests that strict mode fails the request on any match failure
+func TestGetMatchesByRiotID_StrictModeFailure(t *testing.T) {
+	handler := NewHandler(newPartialMatchHistoryService())
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"region": "na", "puuid": "test-puuid", "count": 2, "strict": true})
+	request, _ := http.NewRequest("POST", "/api/v1/matches", bytes.NewBuffer(bodyBytes))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseRecorder := httptest.NewRecorder()
+	handler.GetMatchesByRiotID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusBadGateway {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadGateway, responseRecorder.Code)
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodePartialMatchHistory {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodePartialMatchHistory, response.Error.Code)
+	}
+
+	if response.Error.Message != "failed to retrieve 1 of 2 matches" {
+		t.Errorf("Expected message 'failed to retrieve 1 of 2 matches', got '%s'", response.Error.Message)
+	}
+}
+
+// TestHandlers_UnknownRegion tests that every endpoint rejects unknown regions with 400 instead of defaulting to NA
+func TestHandlers_UnknownRegion(t *testing.T) {
+	mockService := &MockRiotService{
+		GetSummonerByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Summoner, error) {
+			t.Error("Expected Riot service not to be called for an unknown region")
+			return nil, errors.New("unexpected call")
+		},
+		GetAccountByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
+			t.Error("Expected Riot service not to be called for an unknown region")
+			return nil, errors.New("unexpected call")
+		},
+	}
+	handler := NewHandler(mockService)
+
+	testCases := []struct {
+		name        string
+		path        string
+		handlerFunc http.HandlerFunc
+	}{
+		{"summoner", "/api/v1/summoner", handler.GetSummonerByRiotID},
+		{"account", "/api/v1/account", handler.GetAccountByPUUID},
+		{"matches", "/api/v1/matches", handler.GetMatchesByRiotID},
+		{"ranked", "/api/v1/ranked", handler.GetRankedStats},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bodyBytes, _ := json.Marshal(map[string]string{"region": "eu", "gameName": "TestPlayer", "tagLine": "EUW", "puuid": "test-puuid"})
+			request, _ := http.NewRequest("POST", testCase.path, bytes.NewBuffer(bodyBytes))
+			responseRecorder := httptest.NewRecorder()
+			testCase.handlerFunc(responseRecorder, request)
+
+			if responseRecorder.Code != http.StatusBadRequest {
+				t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+			}
+
+			response := decodeErrorResponse(t, responseRecorder)
+			if response.Error.Code != ErrorCodeInvalidRegion || response.Error.Field != "region" {
+				t.Errorf("Expected INVALID_REGION on region, got %s on '%s'", response.Error.Code, response.Error.Field)
+			}
+		})
+	}
+}
+
+// TestGetSummonerByRiotID_RegionAlias tests that region aliases are normalized to the canonical code
+func TestGetSummonerByRiotID_RegionAlias(t *testing.T) {
+	mockService := &MockRiotService{
+		GetSummonerByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Summoner, error) {
+			if region != "euw" {
+				t.Errorf("Expected canonical region 'euw', got '%s'", region)
+			}
+			return &models.Summoner{PUUID: "test-puuid"}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	bodyBytes, _ := json.Marshal(map[string]string{"region": "EUW1", "gameName": "TestPlayer", "tagLine": "EUW"})
+	request, _ := http.NewRequest("POST", "/api/v1/summoner", bytes.NewBuffer(bodyBytes))
+	responseRecorder := httptest.NewRecorder()
+	handler.GetSummonerByRiotID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+}
+
+// TestGetAccountByPUUID_Success tests successful Riot ID lookup by PUUID
+func TestGetAccountByPUUID_Success(t *testing.T) {
+	mockService := &MockRiotService{
+		GetAccountByPUUIDFunc: func(ctx context.Context, region, puuid string) (*models.Account, error) {
+			if region != "na" || puuid != "test-puuid" {
+				t.Errorf("Unexpected parameters: region=%s, puuid=%s", region, puuid)
+			}
+			return &models.Account{PUUID: puuid, GameName: "TestPlayer", TagLine: "NA1"}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	bodyBytes, _ := json.Marshal(map[string]string{"region": "na", "puuid": "test-puuid"})
+	request, _ := http.NewRequest("POST", "/api/v1/account", bytes.NewBuffer(bodyBytes))
+	responseRecorder := httptest.NewRecorder()
+	handler.GetAccountByPUUID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var response models.Account
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if response.GameName != "TestPlayer" || response.TagLine != "NA1" {
+		t.Errorf("Expected TestPlayer#NA1, got %s#%s", response.GameName, response.TagLine)
+	}
+}
+
+// TestGetAccountByPUUID_MissingPUUID tests validation of the puuid field
+func TestGetAccountByPUUID_MissingPUUID(t *testing.T) {
+	handler := NewHandler(&MockRiotService{})
+
+	bodyBytes, _ := json.Marshal(map[string]string{"region": "na"})
+	request, _ := http.NewRequest("POST", "/api/v1/account", bytes.NewBuffer(bodyBytes))
+	responseRecorder := httptest.NewRecorder()
+	handler.GetAccountByPUUID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Field != "puuid" {
+		t.Errorf("Expected field 'puuid', got '%s'", response.Error.Field)
+	}
+}
+
+// TestGetAccountByPUUID_NotFound tests that an unknown PUUID returns 404
+func TestGetAccountByPUUID_NotFound(t *testing.T) {
+	mockService := &MockRiotService{
+		GetAccountByPUUIDFunc: func(ctx context.Context, region, puuid string) (*models.Account, error) {
+			return nil, &services.RiotAPIError{StatusCode: http.StatusNotFound, Service: "account-v1"}
+		},
+	}
+	handler := NewHandler(mockService)
+
+	bodyBytes, _ := json.Marshal(map[string]string{"region": "na", "puuid": "unknown-puuid"})
+	request, _ := http.NewRequest("POST", "/api/v1/account", bytes.NewBuffer(bodyBytes))
+	responseRecorder := httptest.NewRecorder()
+	handler.GetAccountByPUUID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, responseRecorder.Code)
+	}
+}
+
+// TestGetMatchesByRiotID_IncludeRiotIDs tests enriching participants with Riot IDs
+func TestGetMatchesByRiotID_IncludeRiotIDs(t *testing.T) {
+	mockService := &MockRiotService{
+		GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
+			return &models.MatchHistory{
+				Matches: []models.Match{{
+					MatchID:      "NA1_123",
+					Participants: []models.Participant{{PUUID: "puuid-a"}, {PUUID: "puuid-b", RiotIDGameName: "Known", RiotIDTagline: "KR1"}},
+				}},
+				Failures: []models.MatchFailure{},
+			}, nil
+		},
+		GetAccountByPUUIDFunc: func(ctx context.Context, region, puuid string) (*models.Account, error) {
+			if puuid != "puuid-a" {
+				t.Errorf("Expected only puuid-a to be looked up, got '%s'", puuid)
+			}
+			return &models.Account{PUUID: puuid, GameName: "PlayerA", TagLine: "NA1"}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"region": "na", "puuid": "test-puuid", "includeRiotIds": true})
+	request, _ := http.NewRequest("POST", "/api/v1/matches", bytes.NewBuffer(bodyBytes))
+	responseRecorder := httptest.NewRecorder()
+	handler.GetMatchesByRiotID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var response models.MatchHistory
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	participants := response.Matches[0].Participants
+	if participants[0].RiotIDGameName != "PlayerA" || participants[0].RiotIDTagline != "NA1" {
+		t.Errorf("Expected PlayerA#NA1, got %s#%s", participants[0].RiotIDGameName, participants[0].RiotIDTagline)
+	}
+
+	if participants[1].RiotIDGameName != "Known" {
+		t.Errorf("Expected existing Riot ID to be kept, got '%s'", participants[1].RiotIDGameName)
+	}
+}
+
+// TestGetMatchesByRiotID_RiotIDsNotRequested tests that Riot IDs are only looked up when requested
+func TestGetMatchesByRiotID_RiotIDsNotRequested(t *testing.T) {
+	mockService := &MockRiotService{
+		GetMatchHistoryFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) (*models.MatchHistory, error) {
+			return &models.MatchHistory{
+				Matches:  []models.Match{{MatchID: "NA1_123", Participants: []models.Participant{{PUUID: "puuid-a"}}}},
+				Failures: []models.MatchFailure{},
+			}, nil
+		},
+		GetAccountByPUUIDFunc: func(ctx context.Context, region, puuid string) (*models.Account, error) {
+			t.Error("Expected no account lookups")
+			return nil, errors.New("unexpected call")
+		},
+	}
+	handler := NewHandler(mockService)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"region": "na", "puuid": "test-puuid"})
+	request, _ := http.NewRequest("POST", "/api/v1/matches", bytes.NewBuffer(bodyBytes))
+	responseRecorder := httptest.NewRecorder()
+	handler.GetMatchesByRiotID(responseRecorder, request)
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+}
+
+// postJSON sends a JSON request body to a handler and returns the recorded response
+func postJSON(handlerFunc http.HandlerFunc, path string, requestBody interface{}) *httptest.ResponseRecorder {
+	bodyBytes, _ := json.Marshal(requestBody)
+	request, _ := http.NewRequest("POST", path, bytes.NewBuffer(bodyBytes))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseRecorder := httptest.NewRecorder()
+	handlerFunc(responseRecorder, request)
+	return responseRecorder
+}
+
+// TestBackfill_StartAndPoll tests starting a backfill job and polling it until it completes
+func TestBackfill_StartAndPoll(t *testing.T) {
+	mockService := &MockRiotService{
+		GetAccountByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
+			return &models.Account{PUUID: "test-puuid"}, nil
+		},
+		GetMatchIDsFunc: func(ctx context.Context, region, puuid string, options services.MatchListOptions) ([]string, error) {
+			if puuid != "test-puuid" || options.Queue != 420 {
+				t.Errorf("Expected test-puuid with queue 420, got %s with %+v", puuid, options)
+			}
+			return []string{"NA1_1", "NA1_2"}, nil
+		},
+		GetMatchDetailsFunc: func(ctx context.Context, region, matchID string) (*models.Match, error) {
+			return &models.Match{MatchID: matchID}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	responseRecorder := postJSON(handler.StartBackfill, "/api/v1/backfill", map[string]interface{}{
+		"region":   "na",
+		"gameName": "TestPlayer",
+		"tagLine":  "NA1",
+		"queue":    420,
+	})
+	if responseRecorder.Code != http.StatusAccepted {
+		t.Fatalf("Expected status code %d, got %d", http.StatusAccepted, responseRecorder.Code)
+	}
+
+	var job services.BackfillJob
+	json.NewDecoder(responseRecorder.Body).Decode(&job)
+	if job.ID == "" || job.PUUID != "test-puuid" {
+		t.Fatalf("Expected a job for test-puuid, got %+v", job)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job.Status == services.BackfillJobRunning && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+		responseRecorder = postJSON(handler.GetBackfillJob, "/api/v1/backfill/status", map[string]interface{}{
+			"jobId":          job.ID,
+			"includeMatches": true,
+		})
+		job = services.BackfillJob{}
+		json.NewDecoder(responseRecorder.Body).Decode(&job)
+	}
+
+	if job.Status != services.BackfillJobCompleted {
+		t.Fatalf("Expected completed job, got %+v", job)
+	}
+
+	if job.Progress.Matches != 2 || len(job.Matches) != 2 {
+		t.Errorf("Expected 2 matches, got progress %+v with %d matches", job.Progress, len(job.Matches))
+	}
+}
+
+// TestBackfill_InvalidCheckpoint tests that a malformed checkpoint is rejected before any lookup
+func TestBackfill_InvalidCheckpoint(t *testing.T) {
+	handler := NewHandler(&MockRiotService{
+		GetAccountByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
+			t.Error("Expected no Riot ID lookup for an invalid checkpoint")
+			return nil, nil
+		},
+	})
+
+	responseRecorder := postJSON(handler.StartBackfill, "/api/v1/backfill", map[string]interface{}{
+		"region":     "na",
+		"gameName":   "TestPlayer",
+		"tagLine":    "NA1",
+		"checkpoint": "not a checkpoint",
+	})
+
+	if responseRecorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeInvalidField || response.Error.Field != "checkpoint" {
+		t.Errorf("Expected %s on checkpoint, got %+v", ErrorCodeInvalidField, response.Error)
+	}
+}
+
+// TestBackfill_InvalidResultsPage tests that an out-of-range match page is rejected before the job lookup
+func TestBackfill_InvalidResultsPage(t *testing.T) {
+	handler := NewHandler(&MockRiotService{})
+
+	responseRecorder := postJSON(handler.GetBackfillJob, "/api/v1/backfill/status", map[string]interface{}{
+		"jobId":          "missing",
+		"includeMatches": true,
+		"matchLimit":     services.MaxBackfillResultsPageSize + 1,
+	})
+
+	if responseRecorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeInvalidField || response.Error.Field != "matchLimit" {
+		t.Errorf("Expected %s on matchLimit, got %+v", ErrorCodeInvalidField, response.Error)
+	}
+}
+
+// TestBackfill_UnknownJob tests that status and cancel requests for unknown jobs return 404
+func TestBackfill_UnknownJob(t *testing.T) {
+	handler := NewHandler(&MockRiotService{})
+
+	for path, handlerFunc := range map[string]http.HandlerFunc{
+		"/api/v1/backfill/status": handler.GetBackfillJob,
+		"/api/v1/backfill/cancel": handler.CancelBackfill,
+	} {
+		responseRecorder := postJSON(handlerFunc, path, map[string]interface{}{"jobId": "missing"})
+
+		if responseRecorder.Code != http.StatusNotFound {
+			t.Errorf("Expected %s to return %d, got %d", path, http.StatusNotFound, responseRecorder.Code)
+		}
+	}
+}
+
+// TestGetMatchTimeline_Success tests timeline lookup by match ID
+func TestGetMatchTimeline_Success(t *testing.T) {
+	mockService := &MockRiotService{
+		GetMatchTimelineFunc: func(ctx context.Context, region, matchID string) (*models.MatchTimeline, error) {
+			if region != "euw" || matchID != "EUW1_123" {
+				t.Errorf("Expected euw and EUW1_123, got %s and %s", region, matchID)
+			}
+			return &models.MatchTimeline{MatchID: matchID, FrameInterval: 60000}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	responseRecorder := postJSON(handler.GetMatchTimeline, "/api/v1/matches/timeline", map[string]interface{}{
+		"region":  "EUW1",
+		"matchId": "EUW1_123",
+	})
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var timeline models.MatchTimeline
+	json.NewDecoder(responseRecorder.Body).Decode(&timeline)
+	if timeline.MatchID != "EUW1_123" || timeline.FrameInterval != 60000 {
+		t.Errorf("Expected timeline for EUW1_123, got %+v", timeline)
+	}
+}
+
+// TestGetMatchTimeline_MissingMatchID tests that matchId is required
+func TestGetMatchTimeline_MissingMatchID(t *testing.T) {
+	handler := NewHandler(&MockRiotService{})
+
+	responseRecorder := postJSON(handler.GetMatchTimeline, "/api/v1/matches/timeline", map[string]interface{}{"region": "na"})
+
+	if responseRecorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Field != "matchId" {
+		t.Errorf("Expected error field 'matchId', got '%s'", response.Error.Field)
+	}
+}
+
+// TestGetMatchTimeline_NotFound tests that a missing match maps to 404
+func TestGetMatchTimeline_NotFound(t *testing.T) {
+	handler := NewHandler(&MockRiotService{
+		GetMatchTimelineFunc: func(ctx context.Context, region, matchID string) (*models.MatchTimeline, error) {
+			return nil, &services.RiotAPIError{StatusCode: http.StatusNotFound, Service: "match-v5"}
+		},
+	})
+
+	responseRecorder := postJSON(handler.GetMatchTimeline, "/api/v1/matches/timeline", map[string]interface{}{
+		"region":  "na",
+		"matchId": "NA1_404",
+	})
+
+	if responseRecorder.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, responseRecorder.Code)
+	}
+}
+
+// masteryMockService returns a mock that serves every mastery lookup for test-puuid
+func masteryMockService(t *testing.T) *MockRiotService {
+	return &MockRiotService{
+		GetChampionMasteriesFunc: func(ctx context.Context, region, puuid string) ([]models.ChampionMastery, error) {
+			return []models.ChampionMastery{{PUUID: puuid, ChampionID: 103}, {PUUID: puuid, ChampionID: 222}}, nil
+		},
+		GetTopChampionMasteriesFunc: func(ctx context.Context, region, puuid string, count int) ([]models.ChampionMastery, error) {
+			if count != 1 {
+				t.Errorf("Expected count 1, got %d", count)
+			}
+			return []models.ChampionMastery{{PUUID: puuid, ChampionID: 103}}, nil
+		},
+		GetChampionMasteryFunc: func(ctx context.Context, region, puuid string, championID int) (*models.ChampionMastery, error) {
+			return &models.ChampionMastery{PUUID: puuid, ChampionID: championID}, nil
+		},
+		GetMasteryScoreFunc: func(ctx context.Context, region, puuid string) (int, error) {
+			if region != "na" || puuid != "test-puuid" {
+				t.Errorf("Expected na and test-puuid, got %s and %s", region, puuid)
+			}
+			return 300, nil
+		},
+	}
+}
+
+// TestGetChampionMastery_Modes tests that championId and count select the single and top-N lookups
+// and that the score is only fetched for the full list or when includeScore is set
+func TestGetChampionMastery_Modes(t *testing.T) {
+	testCases := []struct {
+		name                string
+		body                map[string]interface{}
+		expectedChampionIDs []int
+		expectScore         bool
+	}{
+		{"all", map[string]interface{}{"region": "na", "puuid": "test-puuid"}, []int{103, 222}, true},
+		{"top", map[string]interface{}{"region": "na", "puuid": "test-puuid", "count": 1}, []int{103}, false},
+		{"single", map[string]interface{}{"region": "na", "puuid": "test-puuid", "championId": 222}, []int{222}, false},
+		{"single with score", map[string]interface{}{"region": "na", "puuid": "test-puuid", "championId": 222, "includeScore": true}, []int{222}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockService := masteryMockService(t)
+			scoreLookups := 0
+			scoreLookup := mockService.GetMasteryScoreFunc
+			mockService.GetMasteryScoreFunc = func(ctx context.Context, region, puuid string) (int, error) {
+				scoreLookups++
+				return scoreLookup(ctx, region, puuid)
+			}
+			handler := NewHandler(mockService)
+
+			responseRecorder := postJSON(handler.GetChampionMastery, "/api/v1/mastery", testCase.body)
+
+			if responseRecorder.Code != http.StatusOK {
+				t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+			}
+
+			var response MasteryResponse
+			json.NewDecoder(responseRecorder.Body).Decode(&response)
+
+			if response.PUUID != "test-puuid" {
+				t.Errorf("Expected test-puuid, got '%s'", response.PUUID)
+			}
+
+			if testCase.expectScore && (response.Score == nil || *response.Score != 300) {
+				t.Errorf("Expected score 300, got %v", response.Score)
+			}
+			if !testCase.expectScore && (response.Score != nil || scoreLookups != 0) {
+				t.Errorf("Expected no score lookup, got %d lookups and score %v", scoreLookups, response.Score)
+			}
+
+			if len(response.Masteries) != len(testCase.expectedChampionIDs) {
+				t.Fatalf("Expected %d masteries, got %d", len(testCase.expectedChampionIDs), len(response.Masteries))
+			}
+			for i, championID := range testCase.expectedChampionIDs {
+				if response.Masteries[i].ChampionID != championID {
+					t.Errorf("Expected champion %d at index %d, got %d", championID, i, response.Masteries[i].ChampionID)
+				}
+			}
+		})
+	}
+}
+
+// TestGetChampionMastery_RiotID tests that a Riot ID is resolved to a PUUID before the lookup
+func TestGetChampionMastery_RiotID(t *testing.T) {
+	mockService := masteryMockService(t)
+	mockService.GetAccountByRiotIDFunc = func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
+		return &models.Account{PUUID: "test-puuid"}, nil
+	}
+	handler := NewHandler(mockService)
+
+	responseRecorder := postJSON(handler.GetChampionMastery, "/api/v1/mastery", map[string]interface{}{
+		"region":   "na",
+		"gameName": "TestPlayer",
+		"tagLine":  "NA1",
+	})
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var response MasteryResponse
+	json.NewDecoder(responseRecorder.Body).Decode(&response)
+	if response.PUUID != "test-puuid" {
+		t.Errorf("Expected resolved PUUID test-puuid, got '%s'", response.PUUID)
+	}
+}
+
+// TestGetChampionMastery_InvalidFields tests rejection of negative championId and count
+func TestGetChampionMastery_InvalidFields(t *testing.T) {
+	for _, field := range []string{"championId", "count"} {
+		handler := NewHandler(&MockRiotService{})
+
+		responseRecorder := postJSON(handler.GetChampionMastery, "/api/v1/mastery", map[string]interface{}{
+			"region": "na",
+			"puuid":  "test-puuid",
+			field:    -1,
+		})
+
+		if responseRecorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for negative %s, got %d", http.StatusBadRequest, field, responseRecorder.Code)
+		}
+
+		response := decodeErrorResponse(t, responseRecorder)
+		if response.Error.Code != ErrorCodeInvalidField || response.Error.Field != field {
+			t.Errorf("Expected %s on '%s', got %s on '%s'", ErrorCodeInvalidField, field, response.Error.Code, response.Error.Field)
+		}
+	}
+}
+
+// TestGetLiveGame_Success tests an active game lookup by PUUID
+func TestGetLiveGame_Success(t *testing.T) {
+	mockService := &MockRiotService{
+		GetActiveGameFunc: func(ctx context.Context, region, puuid string) (*models.ActiveGame, error) {
+			if region != "kr" || puuid != "test-puuid" {
+				t.Errorf("Expected kr and test-puuid, got %s and %s", region, puuid)
+			}
+			return &models.ActiveGame{InGame: true, GameID: 42, Participants: []models.ActiveGameParticipant{{PUUID: puuid}}}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	responseRecorder := postJSON(handler.GetLiveGame, "/api/v1/live", map[string]interface{}{"region": "kr", "puuid": "test-puuid"})
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var game models.ActiveGame
+	json.NewDecoder(responseRecorder.Body).Decode(&game)
+	if !game.InGame || game.GameID != 42 || len(game.Participants) != 1 {
+		t.Errorf("Unexpected game: %+v", game)
+	}
+}
+
+// TestGetLiveGame_NotInGame tests that a player who is not in game gets a 200 with inGame false
+func TestGetLiveGame_NotInGame(t *testing.T) {
+	mockService := &MockRiotService{
+		GetAccountByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
+			return &models.Account{PUUID: "test-puuid"}, nil
+		},
+		GetActiveGameFunc: func(ctx context.Context, region, puuid string) (*models.ActiveGame, error) {
+			return &models.ActiveGame{Bans: []models.ActiveGameBan{}, Participants: []models.ActiveGameParticipant{}}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	responseRecorder := postJSON(handler.GetLiveGame, "/api/v1/live", map[string]interface{}{
+		"region":   "na",
+		"gameName": "TestPlayer",
+		"tagLine":  "NA1",
+	})
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var fields map[string]interface{}
+	json.NewDecoder(responseRecorder.Body).Decode(&fields)
+	if fields["inGame"] != false {
+		t.Errorf("Expected inGame false, got %v", fields["inGame"])
+	}
+}
+
+// TestGetLiveGame_MissingRegion tests that region is required
+func TestGetLiveGame_MissingRegion(t *testing.T) {
+	handler := NewHandler(&MockRiotService{})
+
+	responseRecorder := postJSON(handler.GetLiveGame, "/api/v1/live", map[string]interface{}{"puuid": "test-puuid"})
+
+	if responseRecorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Field != "region" {
+		t.Errorf("Expected error field 'region', got '%s'", response.Error.Field)
+	}
+}
+
+// TestGetLeaderboard_Defaults tests that queue, tier, page and pageSize default to the solo Challenger ladder
+func TestGetLeaderboard_Defaults(t *testing.T) {
+	mockService := &MockRiotService{
+		GetApexLeagueFunc: func(ctx context.Context, region, queue, tier string) (*models.League, error) {
+			if region != "na" || queue != services.RankedSoloQueue || tier != services.TierChallenger {
+				t.Errorf("Expected na solo Challenger league, got %s %s %s", region, queue, tier)
+			}
+			return &models.League{Entries: []models.LeagueEntry{{PUUID: "b", LeaguePoints: 100}, {PUUID: "a", LeaguePoints: 300}}}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	responseRecorder := postJSON(handler.GetLeaderboard, "/api/v1/leaderboard", map[string]interface{}{"region": "na"})
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var leaderboard models.Leaderboard
+	json.NewDecoder(responseRecorder.Body).Decode(&leaderboard)
+
+	if leaderboard.Page != 1 || leaderboard.PageSize != services.DefaultLeaderboardPageSize || leaderboard.Total != 2 {
+		t.Errorf("Unexpected leaderboard: %+v", leaderboard)
+	}
+	if len(leaderboard.Entries) != 2 || leaderboard.Entries[0].PUUID != "a" {
+		t.Errorf("Expected entries sorted by league points, got %+v", leaderboard.Entries)
+	}
+}
+
+// TestGetLeaderboard_Division tests a lowercase division tier request
+func TestGetLeaderboard_Division(t *testing.T) {
+	mockService := &MockRiotService{
+		GetLeagueEntriesFunc: func(ctx context.Context, region, queue, tier, division string, page int) ([]models.LeagueEntry, error) {
+			if queue != services.RankedFlexQueue || tier != "DIAMOND" || division != "III" || page != 2 {
+				t.Errorf("Expected flex DIAMOND III page 2, got %s %s %s %d", queue, tier, division, page)
+			}
+			return []models.LeagueEntry{{PUUID: "a"}}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	responseRecorder := postJSON(handler.GetLeaderboard, "/api/v1/leaderboard", map[string]interface{}{
+		"region":   "na",
+		"queue":    "ranked_flex_sr",
+		"tier":     "diamond",
+		"division": "iii",
+		"page":     2,
+	})
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+}
+
+// TestGetLeaderboard_DivisionPageSize tests that pageSize is rejected for division tiers instead of being ignored
+func TestGetLeaderboard_DivisionPageSize(t *testing.T) {
+	handler := NewHandler(&MockRiotService{
+		GetLeagueEntriesFunc: func(ctx context.Context, region, queue, tier, division string, page int) ([]models.LeagueEntry, error) {
+			t.Error("Expected no league lookup for an invalid pageSize")
+			return nil, nil
+		},
+	})
+
+	responseRecorder := postJSON(handler.GetLeaderboard, "/api/v1/leaderboard", map[string]interface{}{
+		"region":   "na",
+		"tier":     "GOLD",
+		"division": "I",
+		"pageSize": 10,
+	})
+
+	if responseRecorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeInvalidField || response.Error.Field != "pageSize" {
+		t.Errorf("Expected %s on pageSize, got %+v", ErrorCodeInvalidField, response.Error)
+	}
+}
+
+// TestGetLeaderboard_InvalidOptions tests that invalid options are reported against their field
+func TestGetLeaderboard_InvalidOptions(t *testing.T) {
+	testCases := map[string]map[string]interface{}{
+		"queue":    {"region": "na", "queue": "RANKED_TFT"},
+		"division": {"region": "na", "tier": "GOLD"},
+		"pageSize": {"region": "na", "pageSize": 1000},
+		"page":     {"region": "na", "page": -1},
+	}
+
+	for field, body := range testCases {
+		handler := NewHandler(&MockRiotService{})
+
+		responseRecorder := postJSON(handler.GetLeaderboard, "/api/v1/leaderboard", body)
+
+		if responseRecorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for invalid %s, got %d", http.StatusBadRequest, field, responseRecorder.Code)
+		}
+
+		response := decodeErrorResponse(t, responseRecorder)
+		if response.Error.Code != ErrorCodeInvalidField || response.Error.Field != field {
+			t.Errorf("Expected %s on '%s', got %s on '%s'", ErrorCodeInvalidField, field, response.Error.Code, response.Error.Field)
+		}
+	}
+}
+
+// TestGetRankedStats_PUUID tests that a PUUID is used directly without a summoner lookup
+func TestGetRankedStats_PUUID(t *testing.T) {
+	mockService := &MockRiotService{
+		GetAccountByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
+			t.Error("Expected no Riot ID lookup when puuid is given")
+			return nil, nil
+		},
+		GetRankedStatsByPUUIDFunc: func(ctx context.Context, region, puuid string) ([]models.RankedStats, error) {
+			if region != "euw" || puuid != "test-puuid" {
+				t.Errorf("Expected euw and test-puuid, got %s and %s", region, puuid)
+			}
+			return []models.RankedStats{{QueueType: "RANKED_SOLO_5x5", Wins: 3, Losses: 1, WinRate: 75, HotStreak: true}}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	responseRecorder := postJSON(handler.GetRankedStats, "/api/v1/ranked", map[string]interface{}{"region": "euw", "puuid": "test-puuid"})
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var response struct {
+		RankedStats []models.RankedStats `json:"rankedStats"`
+	}
+	json.NewDecoder(responseRecorder.Body).Decode(&response)
+
+	if len(response.RankedStats) != 1 || response.RankedStats[0].WinRate != 75 || !response.RankedStats[0].HotStreak {
+		t.Errorf("Unexpected ranked stats: %+v", response.RankedStats)
+	}
+}
+
+// TestGetRankedStats_RiotID tests that a Riot ID is resolved to a PUUID for the lookup
+func TestGetRankedStats_RiotID(t *testing.T) {
+	mockService := &MockRiotService{
+		GetAccountByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Account, error) {
+			return &models.Account{PUUID: "resolved-puuid"}, nil
+		},
+		GetSummonerByRiotIDFunc: func(ctx context.Context, region, gameName, tagLine string) (*models.Summoner, error) {
+			t.Error("Expected no summoner-v4 lookup when only the PUUID is needed")
+			return nil, nil
+		},
+		GetRankedStatsByPUUIDFunc: func(ctx context.Context, region, puuid string) ([]models.RankedStats, error) {
+			if puuid != "resolved-puuid" {
+				t.Errorf("Expected resolved-puuid, got %s", puuid)
+			}
+			return []models.RankedStats{}, nil
+		},
+	}
+	handler := NewHandler(mockService)
+
+	responseRecorder := postJSON(handler.GetRankedStats, "/api/v1/ranked", map[string]interface{}{
+		"region":   "na",
+		"gameName": "TestPlayer",
+		"tagLine":  "NA1",
+	})
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+}
+
+// challengesMockService returns a mock serving one challenge and its config
+func challengesMockService(t *testing.T, configCalls *int) *MockRiotService {
+	return &MockRiotService{
+		GetPlayerChallengesFunc: func(ctx context.Context, region, puuid string) (*models.PlayerChallenges, error) {
+			if region != "na" || puuid != "test-puuid" {
+				t.Errorf("Expected na and test-puuid, got %s and %s", region, puuid)
+			}
+			return &models.PlayerChallenges{PUUID: puuid, Challenges: []models.ChallengeProgress{{ChallengeID: 101101, Level: "GOLD", Value: 5}}}, nil
+		},
+		GetChallengeConfigsFunc: func(ctx context.Context, region string) ([]models.ChallengeConfig, error) {
+			*configCalls++
+			return []models.ChallengeConfig{{
+				ID:             101101,
+				LocalizedNames: map[string]models.ChallengeName{"en_US": {Name: "Damage Dealer"}, "fr_FR": {Name: "Dégâts"}},
+				Thresholds:     map[string]float64{"GOLD": 5},
+			}}, nil
+		},
+	}
+}
+
+// TestGetChallenges_ProgressOnly tests that configs are not fetched unless requested
+func TestGetChallenges_ProgressOnly(t *testing.T) {
+	configCalls := 0
+	handler := NewHandler(challengesMockService(t, &configCalls))
+
+	responseRecorder := postJSON(handler.GetChallenges, "/api/v1/challenges", map[string]interface{}{"region": "na", "puuid": "test-puuid"})
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var player models.PlayerChallenges
+	json.NewDecoder(responseRecorder.Body).Decode(&player)
+
+	if len(player.Challenges) != 1 || player.Challenges[0].Level != "GOLD" || player.Challenges[0].Name != "" {
+		t.Errorf("Expected progress without config fields, got %+v", player.Challenges)
+	}
+	if configCalls != 0 {
+		t.Errorf("Expected no config lookup, got %d", configCalls)
+	}
+}
+
+// TestGetChallenges_IncludeConfig tests that config names (in the requested locale) and thresholds are joined
+func TestGetChallenges_IncludeConfig(t *testing.T) {
+	configCalls := 0
+	handler := NewHandler(challengesMockService(t, &configCalls))
+
+	responseRecorder := postJSON(handler.GetChallenges, "/api/v1/challenges", map[string]interface{}{
+		"region":        "na",
+		"puuid":         "test-puuid",
+		"includeConfig": true,
+		"locale":        "fr_FR",
+	})
+
+	if responseRecorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, responseRecorder.Code)
+	}
+
+	var player models.PlayerChallenges
+	json.NewDecoder(responseRecorder.Body).Decode(&player)
+
+	challenge := player.Challenges[0]
+	if challenge.Name != "Dégâts" || challenge.Thresholds["GOLD"] != 5 {
+		t.Errorf("Expected joined name and thresholds, got %+v", challenge)
+	}
+	if configCalls != 1 {
+		t.Errorf("Expected 1 config lookup, got %d", configCalls)
+	}
+}
+
+// TestGetChallenges_MissingPlayer tests that a player must be identified
+func TestGetChallenges_MissingPlayer(t *testing.T) {
+	handler := NewHandler(&MockRiotService{})
+
+	responseRecorder := postJSON(handler.GetChallenges, "/api/v1/challenges", map[string]interface{}{"region": "na"})
+
+	if responseRecorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, responseRecorder.Code)
+	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeMissingField || response.Error.Field != "puuid" {
+		t.Errorf("Expected %s on 'puuid', got %s on '%s'", ErrorCodeMissingField, response.Error.Code, response.Error.Field)
+	}
 }
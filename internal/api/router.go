@@ -1,6 +1,8 @@
 package api
 
 import (
+	"net/http"
+
 	"github.com/gorilla/mux"
 )
 
@@ -13,8 +15,21 @@ func SetupRouter(handler *Handler) *mux.Router {
 
 	// Data endpoints
 	router.HandleFunc("/api/v1/summoner", handler.GetSummonerByRiotID).Methods("POST")
+	router.HandleFunc("/api/v1/account", handler.GetAccountByPUUID).Methods("POST")
 	router.HandleFunc("/api/v1/matches", handler.GetMatchesByRiotID).Methods("POST")
+	router.HandleFunc("/api/v1/matches/timeline", handler.GetMatchTimeline).Methods("POST")
 	router.HandleFunc("/api/v1/ranked", handler.GetRankedStats).Methods("POST")
+	router.HandleFunc("/api/v1/mastery", handler.GetChampionMastery).Methods("POST")
+	router.HandleFunc("/api/v1/live", handler.GetLiveGame).Methods("POST")
+	router.HandleFunc("/api/v1/leaderboard", handler.GetLeaderboard).Methods("POST")
+	router.HandleFunc("/api/v1/challenges", handler.GetChallenges).Methods("POST")
+	router.HandleFunc("/api/v1/backfill", handler.StartBackfill).Methods("POST")
+	router.HandleFunc("/api/v1/backfill/status", handler.GetBackfillJob).Methods("POST")
+	router.HandleFunc("/api/v1/backfill/cancel", handler.CancelBackfill).Methods("POST")
+
+	// JSON error envelopes for unknown routes and unsupported methods
+	router.NotFoundHandler = http.HandlerFunc(RouteNotFound)
+	router.MethodNotAllowedHandler = http.HandlerFunc(MethodNotAllowed)
 
 	return router
 }
@@ -54,6 +54,11 @@ func TestSetupRouter_HealthEndpoint_WrongMethod(t *testing.T) {
 	if responseRecorder.Code != http.StatusMethodNotAllowed {
 		t.Errorf("Expected status code %d, got %d", http.StatusMethodNotAllowed, responseRecorder.Code)
 	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeMethodNotAllowed {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeMethodNotAllowed, response.Error.Code)
+	}
 }
 
 // TestSetupRouter_SummonerEndpoint tests the summoner endpoint is registered
@@ -78,6 +83,27 @@ func TestSetupRouter_SummonerEndpoint(t *testing.T) {
 	}
 }
 
+// TestSetupRouter_AccountEndpoint tests the account endpoint is registered
+func TestSetupRouter_AccountEndpoint(t *testing.T) {
+	mockService := &MockRiotService{}
+	handler := NewHandler(mockService)
+	router := SetupRouter(handler)
+
+	request, err := http.NewRequest("POST", "/api/v1/account", bytes.NewBufferString("{}"))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	responseRecorder := httptest.NewRecorder()
+	router.ServeHTTP(responseRecorder, request)
+
+	// Should get 400 because required fields missing, not 404
+	if responseRecorder.Code == http.StatusNotFound {
+		t.Error("Account endpoint not found - route not registered")
+	}
+}
+
 // TestSetupRouter_MatchesEndpoint tests the matches endpoint is registered
 func TestSetupRouter_MatchesEndpoint(t *testing.T) {
 	mockService := &MockRiotService{}
@@ -100,6 +126,28 @@ func TestSetupRouter_MatchesEndpoint(t *testing.T) {
 	}
 }
 
+// TestSetupRouter_BackfillEndpoints tests the backfill endpoints are registered
+func TestSetupRouter_BackfillEndpoints(t *testing.T) {
+	handler := NewHandler(&MockRiotService{})
+	router := SetupRouter(handler)
+
+	for _, path := range []string{"/api/v1/backfill", "/api/v1/backfill/status", "/api/v1/backfill/cancel"} {
+		request, err := http.NewRequest("POST", path, bytes.NewBufferString("{}"))
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+
+		responseRecorder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecorder, request)
+
+		// Should get 400 because required fields missing, not 404
+		if responseRecorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected %s to reject an empty body with %d, got %d", path, http.StatusBadRequest, responseRecorder.Code)
+		}
+	}
+}
+
 // TestSetupRouter_NotFoundEndpoint tests unknown endpoints return 404
 func TestSetupRouter_NotFoundEndpoint(t *testing.T) {
 	mockService := &MockRiotService{}
@@ -117,4 +165,9 @@ func TestSetupRouter_NotFoundEndpoint(t *testing.T) {
 	if responseRecorder.Code != http.StatusNotFound {
 		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, responseRecorder.Code)
 	}
+
+	response := decodeErrorResponse(t, responseRecorder)
+	if response.Error.Code != ErrorCodeRouteNotFound {
+		t.Errorf("Expected error code '%s', got '%s'", ErrorCodeRouteNotFound, response.Error.Code)
+	}
 }
@@ -0,0 +1,15 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores serialized values by key
+// Implementations must be safe for concurrent use
+type Cache interface {
+	// Get returns the value stored under key and whether it was found
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl (0 means no expiry)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
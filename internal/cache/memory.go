@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry is a single cached value
+type memoryEntry struct {
+	key   string
+	value []byte
+	// Time after which the entry is stale (zero means it never expires)
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process Cache that evicts the least recently used entry when full
+// Entries also expire after their TTL
+type MemoryCache struct {
+	mutex sync.Mutex
+	// Maximum number of entries before eviction (0 means unbounded)
+	maxEntries int
+	// Usage order, most recently used at the front
+	order *list.List
+	// Entries by key
+	entries map[string]*list.Element
+	// Clock used for expiry (overridable for testing)
+	now func() time.Time
+}
+
+// NewMemoryCache creates an empty in-memory cache holding at most maxEntries entries
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+// Get returns the value for key if present and not expired
+func (cache *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, exists := cache.entries[key]
+	if !exists {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if !entry.expiresAt.IsZero() && !cache.now().Before(entry.expiresAt) {
+		cache.removeElement(element)
+		return nil, false, nil
+	}
+
+	cache.order.MoveToFront(element)
+	return append([]byte(nil), entry.value...), true, nil
+}
+
+// Set stores value under key for ttl (0 means no expiry), evicting the least recently used entry if full
+func (cache *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = cache.now().Add(ttl)
+	}
+
+	// Copy so callers cannot modify the stored value
+	value = append([]byte(nil), value...)
+
+	if element, exists := cache.entries[key]; exists {
+		entry := element.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		cache.order.MoveToFront(element)
+		return nil
+	}
+
+	element := cache.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	cache.entries[key] = element
+
+	if cache.maxEntries > 0 && cache.order.Len() > cache.maxEntries {
+		cache.removeElement(cache.order.Back())
+	}
+
+	return nil
+}
+
+// Len returns the number of entries in the cache, including expired ones not yet removed
+func (cache *MemoryCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.order.Len()
+}
+
+// removeElement removes an entry; the caller must hold the mutex
+func (cache *MemoryCache) removeElement(element *list.Element) {
+	cache.order.Remove(element)
+	delete(cache.entries, element.Value.(*memoryEntry).key)
+}
+
+// Verify MemoryCache implements Cache
+var _ Cache = (*MemoryCache)(nil)
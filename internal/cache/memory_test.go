@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestMemoryCache_GetSet tests storing and retrieving values
+func TestMemoryCache_GetSet(t *testing.T) {
+	cache := NewMemoryCache(10)
+	cache.Set(context.Background(), "key", []byte("value"), 0)
+
+	value, found, err := cache.Get(context.Background(), "key")
+	if err != nil || !found || string(value) != "value" {
+		t.Errorf("Expected 'value', got %q (found=%v, err=%v)", value, found, err)
+	}
+
+	if _, found, _ := cache.Get(context.Background(), "missing"); found {
+		t.Error("Expected missing key not to be found")
+	}
+}
+
+// TestMemoryCache_ValueIsCopied tests that callers cannot modify stored values
+func TestMemoryCache_ValueIsCopied(t *testing.T) {
+	cache := NewMemoryCache(10)
+	value := []byte("value")
+	cache.Set(context.Background(), "key", value, 0)
+	value[0] = 'X'
+
+	storedValue, _, _ := cache.Get(context.Background(), "key")
+	storedValue[1] = 'X'
+
+	storedValue, _, _ = cache.Get(context.Background(), "key")
+	if string(storedValue) != "value" {
+		t.Errorf("Expected stored value to be unchanged, got %q", storedValue)
+	}
+}
+
+// TestMemoryCache_EvictsLeastRecentlyUsed tests that the least recently used entry is evicted when full
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache(2)
+	cache.Set(ctx, "first", []byte("1"), 0)
+	cache.Set(ctx, "second", []byte("2"), 0)
+
+	// Touch "first" so "second" becomes the least recently used
+	cache.Get(ctx, "first")
+	cache.Set(ctx, "third", []byte("3"), 0)
+
+	if _, found, _ := cache.Get(ctx, "second"); found {
+		t.Error("Expected 'second' to be evicted")
+	}
+
+	if _, found, _ := cache.Get(ctx, "first"); !found {
+		t.Error("Expected 'first' to be retained")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
+
+// TestMemoryCache_Expiry tests that entries expire after their TTL
+func TestMemoryCache_Expiry(t *testing.T) {
+	ctx := context.Background()
+	currentTime := time.Now()
+	cache := NewMemoryCache(10)
+	cache.now = func() time.Time { return currentTime }
+
+	cache.Set(ctx, "short", []byte("value"), time.Minute)
+	cache.Set(ctx, "forever", []byte("value"), 0)
+
+	currentTime = currentTime.Add(time.Minute)
+
+	if _, found, _ := cache.Get(ctx, "short"); found {
+		t.Error("Expected 'short' to have expired")
+	}
+
+	if _, found, _ := cache.Get(ctx, "forever"); !found {
+		t.Error("Expected entry without TTL not to expire")
+	}
+}
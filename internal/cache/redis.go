@@ -0,0 +1,294 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// RedisOptions configures RedisCache
+type RedisOptions struct {
+	// Server address in host:port form
+	Address string
+	// Password sent with AUTH on connect (empty disables AUTH)
+	Password string
+	// Database selected with SELECT on connect
+	DB int
+	// Maximum number of idle connections kept for reuse
+	PoolSize int
+	// Deadline for establishing a connection
+	DialTimeout time.Duration
+	// Deadline for a single command when the context has none
+	CommandTimeout time.Duration
+}
+
+// DefaultRedisOptions returns the options used for a local Redis server
+func DefaultRedisOptions() RedisOptions {
+	return RedisOptions{
+		Address:        "localhost:6379",
+		PoolSize:       10,
+		DialTimeout:    time.Second,
+		CommandTimeout: time.Second,
+	}
+}
+
+// RedisError is an error reply returned by the Redis server
+type RedisError struct {
+	Message string
+}
+
+// Error implements the error interface
+func (redisError *RedisError) Error() string {
+	return "redis: " + redisError.Message
+}
+
+// redisConn is a connection with buffered reads
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// RedisCache is a Cache backed by any server speaking the Redis protocol (RESP)
+type RedisCache struct {
+	options RedisOptions
+	// Idle connections available for reuse
+	idle chan *redisConn
+}
+
+// NewRedisCache creates a Redis-backed cache; connections are opened lazily
+func NewRedisCache(options RedisOptions) *RedisCache {
+	if options.PoolSize < 1 {
+		options.PoolSize = 1
+	}
+
+	return &RedisCache{
+		options: options,
+		idle:    make(chan *redisConn, options.PoolSize),
+	}
+}
+
+// Get returns the value stored under key and whether it was found
+func (cache *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := cache.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+
+	return value, true, nil
+}
+
+// Set stores value under key for ttl (0 means no expiry)
+func (cache *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	arguments := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		// Redis rejects PX 0, so sub-millisecond TTLs are rounded up rather than truncated
+		arguments = append(arguments, "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	}
+
+	_, err := cache.do(ctx, arguments...)
+	return err
+}
+
+// Close closes all idle connections
+func (cache *RedisCache) Close() error {
+	for {
+		select {
+		case connection := <-cache.idle:
+			connection.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do sends a command and reads its reply, reusing a pooled connection when available
+func (cache *RedisCache) do(ctx context.Context, arguments ...string) (interface{}, error) {
+	connection, err := cache.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		deadline = time.Now().Add(cache.options.CommandTimeout)
+	}
+	connection.conn.SetDeadline(deadline)
+
+	reply, err := connection.command(arguments...)
+
+	// Error replies leave the connection in a usable state; anything else may not
+	var redisError *RedisError
+	if err != nil && !errors.As(err, &redisError) {
+		connection.conn.Close()
+		return nil, err
+	}
+
+	cache.release(connection)
+	return reply, err
+}
+
+// acquire returns an idle connection or dials a new one
+func (cache *RedisCache) acquire(ctx context.Context) (*redisConn, error) {
+	select {
+	case connection := <-cache.idle:
+		return connection, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: cache.options.DialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", cache.options.Address)
+	if err != nil {
+		return nil, fmt.Errorf("redis: failed to connect: %w", err)
+	}
+
+	connection := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	if deadline, hasDeadline := ctx.Deadline(); hasDeadline {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(cache.options.CommandTimeout))
+	}
+
+	if cache.options.Password != "" {
+		if _, err := connection.command("AUTH", cache.options.Password); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis: AUTH failed: %w", err)
+		}
+	}
+
+	if cache.options.DB != 0 {
+		if _, err := connection.command("SELECT", strconv.Itoa(cache.options.DB)); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis: SELECT failed: %w", err)
+		}
+	}
+
+	return connection, nil
+}
+
+// release returns a connection to the pool, closing it if the pool is full
+func (cache *RedisCache) release(connection *redisConn) {
+	select {
+	case cache.idle <- connection:
+	default:
+		connection.conn.Close()
+	}
+}
+
+// command writes a command as a RESP array of bulk strings and reads the reply
+func (connection *redisConn) command(arguments ...string) (interface{}, error) {
+	if _, err := connection.conn.Write(encodeCommand(arguments)); err != nil {
+		return nil, fmt.Errorf("redis: failed to send command: %w", err)
+	}
+
+	return readReply(connection.reader)
+}
+
+// encodeCommand encodes a command as a RESP array of bulk strings
+func encodeCommand(arguments []string) []byte {
+	buffer := make([]byte, 0, 64)
+	buffer = append(buffer, '*')
+	buffer = strconv.AppendInt(buffer, int64(len(arguments)), 10)
+	buffer = append(buffer, '\r', '\n')
+
+	for _, argument := range arguments {
+		buffer = append(buffer, '$')
+		buffer = strconv.AppendInt(buffer, int64(len(argument)), 10)
+		buffer = append(buffer, '\r', '\n')
+		buffer = append(buffer, argument...)
+		buffer = append(buffer, '\r', '\n')
+	}
+
+	return buffer
+}
+
+// readReply reads a single RESP reply
+// Simple strings are returned as string, integers as int64, bulk strings as []byte,
+// arrays as []interface{}, and nil bulk strings or arrays as nil
+func readReply(reader *bufio.Reader) (interface{}, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, &RedisError{Message: line[1:]}
+	case ':':
+		value, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid integer reply %q", line)
+		}
+		return value, nil
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", line)
+		}
+		if length < 0 {
+			return nil, nil
+		}
+
+		// Read the payload plus its trailing CRLF
+		payload := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return nil, fmt.Errorf("redis: failed to read reply: %w", err)
+		}
+		return payload[:length], nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length %q", line)
+		}
+		if count < 0 {
+			return nil, nil
+		}
+
+		elements := make([]interface{}, count)
+		for index := range elements {
+			if elements[index], err = readReply(reader); err != nil {
+				return nil, err
+			}
+		}
+		return elements, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply type %q", line[0])
+	}
+}
+
+// readLine reads a CRLF-terminated line without the terminator
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("redis: failed to read reply: %w", err)
+	}
+
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: malformed reply line %q", line)
+	}
+
+	return line[:len(line)-2], nil
+}
+
+// Verify RedisCache implements Cache
+var _ Cache = (*RedisCache)(nil)
@@ -0,0 +1,289 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedisServer is an embedded server speaking enough of the Redis protocol for RedisCache
+type fakeRedisServer struct {
+	listener net.Listener
+	mutex    sync.Mutex
+	values   map[string]string
+	expiries map[string]time.Time
+	// Password required by AUTH (empty disables authentication)
+	password string
+	// Commands received, in order
+	commands    []string
+	connections int
+}
+
+// newFakeRedisServer starts a fake Redis server on a random local port
+// A non-empty password makes the server require AUTH
+func newFakeRedisServer(t *testing.T, password string) *fakeRedisServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	server := &fakeRedisServer{
+		listener: listener,
+		values:   make(map[string]string),
+		expiries: make(map[string]time.Time),
+		password: password,
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go server.serve()
+	return server
+}
+
+// address returns the host:port the server listens on
+func (server *fakeRedisServer) address() string {
+	return server.listener.Addr().String()
+}
+
+// serve accepts connections until the listener is closed
+func (server *fakeRedisServer) serve() {
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		server.mutex.Lock()
+		server.connections++
+		server.mutex.Unlock()
+
+		go server.handle(conn)
+	}
+}
+
+// handle reads commands from a connection and writes replies
+func (server *fakeRedisServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authenticated := server.password == ""
+
+	for {
+		request, err := readReply(reader)
+		if err != nil {
+			return
+		}
+
+		elements, _ := request.([]interface{})
+		arguments := make([]string, len(elements))
+		for index, element := range elements {
+			arguments[index] = string(element.([]byte))
+		}
+
+		server.mutex.Lock()
+		server.commands = append(server.commands, strings.Join(arguments, " "))
+		server.mutex.Unlock()
+
+		if strings.ToUpper(arguments[0]) == "AUTH" {
+			if len(arguments) == 2 && arguments[1] == server.password {
+				authenticated = true
+				conn.Write([]byte("+OK\r\n"))
+			} else {
+				conn.Write([]byte("-WRONGPASS invalid password\r\n"))
+			}
+			continue
+		}
+
+		if !authenticated {
+			conn.Write([]byte("-NOAUTH Authentication required.\r\n"))
+			continue
+		}
+
+		conn.Write(server.execute(arguments))
+	}
+}
+
+// execute runs a single command against the in-memory store
+func (server *fakeRedisServer) execute(arguments []string) []byte {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	switch strings.ToUpper(arguments[0]) {
+	case "GET":
+		value, exists := server.values[arguments[1]]
+		if expiry, hasExpiry := server.expiries[arguments[1]]; hasExpiry && !time.Now().Before(expiry) {
+			exists = false
+		}
+		if !exists {
+			return []byte("$-1\r\n")
+		}
+		return []byte(fmt.Sprintf("$%d\r\n%s\r\n", len(value), value))
+	case "SET":
+		server.values[arguments[1]] = arguments[2]
+		delete(server.expiries, arguments[1])
+		if len(arguments) == 5 && strings.ToUpper(arguments[3]) == "PX" {
+			milliseconds, _ := strconv.Atoi(arguments[4])
+			server.expiries[arguments[1]] = time.Now().Add(time.Duration(milliseconds) * time.Millisecond)
+		}
+		return []byte("+OK\r\n")
+	case "SELECT":
+		return []byte("+OK\r\n")
+	default:
+		return []byte(fmt.Sprintf("-ERR unknown command '%s'\r\n", arguments[0]))
+	}
+}
+
+// receivedCommands returns a copy of the commands received so far
+func (server *fakeRedisServer) receivedCommands() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]string(nil), server.commands...)
+}
+
+// newTestRedisCache creates a RedisCache connected to the fake server
+func newTestRedisCache(server *fakeRedisServer) *RedisCache {
+	options := DefaultRedisOptions()
+	options.Address = server.address()
+	return NewRedisCache(options)
+}
+
+// TestRedisCache_GetSet tests storing and retrieving values
+func TestRedisCache_GetSet(t *testing.T) {
+	server := newFakeRedisServer(t, "")
+	cache := newTestRedisCache(server)
+	defer cache.Close()
+
+	// Values containing CRLF must survive the round trip
+	payload := []byte("{\"matchId\":\"NA1_1\"}\r\nsecond line")
+	if err := cache.Set(context.Background(), "match:NA1_1", payload, 0); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	value, found, err := cache.Get(context.Background(), "match:NA1_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !found || string(value) != string(payload) {
+		t.Errorf("Expected %q, got %q (found=%v)", payload, value, found)
+	}
+
+	if _, found, err := cache.Get(context.Background(), "missing"); err != nil || found {
+		t.Errorf("Expected missing key not to be found, got found=%v err=%v", found, err)
+	}
+}
+
+// TestRedisCache_SetWithTTL tests that TTLs are sent as PX milliseconds
+func TestRedisCache_SetWithTTL(t *testing.T) {
+	server := newFakeRedisServer(t, "")
+	cache := newTestRedisCache(server)
+	defer cache.Close()
+
+	cache.Set(context.Background(), "key", []byte("value"), 50*time.Millisecond)
+
+	commands := server.receivedCommands()
+	if commands[len(commands)-1] != "SET key value PX 50" {
+		t.Errorf("Expected 'SET key value PX 50', got '%s'", commands[len(commands)-1])
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if _, found, _ := cache.Get(context.Background(), "key"); found {
+		t.Error("Expected key to have expired")
+	}
+}
+
+// TestRedisCache_SetWithSubMillisecondTTL tests that a positive TTL below 1ms is sent as PX 1
+func TestRedisCache_SetWithSubMillisecondTTL(t *testing.T) {
+	server := newFakeRedisServer(t, "")
+	cache := newTestRedisCache(server)
+	defer cache.Close()
+
+	if err := cache.Set(context.Background(), "key", []byte("value"), 500*time.Microsecond); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	commands := server.receivedCommands()
+	if commands[len(commands)-1] != "SET key value PX 1" {
+		t.Errorf("Expected 'SET key value PX 1', got '%s'", commands[len(commands)-1])
+	}
+}
+
+// TestRedisCache_ReusesConnections tests that pooled connections are reused across commands
+func TestRedisCache_ReusesConnections(t *testing.T) {
+	server := newFakeRedisServer(t, "")
+	cache := newTestRedisCache(server)
+	defer cache.Close()
+
+	for i := 0; i < 5; i++ {
+		cache.Set(context.Background(), "key", []byte("value"), 0)
+		cache.Get(context.Background(), "key")
+	}
+
+	server.mutex.Lock()
+	connections := server.connections
+	server.mutex.Unlock()
+
+	if connections != 1 {
+		t.Errorf("Expected 1 connection, got %d", connections)
+	}
+}
+
+// TestRedisCache_AuthAndSelect tests that AUTH and SELECT are sent on connect
+func TestRedisCache_AuthAndSelect(t *testing.T) {
+	server := newFakeRedisServer(t, "secret")
+
+	options := DefaultRedisOptions()
+	options.Address = server.address()
+	options.Password = "secret"
+	options.DB = 2
+	cache := NewRedisCache(options)
+	defer cache.Close()
+
+	if err := cache.Set(context.Background(), "key", []byte("value"), 0); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	commands := server.receivedCommands()
+	if len(commands) != 3 || commands[0] != "AUTH secret" || commands[1] != "SELECT 2" {
+		t.Errorf("Expected AUTH then SELECT before SET, got %v", commands)
+	}
+}
+
+// TestRedisCache_WrongPassword tests that a rejected AUTH is reported
+func TestRedisCache_WrongPassword(t *testing.T) {
+	server := newFakeRedisServer(t, "secret")
+
+	options := DefaultRedisOptions()
+	options.Address = server.address()
+	options.Password = "wrong"
+	cache := NewRedisCache(options)
+
+	_, _, err := cache.Get(context.Background(), "key")
+
+	var redisError *RedisError
+	if !errors.As(err, &redisError) {
+		t.Fatalf("Expected RedisError, got: %v", err)
+	}
+}
+
+// TestRedisCache_ConnectionRefused tests that an unreachable server returns an error
+func TestRedisCache_ConnectionRefused(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	address := listener.Addr().String()
+	listener.Close()
+
+	options := DefaultRedisOptions()
+	options.Address = address
+	cache := NewRedisCache(options)
+
+	if _, _, err := cache.Get(context.Background(), "key"); err == nil {
+		t.Error("Expected error for unreachable server, got nil")
+	}
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +17,54 @@ type Config struct {
 	ServerPort string
 	// Database connection string (for future persistence)
 	DatabaseURL string
+	// Maximum attempts per Riot API request, including the first
+	RiotRetryMaxAttempts int
+	// Initial backoff delay between Riot API retries
+	RiotRetryBaseDelay time.Duration
+	// Upper bound for a single backoff delay
+	RiotRetryMaxDelay time.Duration
+	// Upper bound for the total time spent retrying a single Riot API request
+	RiotRetryMaxElapsed time.Duration
+	// Deadline for a single Riot API request attempt
+	RiotRequestTimeout time.Duration
+	// Number of match details fetched concurrently for a match history request
+	MatchFetchParallelism int
+	// Number of Account-V1 lookups run concurrently when filling in participants' Riot IDs
+	RiotIDLookupParallelism int
+	// Account-V1 cluster used for every region ("americas", "europe", "asia"); empty uses each region's nearest
+	RiotAccountCluster string
+	// Consecutive failures that open a Riot routing host's circuit breaker
+	CircuitBreakerFailureThreshold int
+	// How long an open circuit fails fast before allowing a probe request
+	CircuitBreakerOpenDuration time.Duration
+	// Whether Riot API lookups are cached in memory
+	CacheEnabled bool
+	// Cache backend: "memory" or "redis"
+	CacheBackend string
+	// Maximum number of entries kept by the in-memory cache
+	CacheMaxEntries int
+	// Address of the Redis server used by the redis cache backend
+	RedisAddress string
+	// Password for the Redis server (empty disables AUTH)
+	RedisPassword string
+	// Redis database number
+	RedisDB int
+	// How long summoner lookups are cached
+	CacheSummonerTTL time.Duration
+	// How long ranked stats are cached
+	CacheRankedTTL time.Duration
+	// How long match details are cached
+	CacheMatchTTL time.Duration
+	// How long champion mastery lookups are cached
+	CacheMasteryTTL time.Duration
+	// How long active game lookups are cached
+	CacheLiveGameTTL time.Duration
+	// How long apex leagues and league entry pages are cached
+	CacheLeagueTTL time.Duration
+	// How long player challenge data and challenge leaderboards are cached
+	CacheChallengeTTL time.Duration
+	// How long challenge configs are cached
+	CacheChallengeConfigTTL time.Duration
 }
 
 // LoadConfig loads configuration from environment variables
@@ -36,8 +86,88 @@ func LoadConfig() *Config {
 	databaseURL := os.Getenv("DATABASE_URL")
 
 	return &Config{
-		RiotAPIKey:  riotAPIKey,
-		ServerPort:  serverPort,
-		DatabaseURL: databaseURL,
+		RiotAPIKey:                     riotAPIKey,
+		ServerPort:                     serverPort,
+		DatabaseURL:                    databaseURL,
+		RiotRetryMaxAttempts:           getEnvInt("RIOT_RETRY_MAX_ATTEMPTS", 3),
+		RiotRetryBaseDelay:             getEnvDuration("RIOT_RETRY_BASE_DELAY", 250*time.Millisecond),
+		RiotRetryMaxDelay:              getEnvDuration("RIOT_RETRY_MAX_DELAY", 5*time.Second),
+		RiotRetryMaxElapsed:            getEnvDuration("RIOT_RETRY_MAX_ELAPSED", 20*time.Second),
+		RiotRequestTimeout:             getEnvDuration("RIOT_REQUEST_TIMEOUT", 10*time.Second),
+		MatchFetchParallelism:          getEnvInt("MATCH_FETCH_PARALLELISM", 5),
+		RiotIDLookupParallelism:        getEnvInt("RIOT_ID_LOOKUP_PARALLELISM", 5),
+		RiotAccountCluster:             os.Getenv("RIOT_ACCOUNT_CLUSTER"),
+		CircuitBreakerFailureThreshold: getEnvInt("CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+		CircuitBreakerOpenDuration:     getEnvDuration("CIRCUIT_BREAKER_OPEN_DURATION", 30*time.Second),
+		CacheEnabled:                   getEnvBool("CACHE_ENABLED", true),
+		CacheBackend:                   getEnvString("CACHE_BACKEND", "memory"),
+		CacheMaxEntries:                getEnvInt("CACHE_MAX_ENTRIES", 10000),
+		RedisAddress:                   getEnvString("REDIS_ADDRESS", "localhost:6379"),
+		RedisPassword:                  os.Getenv("REDIS_PASSWORD"),
+		RedisDB:                        getEnvInt("REDIS_DB", 0),
+		CacheSummonerTTL:               getEnvDuration("CACHE_SUMMONER_TTL", 5*time.Minute),
+		CacheRankedTTL:                 getEnvDuration("CACHE_RANKED_TTL", 30*time.Second),
+		CacheMatchTTL:                  getEnvDuration("CACHE_MATCH_TTL", 24*time.Hour),
+		CacheMasteryTTL:                getEnvDuration("CACHE_MASTERY_TTL", 5*time.Minute),
+		CacheLiveGameTTL:               getEnvDuration("CACHE_LIVE_GAME_TTL", 15*time.Second),
+		CacheLeagueTTL:                 getEnvDuration("CACHE_LEAGUE_TTL", time.Minute),
+		CacheChallengeTTL:              getEnvDuration("CACHE_CHALLENGE_TTL", 5*time.Minute),
+		CacheChallengeConfigTTL:        getEnvDuration("CACHE_CHALLENGE_CONFIG_TTL", time.Hour),
 	}
 }
+
+// getEnvString reads a string environment variable, falling back to defaultValue if unset
+func getEnvString(key string, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvInt reads an integer environment variable, falling back to defaultValue if unset or invalid
+func getEnvInt(key string, defaultValue int) int {
+	rawValue := os.Getenv(key)
+	if rawValue == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(rawValue)
+	if err != nil {
+		log.Printf("Warning: invalid %s %q, using default %d", key, rawValue, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
+// getEnvBool reads a boolean environment variable (e.g. "true", "0"), falling back to defaultValue if unset or invalid
+func getEnvBool(key string, defaultValue bool) bool {
+	rawValue := os.Getenv(key)
+	if rawValue == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseBool(rawValue)
+	if err != nil {
+		log.Printf("Warning: invalid %s %q, using default %t", key, rawValue, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
+// getEnvDuration reads a duration environment variable (e.g. "500ms", "10s"), falling back to defaultValue if unset or invalid
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	rawValue := os.Getenv(key)
+	if rawValue == "" {
+		return defaultValue
+	}
+
+	value, err := time.ParseDuration(rawValue)
+	if err != nil {
+		log.Printf("Warning: invalid %s %q, using default %s", key, rawValue, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 // TestLoadConfig_DefaultValues tests that default values are set correctly
@@ -136,3 +137,285 @@ func TestConfigStruct(t *testing.T) {
 		t.Errorf("Expected DatabaseURL 'test-url', got '%s'", config.DatabaseURL)
 	}
 }
+
+// TestLoadConfig_RetryDefaults tests default Riot retry settings
+func TestLoadConfig_RetryDefaults(t *testing.T) {
+	os.Unsetenv("RIOT_RETRY_MAX_ATTEMPTS")
+	os.Unsetenv("RIOT_RETRY_BASE_DELAY")
+	os.Unsetenv("RIOT_RETRY_MAX_DELAY")
+	os.Unsetenv("RIOT_RETRY_MAX_ELAPSED")
+
+	config := LoadConfig()
+
+	if config.RiotRetryMaxAttempts != 3 {
+		t.Errorf("Expected default RiotRetryMaxAttempts 3, got %d", config.RiotRetryMaxAttempts)
+	}
+
+	if config.RiotRetryBaseDelay != 250*time.Millisecond {
+		t.Errorf("Expected default RiotRetryBaseDelay 250ms, got %s", config.RiotRetryBaseDelay)
+	}
+
+	if config.RiotRetryMaxDelay != 5*time.Second {
+		t.Errorf("Expected default RiotRetryMaxDelay 5s, got %s", config.RiotRetryMaxDelay)
+	}
+
+	if config.RiotRetryMaxElapsed != 20*time.Second {
+		t.Errorf("Expected default RiotRetryMaxElapsed 20s, got %s", config.RiotRetryMaxElapsed)
+	}
+}
+
+// TestLoadConfig_RetryFromEnvironment tests loading Riot retry settings from environment
+func TestLoadConfig_RetryFromEnvironment(t *testing.T) {
+	os.Setenv("RIOT_RETRY_MAX_ATTEMPTS", "5")
+	os.Setenv("RIOT_RETRY_BASE_DELAY", "100ms")
+	os.Setenv("RIOT_RETRY_MAX_DELAY", "2s")
+	os.Setenv("RIOT_RETRY_MAX_ELAPSED", "1m")
+
+	defer func() {
+		os.Unsetenv("RIOT_RETRY_MAX_ATTEMPTS")
+		os.Unsetenv("RIOT_RETRY_BASE_DELAY")
+		os.Unsetenv("RIOT_RETRY_MAX_DELAY")
+		os.Unsetenv("RIOT_RETRY_MAX_ELAPSED")
+	}()
+
+	config := LoadConfig()
+
+	if config.RiotRetryMaxAttempts != 5 {
+		t.Errorf("Expected RiotRetryMaxAttempts 5, got %d", config.RiotRetryMaxAttempts)
+	}
+
+	if config.RiotRetryBaseDelay != 100*time.Millisecond {
+		t.Errorf("Expected RiotRetryBaseDelay 100ms, got %s", config.RiotRetryBaseDelay)
+	}
+
+	if config.RiotRetryMaxDelay != 2*time.Second {
+		t.Errorf("Expected RiotRetryMaxDelay 2s, got %s", config.RiotRetryMaxDelay)
+	}
+
+	if config.RiotRetryMaxElapsed != time.Minute {
+		t.Errorf("Expected RiotRetryMaxElapsed 1m, got %s", config.RiotRetryMaxElapsed)
+	}
+}
+
+// TestLoadConfig_InvalidRetryValues tests that invalid retry settings fall back to defaults
+func TestLoadConfig_InvalidRetryValues(t *testing.T) {
+	os.Setenv("RIOT_RETRY_MAX_ATTEMPTS", "many")
+	os.Setenv("RIOT_RETRY_BASE_DELAY", "soon")
+
+	defer func() {
+		os.Unsetenv("RIOT_RETRY_MAX_ATTEMPTS")
+		os.Unsetenv("RIOT_RETRY_BASE_DELAY")
+	}()
+
+	config := LoadConfig()
+
+	if config.RiotRetryMaxAttempts != 3 {
+		t.Errorf("Expected fallback RiotRetryMaxAttempts 3, got %d", config.RiotRetryMaxAttempts)
+	}
+
+	if config.RiotRetryBaseDelay != 250*time.Millisecond {
+		t.Errorf("Expected fallback RiotRetryBaseDelay 250ms, got %s", config.RiotRetryBaseDelay)
+	}
+}
+
+// TestLoadConfig_RequestTimeout tests the Riot request timeout default and override
+func TestLoadConfig_RequestTimeout(t *testing.T) {
+	os.Unsetenv("RIOT_REQUEST_TIMEOUT")
+
+	config := LoadConfig()
+	if config.RiotRequestTimeout != 10*time.Second {
+		t.Errorf("Expected default RiotRequestTimeout 10s, got %s", config.RiotRequestTimeout)
+	}
+
+	os.Setenv("RIOT_REQUEST_TIMEOUT", "3s")
+	defer os.Unsetenv("RIOT_REQUEST_TIMEOUT")
+
+	config = LoadConfig()
+	if config.RiotRequestTimeout != 3*time.Second {
+		t.Errorf("Expected RiotRequestTimeout 3s, got %s", config.RiotRequestTimeout)
+	}
+}
+
+// TestLoadConfig_MatchFetchParallelism tests the match fetch parallelism default and override
+func TestLoadConfig_MatchFetchParallelism(t *testing.T) {
+	os.Unsetenv("MATCH_FETCH_PARALLELISM")
+
+	config := LoadConfig()
+	if config.MatchFetchParallelism != 5 {
+		t.Errorf("Expected default MatchFetchParallelism 5, got %d", config.MatchFetchParallelism)
+	}
+
+	os.Setenv("MATCH_FETCH_PARALLELISM", "10")
+	defer os.Unsetenv("MATCH_FETCH_PARALLELISM")
+
+	config = LoadConfig()
+	if config.MatchFetchParallelism != 10 {
+		t.Errorf("Expected MatchFetchParallelism 10, got %d", config.MatchFetchParallelism)
+	}
+}
+
+// TestLoadConfig_RiotIDLookupParallelism tests the Riot ID lookup parallelism default and override
+func TestLoadConfig_RiotIDLookupParallelism(t *testing.T) {
+	os.Unsetenv("RIOT_ID_LOOKUP_PARALLELISM")
+
+	config := LoadConfig()
+	if config.RiotIDLookupParallelism != 5 {
+		t.Errorf("Expected default RiotIDLookupParallelism 5, got %d", config.RiotIDLookupParallelism)
+	}
+
+	os.Setenv("RIOT_ID_LOOKUP_PARALLELISM", "2")
+	defer os.Unsetenv("RIOT_ID_LOOKUP_PARALLELISM")
+
+	config = LoadConfig()
+	if config.RiotIDLookupParallelism != 2 {
+		t.Errorf("Expected RiotIDLookupParallelism 2, got %d", config.RiotIDLookupParallelism)
+	}
+}
+
+// TestLoadConfig_CacheDefaults tests the default cache settings
+func TestLoadConfig_CacheDefaults(t *testing.T) {
+	config := LoadConfig()
+
+	if !config.CacheEnabled {
+		t.Error("Expected cache to be enabled by default")
+	}
+
+	if config.CacheBackend != "memory" {
+		t.Errorf("Expected default CacheBackend 'memory', got '%s'", config.CacheBackend)
+	}
+
+	if config.CacheMaxEntries != 10000 {
+		t.Errorf("Expected default CacheMaxEntries 10000, got %d", config.CacheMaxEntries)
+	}
+
+	if config.CacheSummonerTTL != 5*time.Minute {
+		t.Errorf("Expected default CacheSummonerTTL 5m, got %s", config.CacheSummonerTTL)
+	}
+
+	if config.CacheRankedTTL != 30*time.Second {
+		t.Errorf("Expected default CacheRankedTTL 30s, got %s", config.CacheRankedTTL)
+	}
+
+	if config.CacheMatchTTL != 24*time.Hour {
+		t.Errorf("Expected default CacheMatchTTL 24h, got %s", config.CacheMatchTTL)
+	}
+
+	if config.CacheMasteryTTL != 5*time.Minute {
+		t.Errorf("Expected default CacheMasteryTTL 5m, got %s", config.CacheMasteryTTL)
+	}
+
+	if config.CacheLiveGameTTL != 15*time.Second {
+		t.Errorf("Expected default CacheLiveGameTTL 15s, got %s", config.CacheLiveGameTTL)
+	}
+
+	if config.CacheLeagueTTL != time.Minute {
+		t.Errorf("Expected default CacheLeagueTTL 1m, got %s", config.CacheLeagueTTL)
+	}
+
+	if config.CacheChallengeTTL != 5*time.Minute {
+		t.Errorf("Expected default CacheChallengeTTL 5m, got %s", config.CacheChallengeTTL)
+	}
+
+	if config.CacheChallengeConfigTTL != time.Hour {
+		t.Errorf("Expected default CacheChallengeConfigTTL 1h, got %s", config.CacheChallengeConfigTTL)
+	}
+}
+
+// TestLoadConfig_CacheFromEnvironment tests loading cache settings from environment
+func TestLoadConfig_CacheFromEnvironment(t *testing.T) {
+	os.Setenv("CACHE_ENABLED", "false")
+	os.Setenv("CACHE_MAX_ENTRIES", "500")
+	os.Setenv("CACHE_RANKED_TTL", "1m")
+
+	defer func() {
+		os.Unsetenv("CACHE_ENABLED")
+		os.Unsetenv("CACHE_MAX_ENTRIES")
+		os.Unsetenv("CACHE_RANKED_TTL")
+	}()
+
+	config := LoadConfig()
+
+	if config.CacheEnabled {
+		t.Error("Expected cache to be disabled")
+	}
+
+	if config.CacheMaxEntries != 500 {
+		t.Errorf("Expected CacheMaxEntries 500, got %d", config.CacheMaxEntries)
+	}
+
+	if config.CacheRankedTTL != time.Minute {
+		t.Errorf("Expected CacheRankedTTL 1m, got %s", config.CacheRankedTTL)
+	}
+}
+
+// TestLoadConfig_RedisFromEnvironment tests loading the Redis cache backend settings
+func TestLoadConfig_RedisFromEnvironment(t *testing.T) {
+	os.Setenv("CACHE_BACKEND", "redis")
+	os.Setenv("REDIS_ADDRESS", "redis:6380")
+	os.Setenv("REDIS_DB", "3")
+
+	defer func() {
+		os.Unsetenv("CACHE_BACKEND")
+		os.Unsetenv("REDIS_ADDRESS")
+		os.Unsetenv("REDIS_DB")
+	}()
+
+	config := LoadConfig()
+
+	if config.CacheBackend != "redis" {
+		t.Errorf("Expected CacheBackend 'redis', got '%s'", config.CacheBackend)
+	}
+
+	if config.RedisAddress != "redis:6380" {
+		t.Errorf("Expected RedisAddress 'redis:6380', got '%s'", config.RedisAddress)
+	}
+
+	if config.RedisDB != 3 {
+		t.Errorf("Expected RedisDB 3, got %d", config.RedisDB)
+	}
+}
+
+// TestLoadConfig_CircuitBreaker tests the circuit breaker defaults and overrides
+func TestLoadConfig_CircuitBreaker(t *testing.T) {
+	config := LoadConfig()
+
+	if config.CircuitBreakerFailureThreshold != 5 {
+		t.Errorf("Expected default CircuitBreakerFailureThreshold 5, got %d", config.CircuitBreakerFailureThreshold)
+	}
+
+	if config.CircuitBreakerOpenDuration != 30*time.Second {
+		t.Errorf("Expected default CircuitBreakerOpenDuration 30s, got %s", config.CircuitBreakerOpenDuration)
+	}
+
+	os.Setenv("CIRCUIT_BREAKER_FAILURE_THRESHOLD", "10")
+	os.Setenv("CIRCUIT_BREAKER_OPEN_DURATION", "1m")
+
+	defer func() {
+		os.Unsetenv("CIRCUIT_BREAKER_FAILURE_THRESHOLD")
+		os.Unsetenv("CIRCUIT_BREAKER_OPEN_DURATION")
+	}()
+
+	config = LoadConfig()
+
+	if config.CircuitBreakerFailureThreshold != 10 {
+		t.Errorf("Expected CircuitBreakerFailureThreshold 10, got %d", config.CircuitBreakerFailureThreshold)
+	}
+
+	if config.CircuitBreakerOpenDuration != time.Minute {
+		t.Errorf("Expected CircuitBreakerOpenDuration 1m, got %s", config.CircuitBreakerOpenDuration)
+	}
+}
+
+// TestLoadConfig_AccountCluster tests loading the Account-V1 cluster override
+func TestLoadConfig_AccountCluster(t *testing.T) {
+	if config := LoadConfig(); config.RiotAccountCluster != "" {
+		t.Errorf("Expected empty default RiotAccountCluster, got '%s'", config.RiotAccountCluster)
+	}
+
+	os.Setenv("RIOT_ACCOUNT_CLUSTER", "europe")
+	defer os.Unsetenv("RIOT_ACCOUNT_CLUSTER")
+
+	if config := LoadConfig(); config.RiotAccountCluster != "europe" {
+		t.Errorf("Expected RiotAccountCluster 'europe', got '%s'", config.RiotAccountCluster)
+	}
+}
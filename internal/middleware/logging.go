@@ -38,6 +38,7 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 
 		// Log incoming request
 		log.Info().
+			Str("request_id", RequestIDFromContext(request.Context())).
 			Str("method", request.Method).
 			Str("path", request.URL.Path).
 			Str("remote_addr", request.RemoteAddr).
@@ -65,6 +66,7 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 
 		// Log request completion with details
 		logEvent.
+			Str("request_id", RequestIDFromContext(request.Context())).
 			Str("method", request.Method).
 			Str("path", request.URL.Path).
 			Int("status", statusCode).
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header used to propagate request IDs between services
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the longest incoming request ID that is reused
+const maxRequestIDLength = 128
+
+// requestIDContextKey is the context key under which the request ID is stored
+type requestIDContextKey struct{}
+
+// RequestIDMiddleware assigns every request an ID, reusing the caller's X-Request-ID if it is valid
+// Incoming IDs that are too long or contain characters outside [A-Za-z0-9._-] are replaced with a new one,
+// so they cannot inject content into logs or response headers
+// The ID is echoed in the response header and stored in the request context
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestID := request.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		writer.Header().Set(RequestIDHeader, requestID)
+
+		ctx := context.WithValue(request.Context(), requestIDContextKey{}, requestID)
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the request ID stored by RequestIDMiddleware, or "" if none
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// validRequestID reports whether an incoming request ID is non-empty, at most maxRequestIDLength
+// characters long and made only of [A-Za-z0-9._-]
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(requestID); i++ {
+		character := requestID[i]
+		switch {
+		case 'a' <= character && character <= 'z':
+		case 'A' <= character && character <= 'Z':
+		case '0' <= character && character <= '9':
+		case character == '.' || character == '_' || character == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID generates a random 128-bit hex request ID
+func newRequestID() string {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(randomBytes)
+}
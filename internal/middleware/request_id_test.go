@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRequestIDMiddleware_GeneratesID tests that a request ID is generated when none is provided
+func TestRequestIDMiddleware_GeneratesID(t *testing.T) {
+	var contextRequestID string
+	nextHandler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		contextRequestID = RequestIDFromContext(request.Context())
+	})
+
+	request, _ := http.NewRequest("POST", "/test", nil)
+	responseRecorder := httptest.NewRecorder()
+	RequestIDMiddleware(nextHandler).ServeHTTP(responseRecorder, request)
+
+	if len(contextRequestID) != 32 {
+		t.Errorf("Expected 32 character request ID, got '%s'", contextRequestID)
+	}
+
+	if headerRequestID := responseRecorder.Header().Get(RequestIDHeader); headerRequestID != contextRequestID {
+		t.Errorf("Expected response header '%s', got '%s'", contextRequestID, headerRequestID)
+	}
+}
+
+// TestRequestIDMiddleware_ReusesIncomingID tests that an incoming X-Request-ID is propagated
+func TestRequestIDMiddleware_ReusesIncomingID(t *testing.T) {
+	var contextRequestID string
+	nextHandler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		contextRequestID = RequestIDFromContext(request.Context())
+	})
+
+	request, _ := http.NewRequest("POST", "/test", nil)
+	request.Header.Set(RequestIDHeader, "gateway-request-id")
+	responseRecorder := httptest.NewRecorder()
+	RequestIDMiddleware(nextHandler).ServeHTTP(responseRecorder, request)
+
+	if contextRequestID != "gateway-request-id" {
+		t.Errorf("Expected request ID 'gateway-request-id', got '%s'", contextRequestID)
+	}
+
+	if headerRequestID := responseRecorder.Header().Get(RequestIDHeader); headerRequestID != "gateway-request-id" {
+		t.Errorf("Expected response header 'gateway-request-id', got '%s'", headerRequestID)
+	}
+}
+
+// TestRequestIDMiddleware_ReplacesInvalidID tests that overlong or unsafe incoming IDs are replaced
+func TestRequestIDMiddleware_ReplacesInvalidID(t *testing.T) {
+	testCases := map[string]string{
+		"too long":         strings.Repeat("a", maxRequestIDLength+1),
+		"space":            "gateway request id",
+		"log injection":    "id\nlevel=error",
+		"non-ascii":        "gateway-request-idé",
+		"header splitting": "id\r\nSet-Cookie: a=b",
+	}
+
+	for name, incomingID := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var contextRequestID string
+			nextHandler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				contextRequestID = RequestIDFromContext(request.Context())
+			})
+
+			request, _ := http.NewRequest("POST", "/test", nil)
+			request.Header[RequestIDHeader] = []string{incomingID}
+			responseRecorder := httptest.NewRecorder()
+			RequestIDMiddleware(nextHandler).ServeHTTP(responseRecorder, request)
+
+			if contextRequestID == incomingID || len(contextRequestID) != 32 {
+				t.Errorf("Expected a generated 32 character request ID, got '%s'", contextRequestID)
+			}
+
+			if headerRequestID := responseRecorder.Header().Get(RequestIDHeader); headerRequestID != contextRequestID {
+				t.Errorf("Expected response header '%s', got '%s'", contextRequestID, headerRequestID)
+			}
+		})
+	}
+}
+
+// TestRequestIDMiddleware_AcceptsMaxLengthID tests that an ID of exactly the maximum length is reused
+func TestRequestIDMiddleware_AcceptsMaxLengthID(t *testing.T) {
+	incomingID := strings.Repeat("A1._-", maxRequestIDLength/5) + strings.Repeat("z", maxRequestIDLength%5)
+
+	var contextRequestID string
+	nextHandler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		contextRequestID = RequestIDFromContext(request.Context())
+	})
+
+	request, _ := http.NewRequest("POST", "/test", nil)
+	request.Header.Set(RequestIDHeader, incomingID)
+	RequestIDMiddleware(nextHandler).ServeHTTP(httptest.NewRecorder(), request)
+
+	if contextRequestID != incomingID {
+		t.Errorf("Expected request ID '%s', got '%s'", incomingID, contextRequestID)
+	}
+}
+
+// TestRequestIDFromContext_Missing tests that a context without an ID returns an empty string
+func TestRequestIDFromContext_Missing(t *testing.T) {
+	request, _ := http.NewRequest("POST", "/test", nil)
+
+	if requestID := RequestIDFromContext(request.Context()); requestID != "" {
+		t.Errorf("Expected empty request ID, got '%s'", requestID)
+	}
+}
@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+// PlayerChallenges represents a player's progress across all challenges
+type PlayerChallenges struct {
+	// Player's PUUID
+	PUUID string `json:"puuid"`
+	// Overall challenge points and level
+	TotalPoints ChallengePoints `json:"totalPoints"`
+	// Challenge points per category (COLLECTION, EXPERTISE, IMAGINATION, TEAMWORK, VETERANCY)
+	CategoryPoints map[string]ChallengePoints `json:"categoryPoints"`
+	// Progress on each challenge the player has started
+	Challenges []ChallengeProgress `json:"challenges"`
+}
+
+// ChallengePoints represents points earned toward a challenge level
+type ChallengePoints struct {
+	// Level reached (e.g., GOLD)
+	Level string `json:"level"`
+	// Points earned
+	Current int `json:"current"`
+	// Points available
+	Max int `json:"max"`
+	// Fraction of players with fewer points (0 to 1)
+	Percentile float64 `json:"percentile"`
+}
+
+// ChallengeProgress represents a player's progress on one challenge
+type ChallengeProgress struct {
+	// Challenge ID
+	ChallengeID int64 `json:"challengeId"`
+	// Level reached (NONE, IRON through CHALLENGER)
+	Level string `json:"level"`
+	// Progress value (e.g., games won, damage dealt)
+	Value float64 `json:"value"`
+	// Fraction of players with a lower value (0 to 1)
+	Percentile float64 `json:"percentile"`
+	// Timestamp when the current level was reached (omitted if not reported)
+	AchievedTime *time.Time `json:"achievedTime,omitempty"`
+	// Challenge name, joined from the challenge config on request
+	Name string `json:"name,omitempty"`
+	// Short challenge description, joined from the challenge config on request
+	ShortDescription string `json:"shortDescription,omitempty"`
+	// Full challenge description, joined from the challenge config on request
+	Description string `json:"description,omitempty"`
+	// Value needed for each level, joined from the challenge config on request
+	Thresholds map[string]float64 `json:"thresholds,omitempty"`
+}
+
+// ChallengeConfig represents a challenge's definition
+type ChallengeConfig struct {
+	// Challenge ID
+	ID int64 `json:"id"`
+	// Names and descriptions keyed by locale (e.g., en_US)
+	LocalizedNames map[string]ChallengeName `json:"localizedNames"`
+	// State of the challenge (ENABLED, DISABLED, HIDDEN or ARCHIVED)
+	State string `json:"state"`
+	// Whether progress is tracked for the LIFETIME or per SEASON
+	Tracking string `json:"tracking"`
+	// Whether the challenge has apex leaderboards
+	Leaderboard bool `json:"leaderboard"`
+	// Value needed for each level, keyed by level
+	Thresholds map[string]float64 `json:"thresholds"`
+	// Timestamp when the challenge starts (omitted if always active)
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// Timestamp when the challenge ends (omitted if it never ends)
+	EndTime *time.Time `json:"endTime,omitempty"`
+}
+
+// ChallengeName represents a challenge's name and descriptions in one locale
+type ChallengeName struct {
+	Name             string `json:"name"`
+	ShortDescription string `json:"shortDescription"`
+	Description      string `json:"description"`
+}
+
+// ChallengeLeaderboardEntry represents one player on a challenge's apex leaderboard
+type ChallengeLeaderboardEntry struct {
+	// 1-based position on the leaderboard
+	Position int `json:"position"`
+	// Player's PUUID
+	PUUID string `json:"puuid"`
+	// Player's challenge value
+	Value float64 `json:"value"`
+}
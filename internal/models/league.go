@@ -0,0 +1,77 @@
+package models
+
+// League represents a whole ranked league, such as a queue's Challenger league
+type League struct {
+	// Unique league identifier
+	LeagueID string `json:"leagueId"`
+	// League name (e.g., "Sejuani's Spellslingers")
+	Name string `json:"name"`
+	// Queue type (RANKED_SOLO_5x5 or RANKED_FLEX_SR)
+	Queue string `json:"queue"`
+	// Tier of every entry in the league (CHALLENGER, GRANDMASTER or MASTER)
+	Tier string `json:"tier"`
+	// Players in the league, in the order returned by Riot
+	Entries []LeagueEntry `json:"entries"`
+}
+
+// LeagueEntry represents one player's standing in a ranked league
+type LeagueEntry struct {
+	// League the entry belongs to
+	LeagueID string `json:"leagueId"`
+	// Player's PUUID
+	PUUID string `json:"puuid"`
+	// Queue type (RANKED_SOLO_5x5 or RANKED_FLEX_SR)
+	QueueType string `json:"queueType"`
+	// Rank tier (IRON through CHALLENGER)
+	Tier string `json:"tier"`
+	// Division within the tier (I, II, III, IV; always I for apex tiers)
+	Rank string `json:"rank"`
+	// League points
+	LeaguePoints int `json:"leaguePoints"`
+	// Total ranked wins
+	Wins int `json:"wins"`
+	// Total ranked losses
+	Losses int `json:"losses"`
+	// Whether the player has won three or more games in a row
+	HotStreak bool `json:"hotStreak"`
+	// Whether the player has played 100 or more games in the league
+	Veteran bool `json:"veteran"`
+	// Whether the player recently joined the league
+	FreshBlood bool `json:"freshBlood"`
+	// Whether the player is subject to rank decay for inactivity
+	Inactive bool `json:"inactive"`
+	// Promotion series in progress (omitted when the player is not in one)
+	MiniSeries *MiniSeries `json:"miniSeries,omitempty"`
+}
+
+// MiniSeries represents a promotion series in progress
+type MiniSeries struct {
+	// Wins needed to promote
+	Target int `json:"target"`
+	// Series games won so far
+	Wins int `json:"wins"`
+	// Series games lost so far
+	Losses int `json:"losses"`
+	// Game results so far, one character per game (W win, L loss, N not played)
+	Progress string `json:"progress"`
+}
+
+// Leaderboard represents one page of a ranked ladder, highest league points first
+type Leaderboard struct {
+	// Queue type (RANKED_SOLO_5x5 or RANKED_FLEX_SR)
+	Queue string `json:"queue"`
+	// Rank tier of the ladder
+	Tier string `json:"tier"`
+	// Division of the ladder (empty for apex tiers)
+	Division string `json:"division,omitempty"`
+	// 1-based page number
+	Page int `json:"page"`
+	// Number of entries requested per page (apex tiers only)
+	PageSize int `json:"pageSize,omitempty"`
+	// Total number of players in the league (apex tiers only)
+	Total int `json:"total,omitempty"`
+	// Whether a later page may contain more entries
+	HasMore bool `json:"hasMore"`
+	// Entries on this page
+	Entries []LeagueEntry `json:"entries"`
+}
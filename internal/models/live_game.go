@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// ActiveGame represents the game a player is currently in
+// When the player is not in a game, InGame is false and every other field is empty
+type ActiveGame struct {
+	// Whether the player is currently in a game
+	InGame bool `json:"inGame"`
+	// Unique game identifier (matches the numeric part of the match ID once the game ends)
+	GameID int64 `json:"gameId"`
+	// Game mode (CLASSIC, ARAM, etc.)
+	GameMode string `json:"gameMode"`
+	// Game type (MATCHED, CUSTOM, etc.)
+	GameType string `json:"gameType"`
+	// Queue ID (e.g., 420 for Ranked Solo/Duo)
+	QueueID int `json:"queueId"`
+	// Human-readable queue name (empty for queues missing from the local queue table)
+	QueueName string `json:"queueName"`
+	// Map ID (e.g., 11 for Summoner's Rift)
+	MapID int `json:"mapId"`
+	// Riot platform the game is being played on (e.g., NA1)
+	PlatformID string `json:"platformId"`
+	// Timestamp when the game started (omitted while players are still loading and when not in a game)
+	GameStartTime *time.Time `json:"gameStartTime,omitempty"`
+	// Seconds elapsed since the game started, as reported by Riot
+	GameLength int `json:"gameLength"`
+	// Champions banned during champion select
+	Bans []ActiveGameBan `json:"bans"`
+	// Players in the game and their champion picks
+	Participants []ActiveGameParticipant `json:"participants"`
+}
+
+// ActiveGameBan represents a champion banned during champion select
+type ActiveGameBan struct {
+	// Banned champion ID (-1 when the ban was skipped)
+	ChampionID int `json:"championId"`
+	// Team that made the ban (100 or 200)
+	TeamID int `json:"teamId"`
+	// Order in which the ban was made
+	PickTurn int `json:"pickTurn"`
+}
+
+// ActiveGameParticipant represents a player in an active game
+type ActiveGameParticipant struct {
+	// Player's PUUID (empty for bots)
+	PUUID string `json:"puuid"`
+	// Player's Riot ID (gameName#tagLine)
+	RiotID string `json:"riotId"`
+	// Team the player is on (100 or 200)
+	TeamID int `json:"teamId"`
+	// Map side of the team (blue or red)
+	Side string `json:"side"`
+	// Champion the player picked
+	ChampionID int `json:"championId"`
+	// Summoner spell IDs
+	Spell1ID int `json:"spell1Id"`
+	Spell2ID int `json:"spell2Id"`
+	// Profile icon ID
+	ProfileIconID int `json:"profileIconId"`
+	// Whether the participant is a bot
+	Bot bool `json:"bot"`
+	// Rune path and rune IDs the player selected
+	PerkStyle    int   `json:"perkStyle"`
+	PerkSubStyle int   `json:"perkSubStyle"`
+	PerkIDs      []int `json:"perkIds"`
+}
@@ -18,6 +18,16 @@ type Summoner struct {
 	SummonerLevel int64 `json:"summonerLevel"`
 }
 
+// Account represents a Riot account, identified across all Riot games by PUUID
+type Account struct {
+	// Encrypted PUUID (Player Universally Unique IDentifier)
+	PUUID string `json:"puuid"`
+	// Riot ID name (the part before #)
+	GameName string `json:"gameName"`
+	// Riot ID tagline (the part after #)
+	TagLine string `json:"tagLine"`
+}
+
 // Match represents a single League of Legends match
 type Match struct {
 	// Unique match identifier
@@ -30,16 +40,94 @@ type Match struct {
 	GameMode string `json:"gameMode"`
 	// Game type (e.g., MATCHED_GAME)
 	GameType string `json:"gameType"`
+	// Queue ID (e.g., 420 for ranked solo)
+	QueueID int `json:"queueId"`
+	// Human-readable queue name (empty for queues missing from the local queue table)
+	QueueName string `json:"queueName"`
+	// Full client version the match was played on (e.g., 14.20.628.4212)
+	GameVersion string `json:"gameVersion"`
+	// Patch the match was played on (e.g., 14.20)
+	Patch string `json:"patch"`
+	// Map ID (e.g., 11 for Summoner's Rift, 12 for Howling Abyss)
+	MapID int `json:"mapId"`
+	// Riot platform the match was played on (e.g., NA1)
+	PlatformID string `json:"platformId"`
+	// Timestamp when the game started, after champion select and loading (omitted for older matches)
+	GameStartTimestamp *time.Time `json:"gameStartTimestamp,omitempty"`
+	// Timestamp when the game ended (omitted for older matches)
+	GameEndTimestamp *time.Time `json:"gameEndTimestamp,omitempty"`
 	// List of all participants in the match
 	Participants []Participant `json:"participants"`
+	// Both teams' bans, objectives and result; participants link to them by TeamID
+	Teams []Team `json:"teams"`
+}
+
+// Team IDs used by match-v5
+const (
+	TeamIDBlue = 100
+	TeamIDRed  = 200
+)
+
+// Map sides
+const (
+	SideBlue = "blue"
+	SideRed  = "red"
+)
+
+// Team represents one team's bans, objectives and result in a match
+type Team struct {
+	// Team ID (100 blue side, 200 red side)
+	TeamID int `json:"teamId"`
+	// Map side ("blue" or "red")
+	Side string `json:"side"`
+	// Whether the team won the match
+	Win bool `json:"win"`
+	// Champions banned by the team, in pick order
+	Bans []Ban `json:"bans"`
+	// Objective firsts and kill counts
+	Objectives TeamObjectives `json:"objectives"`
+}
+
+// Ban represents a champion banned during champion select
+type Ban struct {
+	// Banned champion ID (-1 when the ban was skipped)
+	ChampionID int `json:"championId"`
+	// Pick turn the ban belongs to (1-5)
+	PickTurn int `json:"pickTurn"`
+}
+
+// TeamObjectives holds a team's result for each objective type
+type TeamObjectives struct {
+	Baron    Objective `json:"baron"`
+	Champion Objective `json:"champion"`
+	Dragon   Objective `json:"dragon"`
+	// Void grubs
+	Horde      Objective `json:"horde"`
+	Inhibitor  Objective `json:"inhibitor"`
+	RiftHerald Objective `json:"riftHerald"`
+	Tower      Objective `json:"tower"`
+}
+
+// Objective records whether a team took an objective first and how many times it took it
+type Objective struct {
+	First bool `json:"first"`
+	Kills int  `json:"kills"`
 }
 
 // Participant represents a player's performance in a specific match
 type Participant struct {
+	// Participant ID used by the match timeline (1-5 blue side, 6-10 red side)
+	ParticipantID int `json:"participantId"`
+	// Team ID (100 blue side, 200 red side)
+	TeamID int `json:"teamId"`
 	// Player's PUUID
 	PUUID string `json:"puuid"`
-	// Summoner name at the time of the match
+	// Summoner name at the time of the match (Riot leaves this empty for newer matches)
 	SummonerName string `json:"summonerName"`
+	// Riot ID name at the time of the match, or looked up from Account-V1 when requested
+	RiotIDGameName string `json:"riotIdGameName,omitempty"`
+	// Riot ID tagline at the time of the match, or looked up from Account-V1 when requested
+	RiotIDTagline string `json:"riotIdTagline,omitempty"`
 	// Champion ID played in this match
 	ChampionID int `json:"championId"`
 	// Champion name for easier reference
@@ -64,6 +152,105 @@ type Participant struct {
 	Win bool `json:"win"`
 	// Player's role in the match (TOP, JUNGLE, MID, BOT, SUPPORT)
 	TeamPosition string `json:"teamPosition"`
+	// Champion level at the end of the match
+	ChampLevel int `json:"champLevel"`
+	// Final inventory
+	Items ParticipantItems `json:"items"`
+	// Summoner spell IDs (e.g., 4 Flash, 12 Teleport)
+	Summoner1ID int `json:"summoner1Id"`
+	Summoner2ID int `json:"summoner2Id"`
+	// Rune page
+	Perks Perks `json:"perks"`
+	// Jungle monsters killed
+	NeutralMinionsKilled int `json:"neutralMinionsKilled"`
+	// Wards placed, including trinkets
+	WardsPlaced int `json:"wardsPlaced"`
+	// Enemy wards destroyed
+	WardsKilled int `json:"wardsKilled"`
+	// Control wards bought
+	VisionWardsBoughtInGame int `json:"visionWardsBoughtInGame"`
+	// Control wards placed
+	DetectorWardsPlaced int `json:"detectorWardsPlaced"`
+	// Damage to champions by type (sums to TotalDamageDealtToChampions)
+	PhysicalDamageDealtToChampions int `json:"physicalDamageDealtToChampions"`
+	MagicDamageDealtToChampions    int `json:"magicDamageDealtToChampions"`
+	TrueDamageDealtToChampions     int `json:"trueDamageDealtToChampions"`
+	// Damage to every target, including minions and monsters
+	TotalDamageDealt int `json:"totalDamageDealt"`
+	// Damage to turrets and inhibitors
+	DamageDealtToBuildings int `json:"damageDealtToBuildings"`
+	// Damage to turrets, dragons, barons and heralds
+	DamageDealtToObjectives int `json:"damageDealtToObjectives"`
+	// Damage prevented by resistances and shields
+	DamageSelfMitigated int `json:"damageSelfMitigated"`
+	// Healing done to self and allies
+	TotalHeal int `json:"totalHeal"`
+	// Shielding done to allies
+	TotalDamageShieldedOnTeammates int `json:"totalDamageShieldedOnTeammates"`
+	// Turrets and inhibitors this player landed the final hit on
+	TurretKills    int `json:"turretKills"`
+	InhibitorKills int `json:"inhibitorKills"`
+	// Epic monsters this player landed the final hit on
+	DragonKills int `json:"dragonKills"`
+	BaronKills  int `json:"baronKills"`
+	// Epic monsters smited away from the enemy team
+	ObjectivesStolen int `json:"objectivesStolen"`
+	// Multikill counts
+	DoubleKills int `json:"doubleKills"`
+	TripleKills int `json:"tripleKills"`
+	QuadraKills int `json:"quadraKills"`
+	PentaKills  int `json:"pentaKills"`
+	// Largest multikill (1 to 5) and kill streak of the match
+	LargestMultiKill    int `json:"largestMultiKill"`
+	LargestKillingSpree int `json:"largestKillingSpree"`
+	// Smart ping usage
+	Pings ParticipantPings `json:"pings"`
+}
+
+// ParticipantItems is a participant's inventory by slot
+type ParticipantItems struct {
+	// Item IDs in slots 0-5; 0 means an empty slot
+	Slots []int `json:"slots"`
+	// Trinket item ID (slot 6); 0 if none
+	Trinket int `json:"trinket"`
+}
+
+// Perks is a participant's rune page
+type Perks struct {
+	// Primary rune path ID (e.g., 8100 Domination)
+	PrimaryStyle int `json:"primaryStyle"`
+	// Primary path runes, keystone first
+	PrimaryRunes []int `json:"primaryRunes"`
+	// Secondary rune path ID
+	SubStyle int `json:"subStyle"`
+	// Secondary path runes
+	SubRunes []int `json:"subRunes"`
+	// Stat shard IDs
+	StatPerks StatPerks `json:"statPerks"`
+}
+
+// StatPerks holds a rune page's stat shards
+type StatPerks struct {
+	Offense int `json:"offense"`
+	Flex    int `json:"flex"`
+	Defense int `json:"defense"`
+}
+
+// ParticipantPings counts the smart pings a participant used
+type ParticipantPings struct {
+	AllIn         int `json:"allIn"`
+	AssistMe      int `json:"assistMe"`
+	Basic         int `json:"basic"`
+	Command       int `json:"command"`
+	Danger        int `json:"danger"`
+	EnemyMissing  int `json:"enemyMissing"`
+	EnemyVision   int `json:"enemyVision"`
+	GetBack       int `json:"getBack"`
+	Hold          int `json:"hold"`
+	NeedVision    int `json:"needVision"`
+	OnMyWay       int `json:"onMyWay"`
+	Push          int `json:"push"`
+	VisionCleared int `json:"visionCleared"`
 }
 
 // RankedStats represents a player's ranked statistics for a specific queue
@@ -80,4 +267,64 @@ type RankedStats struct {
 	Wins int `json:"wins"`
 	// Total ranked losses
 	Losses int `json:"losses"`
+	// Percentage of ranked games won, rounded to one decimal (0 when no games were played)
+	WinRate float64 `json:"winRate"`
+	// League the player is placed in
+	LeagueID string `json:"leagueId"`
+	// Whether the player has won three or more games in a row
+	HotStreak bool `json:"hotStreak"`
+	// Whether the player has played 100 or more games in the league
+	Veteran bool `json:"veteran"`
+	// Whether the player recently joined the league
+	FreshBlood bool `json:"freshBlood"`
+	// Whether the player is subject to rank decay for inactivity
+	Inactive bool `json:"inactive"`
+	// Promotion series in progress (omitted when the player is not in one)
+	MiniSeries *MiniSeries `json:"miniSeries,omitempty"`
+}
+
+// MatchHistory represents the result of a match history lookup
+type MatchHistory struct {
+	// Matches that were retrieved successfully, in match list order
+	Matches []Match `json:"matches"`
+	// Matches that could not be retrieved
+	Failures []MatchFailure `json:"failures"`
+	// Opaque cursor for fetching the next page (omitted on the last page)
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// MatchFailure describes a match that could not be retrieved
+type MatchFailure struct {
+	// ID of the match that failed
+	MatchID string `json:"matchId"`
+	// Stable reason the match could not be retrieved (e.g., not_found, rate_limited, upstream_timeout)
+	Reason string `json:"reason"`
+	// HTTP status returned by the Riot API (0 if the request never completed)
+	StatusCode int `json:"statusCode,omitempty"`
+}
+
+// ChampionMastery represents a player's mastery of one champion
+type ChampionMastery struct {
+	// Player's PUUID
+	PUUID string `json:"puuid"`
+	// Champion ID the mastery belongs to
+	ChampionID int `json:"championId"`
+	// Mastery level
+	ChampionLevel int `json:"championLevel"`
+	// Total mastery points earned on the champion
+	ChampionPoints int `json:"championPoints"`
+	// Points earned since the current level was reached
+	ChampionPointsSinceLastLevel int `json:"championPointsSinceLastLevel"`
+	// Points still needed for the next level (0 when no more points are needed)
+	ChampionPointsUntilNextLevel int `json:"championPointsUntilNextLevel"`
+	// Marks of mastery needed to level up
+	MarkRequiredForNextLevel int `json:"markRequiredForNextLevel"`
+	// Marks of mastery earned toward the next level
+	TokensEarned int `json:"tokensEarned"`
+	// Season milestone reached on the champion
+	ChampionSeasonMilestone int `json:"championSeasonMilestone"`
+	// Grades (e.g., S+, A) earned toward the current milestone
+	MilestoneGrades []string `json:"milestoneGrades"`
+	// Timestamp of the last game played on the champion
+	LastPlayTime time.Time `json:"lastPlayTime"`
 }
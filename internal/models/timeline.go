@@ -0,0 +1,164 @@
+package models
+
+// Timeline event types decoded into typed payloads
+const (
+	EventTypeChampionKill     = "CHAMPION_KILL"
+	EventTypeItemPurchased    = "ITEM_PURCHASED"
+	EventTypeEliteMonsterKill = "ELITE_MONSTER_KILL"
+	EventTypeBuildingKill     = "BUILDING_KILL"
+	EventTypeWardPlaced       = "WARD_PLACED"
+	EventTypeSkillLevelUp     = "SKILL_LEVEL_UP"
+)
+
+// MatchTimeline represents the minute-by-minute timeline of a match
+type MatchTimeline struct {
+	// Unique match identifier
+	MatchID string `json:"matchId"`
+	// Milliseconds between frames (usually 60000)
+	FrameInterval int64 `json:"frameInterval"`
+	// Maps the participant IDs used in frames and events to PUUIDs
+	Participants []TimelineParticipant `json:"participants"`
+	// Snapshots of the game, one per frame interval
+	Frames []TimelineFrame `json:"frames"`
+}
+
+// TimelineParticipant maps a timeline participant ID to a player
+type TimelineParticipant struct {
+	// Participant ID (1-5 blue side, 6-10 red side)
+	ParticipantID int `json:"participantId"`
+	// Player's PUUID
+	PUUID string `json:"puuid"`
+}
+
+// TimelineFrame is a snapshot of every participant plus the events since the previous frame
+type TimelineFrame struct {
+	// Milliseconds since the game started
+	Timestamp int64 `json:"timestamp"`
+	// State of each participant, ordered by participant ID
+	ParticipantFrames []ParticipantFrame `json:"participantFrames"`
+	// Events that happened since the previous frame, in game order
+	Events []TimelineEvent `json:"events"`
+}
+
+// ParticipantFrame is a participant's state at a frame, used for gold and XP curves
+type ParticipantFrame struct {
+	// Participant ID (1-5 blue side, 6-10 red side)
+	ParticipantID int `json:"participantId"`
+	// Champion level
+	Level int `json:"level"`
+	// Total experience
+	XP int `json:"xp"`
+	// Unspent gold
+	CurrentGold int `json:"currentGold"`
+	// Total gold earned so far
+	TotalGold int `json:"totalGold"`
+	// Lane minions killed so far
+	MinionsKilled int `json:"minionsKilled"`
+	// Jungle monsters killed so far
+	JungleMinionsKilled int `json:"jungleMinionsKilled"`
+	// Map position at the frame
+	Position Position `json:"position"`
+}
+
+// Position is a point on the map in game units
+type Position struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// TimelineEvent is a single timeline event
+// Exactly one payload is set for the decoded event types; other types carry only Type and Timestamp
+type TimelineEvent struct {
+	// Event type (e.g., CHAMPION_KILL)
+	Type string `json:"type"`
+	// Milliseconds since the game started
+	Timestamp int64 `json:"timestamp"`
+	// Payload for CHAMPION_KILL events
+	ChampionKill *ChampionKillEvent `json:"championKill,omitempty"`
+	// Payload for ITEM_PURCHASED events
+	ItemPurchased *ItemPurchasedEvent `json:"itemPurchased,omitempty"`
+	// Payload for ELITE_MONSTER_KILL events
+	EliteMonsterKill *EliteMonsterKillEvent `json:"eliteMonsterKill,omitempty"`
+	// Payload for BUILDING_KILL events
+	BuildingKill *BuildingKillEvent `json:"buildingKill,omitempty"`
+	// Payload for WARD_PLACED events
+	WardPlaced *WardPlacedEvent `json:"wardPlaced,omitempty"`
+	// Payload for SKILL_LEVEL_UP events
+	SkillLevelUp *SkillLevelUpEvent `json:"skillLevelUp,omitempty"`
+}
+
+// ChampionKillEvent describes a champion being killed
+type ChampionKillEvent struct {
+	// Participant ID of the killer (0 for executions by minions, turrets or monsters)
+	KillerID int `json:"killerId"`
+	// Participant ID of the champion killed
+	VictimID int `json:"victimId"`
+	// Participant IDs of the assisting champions
+	AssistingParticipantIDs []int `json:"assistingParticipantIds"`
+	// Where the kill happened
+	Position Position `json:"position"`
+	// Gold awarded for the kill
+	Bounty int `json:"bounty"`
+	// Extra gold awarded for ending the victim's streak
+	ShutdownBounty int `json:"shutdownBounty"`
+	// Killer's kill streak including this kill
+	KillStreakLength int `json:"killStreakLength"`
+}
+
+// ItemPurchasedEvent describes an item bought by a participant
+type ItemPurchasedEvent struct {
+	// Participant ID of the buyer
+	ParticipantID int `json:"participantId"`
+	// Item ID bought
+	ItemID int `json:"itemId"`
+}
+
+// EliteMonsterKillEvent describes a dragon, baron, herald or other epic monster being killed
+type EliteMonsterKillEvent struct {
+	// Participant ID of the killer
+	KillerID int `json:"killerId"`
+	// Team ID (100 blue, 200 red) of the killer
+	KillerTeamID int `json:"killerTeamId"`
+	// Monster type (e.g., DRAGON, BARON_NASHOR, RIFTHERALD)
+	MonsterType string `json:"monsterType"`
+	// Monster sub-type for dragons (e.g., FIRE_DRAGON, ELDER_DRAGON)
+	MonsterSubType string `json:"monsterSubType,omitempty"`
+	// Where the kill happened
+	Position Position `json:"position"`
+}
+
+// BuildingKillEvent describes a turret or inhibitor being destroyed
+type BuildingKillEvent struct {
+	// Participant ID of the killer (0 when destroyed by minions)
+	KillerID int `json:"killerId"`
+	// Team ID (100 blue, 200 red) that owned the building
+	TeamID int `json:"teamId"`
+	// Building type (TOWER_BUILDING or INHIBITOR_BUILDING)
+	BuildingType string `json:"buildingType"`
+	// Lane of the building (TOP_LANE, MID_LANE, BOT_LANE)
+	LaneType string `json:"laneType"`
+	// Turret tier for towers (e.g., OUTER_TURRET, NEXUS_TURRET)
+	TowerType string `json:"towerType,omitempty"`
+	// Participant IDs of the assisting champions
+	AssistingParticipantIDs []int `json:"assistingParticipantIds"`
+	// Where the building stood
+	Position Position `json:"position"`
+}
+
+// WardPlacedEvent describes a ward being placed
+type WardPlacedEvent struct {
+	// Participant ID of the player who placed the ward
+	CreatorID int `json:"creatorId"`
+	// Ward type (e.g., YELLOW_TRINKET, CONTROL_WARD, SIGHT_WARD)
+	WardType string `json:"wardType"`
+}
+
+// SkillLevelUpEvent describes a participant leveling up an ability
+type SkillLevelUpEvent struct {
+	// Participant ID of the player
+	ParticipantID int `json:"participantId"`
+	// Ability slot (1 Q, 2 W, 3 E, 4 R)
+	SkillSlot int `json:"skillSlot"`
+	// How the level was gained (NORMAL or EVOLVE)
+	LevelUpType string `json:"levelUpType"`
+}
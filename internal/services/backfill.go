@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// BackfillOptions selects the matches walked by Backfill
+type BackfillOptions struct {
+	// Queue ID filter (e.g., 420 for ranked solo); 0 means any queue
+	Queue int `json:"queue,omitempty"`
+	// Match type filter: ranked, normal, tourney or tutorial; empty means any type
+	Type string `json:"type,omitempty"`
+	// Oldest match start to include, in epoch seconds; 0 walks the whole history
+	StartTime int64 `json:"startTime,omitempty"`
+	// Newest match start to include, in epoch seconds; 0 means the time the backfill starts
+	EndTime int64 `json:"endTime,omitempty"`
+	// Checkpoint from an earlier backfill to resume from; replaces the filters above when set
+	Checkpoint string `json:"checkpoint,omitempty"`
+}
+
+// BackfillCallbacks receives backfill results as they arrive
+// Callbacks are invoked from a single goroutine, so they need no locking of their own; nil callbacks are skipped
+type BackfillCallbacks struct {
+	// Called with each page of match IDs before its details are fetched
+	OnMatchIDs func(matchIDs []string)
+	// Called with each match as its details arrive, in completion order
+	OnMatch func(match *models.Match)
+	// Called for each match whose details could not be fetched
+	OnFailure func(failure models.MatchFailure)
+	// Called after each page with the checkpoint to resume from; empty once the history is exhausted
+	OnCheckpoint func(checkpoint string)
+}
+
+// Validate checks the options without starting a backfill
+func (options BackfillOptions) Validate() error {
+	_, err := options.listOptions(time.Now())
+	return err
+}
+
+// listOptions returns the first page to fetch, pinning an open-ended window to now
+// Pinning keeps page offsets stable when new games are played during or between runs
+func (options BackfillOptions) listOptions(now time.Time) (MatchListOptions, error) {
+	if options.Checkpoint != "" {
+		listOptions, err := DecodeMatchCursor(options.Checkpoint)
+		if err != nil {
+			return MatchListOptions{}, &InvalidMatchListOptionError{Field: "checkpoint", Message: "malformed checkpoint"}
+		}
+		return listOptions, nil
+	}
+
+	listOptions := MatchListOptions{
+		Count:     MaxMatchCount,
+		Queue:     options.Queue,
+		Type:      options.Type,
+		StartTime: options.StartTime,
+		EndTime:   options.EndTime,
+	}
+	if listOptions.EndTime == 0 {
+		listOptions.EndTime = now.Unix()
+	}
+
+	if err := listOptions.Validate(); err != nil {
+		return MatchListOptions{}, err
+	}
+	return listOptions, nil
+}
+
+// Backfill walks a player's match history page by page until it is exhausted or passes options.StartTime
+// Match IDs and details are streamed to callbacks as they arrive; a checkpoint is reported after every page,
+// so an interrupted backfill resumes by passing the last checkpoint (at most one page is fetched again)
+// Failed match details are reported and skipped; a failed match ID page stops the backfill with its error
+// Up to parallelism match details are fetched concurrently
+func Backfill(ctx context.Context, riotService RiotServiceInterface, region string, puuid string, options BackfillOptions, parallelism int, callbacks BackfillCallbacks) error {
+	listOptions, err := options.listOptions(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for {
+		matchIDs, err := riotService.GetMatchIDs(ctx, region, puuid, listOptions)
+		if err != nil {
+			return err
+		}
+
+		if callbacks.OnMatchIDs != nil && len(matchIDs) > 0 {
+			callbacks.OnMatchIDs(matchIDs)
+		}
+
+		if err := streamMatchDetails(ctx, riotService, region, matchIDs, parallelism, callbacks); err != nil {
+			return err
+		}
+
+		checkpoint := nextMatchCursor(listOptions, len(matchIDs))
+		if callbacks.OnCheckpoint != nil {
+			callbacks.OnCheckpoint(checkpoint)
+		}
+
+		// match-v5 applies the time window itself, so a short page means nothing older is left
+		if checkpoint == "" {
+			return nil
+		}
+		listOptions.Start += listOptions.Count
+	}
+}
+
+// streamMatchDetails fetches the details of matchIDs on at most parallelism workers and reports each result as it completes
+func streamMatchDetails(ctx context.Context, riotService RiotServiceInterface, region string, matchIDs []string, parallelism int, callbacks BackfillCallbacks) error {
+	// Results carry their match ID because they arrive out of order
+	type streamedResult struct {
+		matchID string
+		matchFetchResult
+	}
+
+	results := make(chan streamedResult)
+	go func() {
+		defer close(results)
+		runParallel(ctx, len(matchIDs), parallelism, func(index int) {
+			match, err := riotService.GetMatchDetails(ctx, region, matchIDs[index])
+			results <- streamedResult{matchID: matchIDs[index], matchFetchResult: matchFetchResult{match: match, err: err}}
+		})
+	}()
+
+	// Report results on this goroutine so callbacks are never called concurrently
+	for result := range results {
+		if result.err != nil {
+			// Requests cut short by cancellation are not failures of the match itself
+			if ctx.Err() != nil {
+				continue
+			}
+			log.Warn().
+				Err(result.err).
+				Str("match_id", result.matchID).
+				Msg("Failed to fetch match details during backfill")
+			if callbacks.OnFailure != nil {
+				callbacks.OnFailure(newMatchFailure(result.matchID, result.err))
+			}
+			continue
+		}
+
+		if callbacks.OnMatch != nil {
+			callbacks.OnMatch(result.match)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("backfill cancelled: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// Limits on backfill jobs kept by BackfillJobManager
+const (
+	// Backfills allowed to run at once; each one shares the Riot API rate limits with live requests
+	maxRunningBackfillJobs = 4
+	// How long a finished job's results stay available
+	backfillJobRetention = time.Hour
+	// Matches and failures a job keeps for status requests; later results are counted but not kept
+	maxStoredBackfillResults = 1000
+)
+
+// MaxBackfillResultsPageSize is the default and maximum number of matches returned by one status request
+const MaxBackfillResultsPageSize = 100
+
+// BackfillJobStatus is the state of a backfill job
+type BackfillJobStatus string
+
+// Backfill job states
+const (
+	// The job is still walking the match history
+	BackfillJobRunning BackfillJobStatus = "running"
+	// Every page was fetched
+	BackfillJobCompleted BackfillJobStatus = "completed"
+	// A match ID page could not be fetched; the job can be resumed from its checkpoint
+	BackfillJobFailed BackfillJobStatus = "failed"
+	// The job was cancelled; it can be resumed from its checkpoint
+	BackfillJobCancelled BackfillJobStatus = "cancelled"
+)
+
+// BackfillProgress counts what a backfill job has fetched so far
+type BackfillProgress struct {
+	// Match ID pages fetched
+	Pages int `json:"pages"`
+	// Match IDs found
+	MatchIDs int `json:"matchIds"`
+	// Matches whose details were fetched
+	Matches int `json:"matches"`
+	// Matches whose details could not be fetched
+	Failures int `json:"failures"`
+}
+
+// BackfillJob is a snapshot of a backfill job
+type BackfillJob struct {
+	// Opaque job identifier
+	ID     string `json:"id"`
+	Region string `json:"region"`
+	PUUID  string `json:"puuid"`
+	// Current state of the job
+	Status   BackfillJobStatus `json:"status"`
+	Progress BackfillProgress  `json:"progress"`
+	// Checkpoint to resume from after the last completed page (empty once the history is exhausted)
+	Checkpoint string `json:"checkpoint,omitempty"`
+	// Stable reason the job failed, as returned by FailureReason (failed jobs only)
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	// Results fetched after the job stored maxStoredBackfillResults of them; counted in Progress but not kept
+	DroppedResults int `json:"droppedResults,omitempty"`
+	// One page of the stored matches, and the stored failures, only included when requested
+	Matches  []models.Match        `json:"matches,omitempty"`
+	Failures []models.MatchFailure `json:"failures,omitempty"`
+	// Offset of the next page of stored matches (only when more remain)
+	NextMatchOffset int `json:"nextMatchOffset,omitempty"`
+}
+
+// BackfillResultsPage selects the stored matches returned with a job snapshot
+type BackfillResultsPage struct {
+	// Index of the first stored match to return
+	MatchOffset int `json:"matchOffset,omitempty"`
+	// Matches to return; 0 means MaxBackfillResultsPageSize
+	MatchLimit int `json:"matchLimit,omitempty"`
+}
+
+// Validate checks the page against the status endpoint's limits
+func (page BackfillResultsPage) Validate() error {
+	switch {
+	case page.MatchOffset < 0:
+		return &InvalidMatchListOptionError{Field: "matchOffset", Message: "must not be negative"}
+	case page.MatchLimit < 0 || page.MatchLimit > MaxBackfillResultsPageSize:
+		return &InvalidMatchListOptionError{Field: "matchLimit", Message: fmt.Sprintf("must be between 1 and %d", MaxBackfillResultsPageSize)}
+	}
+	return nil
+}
+
+// BackfillJobLimitError is returned when too many backfill jobs are already running
+type BackfillJobLimitError struct {
+	Limit int
+}
+
+// Error implements the error interface
+func (limitError *BackfillJobLimitError) Error() string {
+	return fmt.Sprintf("backfill job limit reached: %d jobs already running", limitError.Limit)
+}
+
+// backfillJob is the manager's mutable record of one job
+type backfillJob struct {
+	snapshot BackfillJob
+	matches  []models.Match
+	failures []models.MatchFailure
+	cancel   context.CancelFunc
+}
+
+// BackfillJobManager runs backfills in the background and tracks their progress
+// Jobs live in memory, so they are lost on restart; their checkpoints are what make them resumable
+type BackfillJobManager struct {
+	riotService RiotServiceInterface
+	mutex       sync.Mutex
+	jobs        map[string]*backfillJob
+	// Number of match details each job fetches concurrently
+	parallelism int
+	// Clock, replaceable in tests
+	now func() time.Time
+}
+
+// NewBackfillJobManager creates a BackfillJobManager that fetches through riotService
+func NewBackfillJobManager(riotService RiotServiceInterface) *BackfillJobManager {
+	return &BackfillJobManager{
+		riotService: riotService,
+		jobs:        make(map[string]*backfillJob),
+		parallelism: defaultMatchFetchParallelism,
+		now:         time.Now,
+	}
+}
+
+// SetParallelism sets how many match details each job fetches concurrently; jobs already running keep their value
+func (manager *BackfillJobManager) SetParallelism(parallelism int) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	manager.parallelism = parallelism
+}
+
+// Start validates options and starts a backfill job for puuid in the background
+func (manager *BackfillJobManager) Start(region string, puuid string, options BackfillOptions) (BackfillJob, error) {
+	if err := options.Validate(); err != nil {
+		return BackfillJob{}, err
+	}
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.pruneLocked()
+
+	running := 0
+	for _, job := range manager.jobs {
+		if job.snapshot.Status == BackfillJobRunning {
+			running++
+		}
+	}
+	if running >= maxRunningBackfillJobs {
+		return BackfillJob{}, &BackfillJobLimitError{Limit: maxRunningBackfillJobs}
+	}
+
+	// Jobs outlive the request that started them, so they get their own context
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &backfillJob{
+		snapshot: BackfillJob{
+			ID:         newBackfillJobID(),
+			Region:     region,
+			PUUID:      puuid,
+			Status:     BackfillJobRunning,
+			Checkpoint: options.Checkpoint,
+			StartedAt:  manager.now(),
+		},
+		cancel: cancel,
+	}
+	manager.jobs[job.snapshot.ID] = job
+
+	go manager.run(ctx, job, options, manager.parallelism)
+
+	return job.snapshot, nil
+}
+
+// Job returns a snapshot of the job with the given ID
+// When includeMatches is set the snapshot carries the stored failures and the selected page of stored matches
+func (manager *BackfillJobManager) Job(id string, includeMatches bool, page BackfillResultsPage) (BackfillJob, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	job, exists := manager.jobs[id]
+	if !exists {
+		return BackfillJob{}, false
+	}
+
+	snapshot := job.snapshot
+	if includeMatches {
+		limit := page.MatchLimit
+		if limit <= 0 {
+			limit = MaxBackfillResultsPageSize
+		}
+		start := min(max(page.MatchOffset, 0), len(job.matches))
+		end := start + min(limit, len(job.matches)-start)
+
+		snapshot.Matches = append([]models.Match(nil), job.matches[start:end]...)
+		snapshot.Failures = append([]models.MatchFailure(nil), job.failures...)
+		if end < len(job.matches) {
+			snapshot.NextMatchOffset = end
+		}
+	}
+	return snapshot, true
+}
+
+// Cancel stops a running job; finished jobs are returned unchanged
+func (manager *BackfillJobManager) Cancel(id string) (BackfillJob, bool) {
+	manager.mutex.Lock()
+	job, exists := manager.jobs[id]
+	manager.mutex.Unlock()
+
+	if !exists {
+		return BackfillJob{}, false
+	}
+
+	job.cancel()
+	return manager.Job(id, false, BackfillResultsPage{})
+}
+
+// run performs the backfill and records its outcome
+func (manager *BackfillJobManager) run(ctx context.Context, job *backfillJob, options BackfillOptions, parallelism int) {
+	callbacks := BackfillCallbacks{
+		OnMatchIDs: func(matchIDs []string) {
+			manager.update(job, func() {
+				job.snapshot.Progress.MatchIDs += len(matchIDs)
+			})
+		},
+		OnMatch: func(match *models.Match) {
+			manager.update(job, func() {
+				if len(job.matches) < maxStoredBackfillResults {
+					job.matches = append(job.matches, *match)
+				} else {
+					job.snapshot.DroppedResults++
+				}
+				job.snapshot.Progress.Matches++
+			})
+		},
+		OnFailure: func(failure models.MatchFailure) {
+			manager.update(job, func() {
+				if len(job.failures) < maxStoredBackfillResults {
+					job.failures = append(job.failures, failure)
+				} else {
+					job.snapshot.DroppedResults++
+				}
+				job.snapshot.Progress.Failures++
+			})
+		},
+		OnCheckpoint: func(checkpoint string) {
+			manager.update(job, func() {
+				job.snapshot.Progress.Pages++
+				job.snapshot.Checkpoint = checkpoint
+			})
+		},
+	}
+
+	err := Backfill(ctx, manager.riotService, job.snapshot.Region, job.snapshot.PUUID, options, parallelism, callbacks)
+	job.cancel()
+
+	// Only a stable reason is exposed to clients; the full error stays in the logs
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Warn().Err(err).Str("job_id", job.snapshot.ID).Msg("Backfill job failed")
+	}
+
+	manager.update(job, func() {
+		finishedAt := manager.now()
+		job.snapshot.FinishedAt = &finishedAt
+
+		switch {
+		case err == nil:
+			job.snapshot.Status = BackfillJobCompleted
+		case errors.Is(err, context.Canceled):
+			job.snapshot.Status = BackfillJobCancelled
+		default:
+			job.snapshot.Status = BackfillJobFailed
+			job.snapshot.Error = FailureReason(err)
+		}
+	})
+}
+
+// update applies a change to a job's state under the manager lock
+func (manager *BackfillJobManager) update(job *backfillJob, change func()) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	change()
+}
+
+// pruneLocked forgets jobs that finished more than backfillJobRetention ago; the caller holds the lock
+func (manager *BackfillJobManager) pruneLocked() {
+	cutoff := manager.now().Add(-backfillJobRetention)
+	for id, job := range manager.jobs {
+		if job.snapshot.FinishedAt != nil && job.snapshot.FinishedAt.Before(cutoff) {
+			delete(manager.jobs, id)
+		}
+	}
+}
+
+// newBackfillJobID generates a random 128-bit hex job ID
+func newBackfillJobID() string {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(randomBytes)
+}
@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// pagedRiotService serves a fixed match history one page at a time
+type pagedRiotService struct {
+	countingRiotService
+	// Match IDs in the history, newest first
+	matchIDs []string
+	// Match IDs whose details fail to load
+	failingMatches map[string]bool
+	// Error returned by GetMatchIDs for pages starting at failAtStart (when set)
+	pageErr     error
+	failAtStart int
+	// Make GetMatchDetails wait for cancellation
+	blockDetails bool
+
+	mutex sync.Mutex
+	// Options passed to each GetMatchIDs call
+	requestedPages []MatchListOptions
+}
+
+func newPagedRiotService(matchCount int) *pagedRiotService {
+	service := &pagedRiotService{failingMatches: make(map[string]bool)}
+	for index := 0; index < matchCount; index++ {
+		service.matchIDs = append(service.matchIDs, fmt.Sprintf("NA1_%d", index))
+	}
+	return service
+}
+
+func (service *pagedRiotService) GetMatchIDs(ctx context.Context, region string, puuid string, options MatchListOptions) ([]string, error) {
+	service.mutex.Lock()
+	service.requestedPages = append(service.requestedPages, options)
+	service.mutex.Unlock()
+
+	if service.pageErr != nil && options.Start == service.failAtStart {
+		return nil, service.pageErr
+	}
+
+	start := min(options.Start, len(service.matchIDs))
+	end := min(options.Start+options.Count, len(service.matchIDs))
+	return service.matchIDs[start:end], nil
+}
+
+func (service *pagedRiotService) GetMatchDetails(ctx context.Context, region string, matchID string) (*models.Match, error) {
+	if service.blockDetails {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if service.failingMatches[matchID] {
+		return nil, &RiotAPIError{StatusCode: 404, Service: "match-v5"}
+	}
+	return &models.Match{MatchID: matchID}, nil
+}
+
+// TestBackfill_WalksAllPages tests that a backfill follows pages until the history is exhausted
+func TestBackfill_WalksAllPages(t *testing.T) {
+	service := newPagedRiotService(250)
+	service.failingMatches["NA1_120"] = true
+
+	var pages [][]string
+	var checkpoints []string
+	matchIDs := make(map[string]bool)
+	var failures []models.MatchFailure
+
+	err := Backfill(context.Background(), service, "na", "test-puuid", BackfillOptions{Queue: 420, EndTime: 1710000000}, defaultMatchFetchParallelism, BackfillCallbacks{
+		OnMatchIDs:   func(ids []string) { pages = append(pages, ids) },
+		OnMatch:      func(match *models.Match) { matchIDs[match.MatchID] = true },
+		OnFailure:    func(failure models.MatchFailure) { failures = append(failures, failure) },
+		OnCheckpoint: func(checkpoint string) { checkpoints = append(checkpoints, checkpoint) },
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(pages) != 3 || len(pages[2]) != 50 {
+		t.Errorf("Expected pages of 100, 100 and 50 match IDs, got %d pages", len(pages))
+	}
+
+	if len(matchIDs) != 249 {
+		t.Errorf("Expected 249 matches, got %d", len(matchIDs))
+	}
+
+	if len(failures) != 1 || failures[0].MatchID != "NA1_120" || failures[0].StatusCode != 404 {
+		t.Errorf("Expected one 404 failure for NA1_120, got %+v", failures)
+	}
+
+	if len(checkpoints) != 3 || checkpoints[2] != "" {
+		t.Errorf("Expected 3 checkpoints ending with an empty one, got %q", checkpoints)
+	}
+
+	for index, page := range service.requestedPages {
+		expected := MatchListOptions{Start: index * MaxMatchCount, Count: MaxMatchCount, Queue: 420, EndTime: 1710000000}
+		if page != expected {
+			t.Errorf("Expected page %d options %+v, got %+v", index, expected, page)
+		}
+	}
+}
+
+// TestBackfill_PinsEndTime tests that an open-ended window is pinned to the backfill's start
+func TestBackfill_PinsEndTime(t *testing.T) {
+	service := newPagedRiotService(0)
+
+	before := time.Now().Unix()
+	if err := Backfill(context.Background(), service, "na", "test-puuid", BackfillOptions{}, defaultMatchFetchParallelism, BackfillCallbacks{}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if endTime := service.requestedPages[0].EndTime; endTime < before || endTime > time.Now().Unix() {
+		t.Errorf("Expected endTime pinned to now, got %d", endTime)
+	}
+}
+
+// TestBackfill_ResumesFromCheckpoint tests that a failed backfill resumes at the page that failed
+func TestBackfill_ResumesFromCheckpoint(t *testing.T) {
+	service := newPagedRiotService(250)
+	service.pageErr = &RiotAPIError{StatusCode: 503, Service: "match-v5"}
+	service.failAtStart = 200
+
+	var lastCheckpoint string
+	err := Backfill(context.Background(), service, "na", "test-puuid", BackfillOptions{EndTime: 1710000000}, defaultMatchFetchParallelism, BackfillCallbacks{
+		OnCheckpoint: func(checkpoint string) { lastCheckpoint = checkpoint },
+	})
+
+	var riotAPIError *RiotAPIError
+	if !errors.As(err, &riotAPIError) {
+		t.Fatalf("Expected the page error, got: %v", err)
+	}
+
+	service.pageErr = nil
+	service.requestedPages = nil
+	var resumedMatches int
+	err = Backfill(context.Background(), service, "na", "test-puuid", BackfillOptions{Checkpoint: lastCheckpoint}, defaultMatchFetchParallelism, BackfillCallbacks{
+		OnMatch: func(match *models.Match) { resumedMatches++ },
+	})
+	if err != nil {
+		t.Fatalf("Expected no error on resume, got: %v", err)
+	}
+
+	if service.requestedPages[0].Start != 200 || service.requestedPages[0].EndTime != 1710000000 {
+		t.Errorf("Expected resume at start 200 with the original window, got %+v", service.requestedPages[0])
+	}
+
+	if resumedMatches != 50 {
+		t.Errorf("Expected 50 matches after resuming, got %d", resumedMatches)
+	}
+}
+
+// TestBackfill_InvalidOptions tests that invalid filters and checkpoints are rejected up front
+func TestBackfill_InvalidOptions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		options       BackfillOptions
+		expectedField string
+	}{
+		{"unknown type", BackfillOptions{Type: "arena"}, "type"},
+		{"end before start", BackfillOptions{StartTime: 200, EndTime: 100}, "endTime"},
+		{"malformed checkpoint", BackfillOptions{Checkpoint: "not a checkpoint"}, "checkpoint"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var invalidOptionError *InvalidMatchListOptionError
+			if err := testCase.options.Validate(); !errors.As(err, &invalidOptionError) || invalidOptionError.Field != testCase.expectedField {
+				t.Errorf("Expected invalid %s, got: %v", testCase.expectedField, err)
+			}
+		})
+	}
+}
+
+// TestBackfill_Cancelled tests that cancellation stops the backfill
+func TestBackfill_Cancelled(t *testing.T) {
+	service := newPagedRiotService(250)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := Backfill(ctx, service, "na", "test-puuid", BackfillOptions{}, defaultMatchFetchParallelism, BackfillCallbacks{
+		OnMatchIDs: func(matchIDs []string) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+
+	if len(service.requestedPages) != 1 {
+		t.Errorf("Expected only the first page to be requested before cancellation, got %d", len(service.requestedPages))
+	}
+}
+
+// waitForBackfillJob polls until the job leaves the running state
+func waitForBackfillJob(t *testing.T, manager *BackfillJobManager, id string) BackfillJob {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, exists := manager.Job(id, true, BackfillResultsPage{MatchLimit: MaxBackfillResultsPageSize})
+		if !exists {
+			t.Fatalf("Job %s not found", id)
+		}
+		if job.Status != BackfillJobRunning {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("Job %s did not finish", id)
+	return BackfillJob{}
+}
+
+// TestBackfillJobManager_Completes tests that a job records progress and results
+func TestBackfillJobManager_Completes(t *testing.T) {
+	manager := NewBackfillJobManager(newPagedRiotService(150))
+
+	job, err := manager.Start("na", "test-puuid", BackfillOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if job.ID == "" || job.Status != BackfillJobRunning {
+		t.Errorf("Expected a running job with an ID, got %+v", job)
+	}
+
+	job = waitForBackfillJob(t, manager, job.ID)
+	if job.Status != BackfillJobCompleted || job.FinishedAt == nil {
+		t.Errorf("Expected completed job, got %+v", job)
+	}
+
+	expectedProgress := BackfillProgress{Pages: 2, MatchIDs: 150, Matches: 150}
+	if job.Progress != expectedProgress {
+		t.Errorf("Expected progress %+v, got %+v", expectedProgress, job.Progress)
+	}
+
+	if len(job.Matches) != MaxBackfillResultsPageSize || job.NextMatchOffset != MaxBackfillResultsPageSize || job.Checkpoint != "" {
+		t.Errorf("Expected a first page of %d matches and no checkpoint, got %d matches, next offset %d and checkpoint %q",
+			MaxBackfillResultsPageSize, len(job.Matches), job.NextMatchOffset, job.Checkpoint)
+	}
+
+	lastPage, _ := manager.Job(job.ID, true, BackfillResultsPage{MatchOffset: job.NextMatchOffset})
+	if len(lastPage.Matches) != 50 || lastPage.NextMatchOffset != 0 {
+		t.Errorf("Expected a last page of 50 matches, got %d matches and next offset %d", len(lastPage.Matches), lastPage.NextMatchOffset)
+	}
+
+	if summary, _ := manager.Job(job.ID, false, BackfillResultsPage{}); summary.Matches != nil {
+		t.Error("Expected matches to be omitted unless requested")
+	}
+}
+
+// TestBackfillJobManager_CapsStoredResults tests that a job stops storing matches past the cap but keeps counting them
+func TestBackfillJobManager_CapsStoredResults(t *testing.T) {
+	manager := NewBackfillJobManager(newPagedRiotService(maxStoredBackfillResults + 50))
+
+	job, _ := manager.Start("na", "test-puuid", BackfillOptions{})
+	job = waitForBackfillJob(t, manager, job.ID)
+
+	if job.Progress.Matches != maxStoredBackfillResults+50 || job.DroppedResults != 50 {
+		t.Errorf("Expected %d matches with 50 dropped, got progress %+v with %d dropped",
+			maxStoredBackfillResults+50, job.Progress, job.DroppedResults)
+	}
+
+	lastPage, _ := manager.Job(job.ID, true, BackfillResultsPage{MatchOffset: maxStoredBackfillResults - 10})
+	if len(lastPage.Matches) != 10 || lastPage.NextMatchOffset != 0 {
+		t.Errorf("Expected the last 10 stored matches, got %d with next offset %d", len(lastPage.Matches), lastPage.NextMatchOffset)
+	}
+}
+
+// TestBackfillResultsPage_Validate tests that out-of-range status pages are rejected
+func TestBackfillResultsPage_Validate(t *testing.T) {
+	testCases := []struct {
+		page  BackfillResultsPage
+		field string
+	}{
+		{BackfillResultsPage{}, ""},
+		{BackfillResultsPage{MatchOffset: 200, MatchLimit: MaxBackfillResultsPageSize}, ""},
+		{BackfillResultsPage{MatchOffset: -1}, "matchOffset"},
+		{BackfillResultsPage{MatchLimit: -1}, "matchLimit"},
+		{BackfillResultsPage{MatchLimit: MaxBackfillResultsPageSize + 1}, "matchLimit"},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.page.Validate()
+
+		var invalidOptionError *InvalidMatchListOptionError
+		switch {
+		case testCase.field == "" && err != nil:
+			t.Errorf("Expected %+v to be valid, got: %v", testCase.page, err)
+		case testCase.field != "" && (!errors.As(err, &invalidOptionError) || invalidOptionError.Field != testCase.field):
+			t.Errorf("Expected %+v to be rejected on %s, got: %v", testCase.page, testCase.field, err)
+		}
+	}
+}
+
+// TestBackfillJobManager_Failed tests that a failed job keeps its checkpoint for resuming
+func TestBackfillJobManager_Failed(t *testing.T) {
+	service := newPagedRiotService(250)
+	service.pageErr = &RiotAPIError{StatusCode: 503, Service: "match-v5"}
+	service.failAtStart = 100
+	manager := NewBackfillJobManager(service)
+
+	job, _ := manager.Start("na", "test-puuid", BackfillOptions{})
+	job = waitForBackfillJob(t, manager, job.ID)
+
+	if job.Status != BackfillJobFailed || job.Error != FailureReasonUpstreamUnavailable {
+		t.Errorf("Expected failed job with an error, got %+v", job)
+	}
+
+	resumeOptions, err := DecodeMatchCursor(job.Checkpoint)
+	if err != nil || resumeOptions.Start != 100 {
+		t.Errorf("Expected checkpoint at start 100, got %+v (%v)", resumeOptions, err)
+	}
+}
+
+// TestBackfillJobManager_Cancel tests that a cancelled job stops and reports the cancelled state
+func TestBackfillJobManager_Cancel(t *testing.T) {
+	service := newPagedRiotService(250)
+	service.blockDetails = true
+	manager := NewBackfillJobManager(service)
+
+	job, _ := manager.Start("na", "test-puuid", BackfillOptions{})
+	if _, exists := manager.Cancel(job.ID); !exists {
+		t.Fatal("Expected job to exist")
+	}
+
+	job = waitForBackfillJob(t, manager, job.ID)
+	if job.Status != BackfillJobCancelled || job.Error != "" {
+		t.Errorf("Expected cancelled job without an error, got %+v", job)
+	}
+
+	if job.Progress.Failures != 0 {
+		t.Errorf("Expected cancelled requests not to count as failures, got %d", job.Progress.Failures)
+	}
+}
+
+// TestBackfillJobManager_Limit tests that only a bounded number of jobs run at once
+func TestBackfillJobManager_Limit(t *testing.T) {
+	manager := NewBackfillJobManager(newPagedRiotService(0))
+	for index := 0; index < maxRunningBackfillJobs; index++ {
+		manager.jobs[fmt.Sprint(index)] = &backfillJob{snapshot: BackfillJob{Status: BackfillJobRunning}}
+	}
+
+	_, err := manager.Start("na", "test-puuid", BackfillOptions{})
+
+	var limitError *BackfillJobLimitError
+	if !errors.As(err, &limitError) {
+		t.Errorf("Expected BackfillJobLimitError, got: %v", err)
+	}
+}
+
+// TestBackfillJobManager_PrunesFinishedJobs tests that finished jobs are forgotten after the retention period
+func TestBackfillJobManager_PrunesFinishedJobs(t *testing.T) {
+	currentTime := time.Now()
+	manager := NewBackfillJobManager(newPagedRiotService(0))
+	manager.now = func() time.Time { return currentTime }
+
+	finishedAt := currentTime.Add(-backfillJobRetention - time.Second)
+	manager.jobs["old"] = &backfillJob{snapshot: BackfillJob{Status: BackfillJobCompleted, FinishedAt: &finishedAt}}
+
+	job, err := manager.Start("na", "test-puuid", BackfillOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	waitForBackfillJob(t, manager, job.ID)
+
+	if _, exists := manager.Job("old", false, BackfillResultsPage{}); exists {
+		t.Error("Expected expired job to be pruned")
+	}
+}
@@ -0,0 +1,549 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/cache"
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// cacheSchemaVersion is embedded in every cache key
+// Bump it whenever a cached model changes shape so entries written by older builds are ignored
+const cacheSchemaVersion = 9
+
+// CacheOptions configures CachedRiotService
+type CacheOptions struct {
+	// How long summoner and account lookups (by Riot ID or PUUID) are cached
+	SummonerTTL time.Duration
+	// How long ranked stats are cached
+	RankedTTL time.Duration
+	// How long match details and timelines are cached (finished matches never change)
+	MatchTTL time.Duration
+	// How long champion masteries and mastery scores are cached
+	MasteryTTL time.Duration
+	// How long active games (and not-in-game results) are cached
+	LiveGameTTL time.Duration
+	// How long apex leagues and league entry pages are cached
+	LeagueTTL time.Duration
+	// How long player challenge data and challenge leaderboards are cached
+	ChallengeTTL time.Duration
+	// How long challenge configs are cached (they only change with patches)
+	ChallengeConfigTTL time.Duration
+	// Number of match details fetched concurrently when building a match history
+	MatchFetchParallelism int
+}
+
+// DefaultCacheOptions returns the cache options used when none are configured
+func DefaultCacheOptions() CacheOptions {
+	return CacheOptions{
+		SummonerTTL:           5 * time.Minute,
+		RankedTTL:             30 * time.Second,
+		MatchTTL:              24 * time.Hour,
+		MasteryTTL:            5 * time.Minute,
+		LiveGameTTL:           15 * time.Second,
+		LeagueTTL:             time.Minute,
+		ChallengeTTL:          5 * time.Minute,
+		ChallengeConfigTTL:    time.Hour,
+		MatchFetchParallelism: defaultMatchFetchParallelism,
+	}
+}
+
+// CacheCounters holds hit and miss counts for one cached method
+type CacheCounters struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// Cached method names used as keys for hit and miss counters
+const (
+	cachedSummonerByRiotID     = "GetSummonerByRiotID"
+	cachedSummonerByPUUID      = "GetSummonerByPUUID"
+	cachedAccountByPUUID       = "GetAccountByPUUID"
+	cachedAccountByRiotID      = "GetAccountByRiotID"
+	cachedMatchDetails         = "GetMatchDetails"
+	cachedMatchTimeline        = "GetMatchTimeline"
+	cachedRankedStats          = "GetRankedStats"
+	cachedRankedStatsByPUUID   = "GetRankedStatsByPUUID"
+	cachedChampionMasteries    = "GetChampionMasteries"
+	cachedTopChampionMasteries = "GetTopChampionMasteries"
+	cachedChampionMastery      = "GetChampionMastery"
+	cachedMasteryScore         = "GetMasteryScore"
+	cachedActiveGame           = "GetActiveGame"
+	cachedApexLeague           = "GetApexLeague"
+	cachedLeagueEntries        = "GetLeagueEntries"
+	cachedPlayerChallenges     = "GetPlayerChallenges"
+	cachedChallengeConfigs     = "GetChallengeConfigs"
+	cachedChallengeLeaderboard = "GetChallengeLeaderboard"
+)
+
+// CachedRiotService is a RiotServiceInterface decorator that caches Riot API lookups
+// Values are stored as JSON so any cache.Cache backend can be shared between replicas
+// Match ID lists are never cached because new games appear at any time
+type CachedRiotService struct {
+	// Underlying service that performs the Riot API calls
+	inner RiotServiceInterface
+	// Backend storing serialized lookups
+	backend cache.Cache
+	// TTLs per lookup type
+	options CacheOptions
+	// Hit and miss counters keyed by method name
+	counters map[string]*CacheCounters
+}
+
+// NewCachedRiotService wraps a RiotServiceInterface with caching in the given backend
+func NewCachedRiotService(inner RiotServiceInterface, backend cache.Cache, options CacheOptions) *CachedRiotService {
+	return &CachedRiotService{
+		inner:   inner,
+		backend: backend,
+		options: options,
+		counters: map[string]*CacheCounters{
+			cachedSummonerByRiotID:     {},
+			cachedSummonerByPUUID:      {},
+			cachedAccountByPUUID:       {},
+			cachedAccountByRiotID:      {},
+			cachedMatchDetails:         {},
+			cachedMatchTimeline:        {},
+			cachedRankedStats:          {},
+			cachedRankedStatsByPUUID:   {},
+			cachedChampionMasteries:    {},
+			cachedTopChampionMasteries: {},
+			cachedChampionMastery:      {},
+			cachedMasteryScore:         {},
+			cachedActiveGame:           {},
+			cachedApexLeague:           {},
+			cachedLeagueEntries:        {},
+			cachedPlayerChallenges:     {},
+			cachedChallengeConfigs:     {},
+			cachedChallengeLeaderboard: {},
+		},
+	}
+}
+
+// Stats returns a snapshot of the hit and miss counters for each cached method
+func (cachedService *CachedRiotService) Stats() map[string]CacheCounters {
+	stats := make(map[string]CacheCounters, len(cachedService.counters))
+	for method, counters := range cachedService.counters {
+		stats[method] = CacheCounters{
+			Hits:   atomic.LoadInt64(&counters.Hits),
+			Misses: atomic.LoadInt64(&counters.Misses),
+		}
+	}
+	return stats
+}
+
+// lookup decodes the value cached under key into target and records a hit or miss for method
+// Backend and decoding failures are logged and treated as misses so the Riot API is still consulted
+func (cachedService *CachedRiotService) lookup(ctx context.Context, method string, key string, target interface{}) bool {
+	found := cachedService.read(ctx, key, target)
+
+	counters := cachedService.counters[method]
+	if found {
+		atomic.AddInt64(&counters.Hits, 1)
+	} else {
+		atomic.AddInt64(&counters.Misses, 1)
+	}
+
+	return found
+}
+
+// read fetches and decodes a cached value, returning false on a miss or any failure
+func (cachedService *CachedRiotService) read(ctx context.Context, key string, target interface{}) bool {
+	data, found, err := cachedService.backend.Get(ctx, key)
+	if err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Cache read failed")
+		return false
+	}
+
+	if !found {
+		return false
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Discarding undecodable cache entry")
+		return false
+	}
+
+	return true
+}
+
+// store encodes value and writes it under key, logging failures
+func (cachedService *CachedRiotService) store(ctx context.Context, key string, value interface{}, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to encode cache entry")
+		return
+	}
+
+	if err := cachedService.backend.Set(ctx, key, data, ttl); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Cache write failed")
+	}
+}
+
+// cacheKey builds a versioned cache key from its parts (e.g., "opgl:v1:puuid:na:abc")
+func cacheKey(parts ...string) string {
+	return fmt.Sprintf("opgl:v%d:%s", cacheSchemaVersion, strings.Join(parts, ":"))
+}
+
+// GetSummonerByRiotID returns the cached summoner for a Riot ID or looks it up
+func (cachedService *CachedRiotService) GetSummonerByRiotID(ctx context.Context, region string, gameName string, tagLine string) (*models.Summoner, error) {
+	// Riot IDs are case-insensitive, unlike PUUIDs and summoner IDs
+	key := cacheKey("riot-id", region, strings.ToLower(gameName), strings.ToLower(tagLine))
+	var cachedSummoner models.Summoner
+	if cachedService.lookup(ctx, cachedSummonerByRiotID, key, &cachedSummoner) {
+		return &cachedSummoner, nil
+	}
+
+	summoner, err := cachedService.inner.GetSummonerByRiotID(ctx, region, gameName, tagLine)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, summoner, cachedService.options.SummonerTTL)
+	cachedService.store(ctx, cacheKey("puuid", region, summoner.PUUID), summoner, cachedService.options.SummonerTTL)
+
+	return summoner, nil
+}
+
+// GetSummonerByPUUID returns the cached summoner for a PUUID or looks it up
+func (cachedService *CachedRiotService) GetSummonerByPUUID(ctx context.Context, region string, puuid string) (*models.Summoner, error) {
+	key := cacheKey("puuid", region, puuid)
+	var cachedSummoner models.Summoner
+	if cachedService.lookup(ctx, cachedSummonerByPUUID, key, &cachedSummoner) {
+		return &cachedSummoner, nil
+	}
+
+	summoner, err := cachedService.inner.GetSummonerByPUUID(ctx, region, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, summoner, cachedService.options.SummonerTTL)
+
+	return summoner, nil
+}
+
+// GetAccountByPUUID returns the cached account for a PUUID or looks it up
+// Accounts are global, so the key ignores region
+func (cachedService *CachedRiotService) GetAccountByPUUID(ctx context.Context, region string, puuid string) (*models.Account, error) {
+	key := cacheKey("account", puuid)
+	var cachedAccount models.Account
+	if cachedService.lookup(ctx, cachedAccountByPUUID, key, &cachedAccount) {
+		return &cachedAccount, nil
+	}
+
+	account, err := cachedService.inner.GetAccountByPUUID(ctx, region, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, account, cachedService.options.SummonerTTL)
+
+	return account, nil
+}
+
+// GetAccountByRiotID returns the cached account for a Riot ID or looks it up
+// Accounts are global and Riot IDs case-insensitive, so the key ignores region and case
+func (cachedService *CachedRiotService) GetAccountByRiotID(ctx context.Context, region string, gameName string, tagLine string) (*models.Account, error) {
+	key := cacheKey("account-riot-id", strings.ToLower(gameName), strings.ToLower(tagLine))
+	var cachedAccount models.Account
+	if cachedService.lookup(ctx, cachedAccountByRiotID, key, &cachedAccount) {
+		return &cachedAccount, nil
+	}
+
+	account, err := cachedService.inner.GetAccountByRiotID(ctx, region, gameName, tagLine)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, account, cachedService.options.SummonerTTL)
+	cachedService.store(ctx, cacheKey("account", account.PUUID), account, cachedService.options.SummonerTTL)
+
+	return account, nil
+}
+
+// GetMatchIDs always fetches fresh match IDs from the underlying service
+func (cachedService *CachedRiotService) GetMatchIDs(ctx context.Context, region string, puuid string, options MatchListOptions) ([]string, error) {
+	return cachedService.inner.GetMatchIDs(ctx, region, puuid, options)
+}
+
+// GetMatchHistory fetches fresh match IDs and serves match details from the cache where possible
+func (cachedService *CachedRiotService) GetMatchHistory(ctx context.Context, region string, puuid string, options MatchListOptions) (*models.MatchHistory, error) {
+	matchIDs, err := cachedService.GetMatchIDs(ctx, region, puuid, options)
+	if err != nil {
+		return nil, err
+	}
+
+	matchHistory, err := fetchMatchDetails(ctx, matchIDs, cachedService.options.MatchFetchParallelism, func(ctx context.Context, matchID string) (*models.Match, error) {
+		return cachedService.GetMatchDetails(ctx, region, matchID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matchHistory.NextCursor = nextMatchCursor(options, len(matchIDs))
+	return matchHistory, nil
+}
+
+// GetMatchDetails returns the cached match or looks it up
+func (cachedService *CachedRiotService) GetMatchDetails(ctx context.Context, region string, matchID string) (*models.Match, error) {
+	// Match IDs carry their platform prefix (e.g., NA1_) so they are unique across regions
+	key := cacheKey("match", matchID)
+	var cachedMatch models.Match
+	if cachedService.lookup(ctx, cachedMatchDetails, key, &cachedMatch) {
+		return &cachedMatch, nil
+	}
+
+	match, err := cachedService.inner.GetMatchDetails(ctx, region, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, match, cachedService.options.MatchTTL)
+
+	return match, nil
+}
+
+// GetMatchTimeline returns the cached match timeline or looks it up
+func (cachedService *CachedRiotService) GetMatchTimeline(ctx context.Context, region string, matchID string) (*models.MatchTimeline, error) {
+	key := cacheKey("timeline", matchID)
+	var cachedTimeline models.MatchTimeline
+	if cachedService.lookup(ctx, cachedMatchTimeline, key, &cachedTimeline) {
+		return &cachedTimeline, nil
+	}
+
+	timeline, err := cachedService.inner.GetMatchTimeline(ctx, region, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, timeline, cachedService.options.MatchTTL)
+
+	return timeline, nil
+}
+
+// GetRankedStats returns cached ranked stats or looks them up
+func (cachedService *CachedRiotService) GetRankedStats(ctx context.Context, region string, encryptedSummonerID string) ([]models.RankedStats, error) {
+	key := cacheKey("ranked", region, encryptedSummonerID)
+	var cachedStats []models.RankedStats
+	if cachedService.lookup(ctx, cachedRankedStats, key, &cachedStats) {
+		return cachedStats, nil
+	}
+
+	rankedStats, err := cachedService.inner.GetRankedStats(ctx, region, encryptedSummonerID)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, rankedStats, cachedService.options.RankedTTL)
+
+	return rankedStats, nil
+}
+
+// GetRankedStatsByPUUID returns cached ranked stats or looks them up by PUUID
+func (cachedService *CachedRiotService) GetRankedStatsByPUUID(ctx context.Context, region string, puuid string) ([]models.RankedStats, error) {
+	key := cacheKey("ranked-puuid", region, puuid)
+	var cachedStats []models.RankedStats
+	if cachedService.lookup(ctx, cachedRankedStatsByPUUID, key, &cachedStats) {
+		return cachedStats, nil
+	}
+
+	rankedStats, err := cachedService.inner.GetRankedStatsByPUUID(ctx, region, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, rankedStats, cachedService.options.RankedTTL)
+
+	return rankedStats, nil
+}
+
+// GetChampionMasteries returns cached champion masteries or looks them up
+func (cachedService *CachedRiotService) GetChampionMasteries(ctx context.Context, region string, puuid string) ([]models.ChampionMastery, error) {
+	key := cacheKey("mastery", region, puuid)
+	var cachedMasteries []models.ChampionMastery
+	if cachedService.lookup(ctx, cachedChampionMasteries, key, &cachedMasteries) {
+		return cachedMasteries, nil
+	}
+
+	masteries, err := cachedService.inner.GetChampionMasteries(ctx, region, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, masteries, cachedService.options.MasteryTTL)
+
+	return masteries, nil
+}
+
+// GetTopChampionMasteries returns cached top champion masteries or looks them up
+func (cachedService *CachedRiotService) GetTopChampionMasteries(ctx context.Context, region string, puuid string, count int) ([]models.ChampionMastery, error) {
+	key := cacheKey("mastery-top", region, puuid, strconv.Itoa(count))
+	var cachedMasteries []models.ChampionMastery
+	if cachedService.lookup(ctx, cachedTopChampionMasteries, key, &cachedMasteries) {
+		return cachedMasteries, nil
+	}
+
+	masteries, err := cachedService.inner.GetTopChampionMasteries(ctx, region, puuid, count)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, masteries, cachedService.options.MasteryTTL)
+
+	return masteries, nil
+}
+
+// GetChampionMastery returns a cached single-champion mastery or looks it up
+func (cachedService *CachedRiotService) GetChampionMastery(ctx context.Context, region string, puuid string, championID int) (*models.ChampionMastery, error) {
+	key := cacheKey("mastery-champion", region, puuid, strconv.Itoa(championID))
+	var cachedMastery models.ChampionMastery
+	if cachedService.lookup(ctx, cachedChampionMastery, key, &cachedMastery) {
+		return &cachedMastery, nil
+	}
+
+	mastery, err := cachedService.inner.GetChampionMastery(ctx, region, puuid, championID)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, mastery, cachedService.options.MasteryTTL)
+
+	return mastery, nil
+}
+
+// GetMasteryScore returns a cached mastery score or looks it up
+func (cachedService *CachedRiotService) GetMasteryScore(ctx context.Context, region string, puuid string) (int, error) {
+	key := cacheKey("mastery-score", region, puuid)
+	var cachedScore int
+	if cachedService.lookup(ctx, cachedMasteryScore, key, &cachedScore) {
+		return cachedScore, nil
+	}
+
+	score, err := cachedService.inner.GetMasteryScore(ctx, region, puuid)
+	if err != nil {
+		return 0, err
+	}
+
+	cachedService.store(ctx, key, score, cachedService.options.MasteryTTL)
+
+	return score, nil
+}
+
+// GetActiveGame returns a cached active game or looks it up
+// The TTL is kept short because players enter and leave games at any time
+func (cachedService *CachedRiotService) GetActiveGame(ctx context.Context, region string, puuid string) (*models.ActiveGame, error) {
+	key := cacheKey("live", region, puuid)
+	var cachedGame models.ActiveGame
+	if cachedService.lookup(ctx, cachedActiveGame, key, &cachedGame) {
+		return &cachedGame, nil
+	}
+
+	game, err := cachedService.inner.GetActiveGame(ctx, region, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, game, cachedService.options.LiveGameTTL)
+
+	return game, nil
+}
+
+// GetApexLeague returns a cached apex league or looks it up
+func (cachedService *CachedRiotService) GetApexLeague(ctx context.Context, region string, queue string, tier string) (*models.League, error) {
+	key := cacheKey("league", region, queue, tier)
+	var cachedLeague models.League
+	if cachedService.lookup(ctx, cachedApexLeague, key, &cachedLeague) {
+		return &cachedLeague, nil
+	}
+
+	league, err := cachedService.inner.GetApexLeague(ctx, region, queue, tier)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, league, cachedService.options.LeagueTTL)
+
+	return league, nil
+}
+
+// GetLeagueEntries returns a cached league entry page or looks it up
+func (cachedService *CachedRiotService) GetLeagueEntries(ctx context.Context, region string, queue string, tier string, division string, page int) ([]models.LeagueEntry, error) {
+	key := cacheKey("league-exp", region, queue, tier, division, strconv.Itoa(page))
+	var cachedEntries []models.LeagueEntry
+	if cachedService.lookup(ctx, cachedLeagueEntries, key, &cachedEntries) {
+		return cachedEntries, nil
+	}
+
+	entries, err := cachedService.inner.GetLeagueEntries(ctx, region, queue, tier, division, page)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, entries, cachedService.options.LeagueTTL)
+
+	return entries, nil
+}
+
+// GetPlayerChallenges returns cached player challenge data or looks it up
+func (cachedService *CachedRiotService) GetPlayerChallenges(ctx context.Context, region string, puuid string) (*models.PlayerChallenges, error) {
+	key := cacheKey("challenges", region, puuid)
+	var cachedPlayer models.PlayerChallenges
+	if cachedService.lookup(ctx, cachedPlayerChallenges, key, &cachedPlayer) {
+		return &cachedPlayer, nil
+	}
+
+	player, err := cachedService.inner.GetPlayerChallenges(ctx, region, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, player, cachedService.options.ChallengeTTL)
+
+	return player, nil
+}
+
+// GetChallengeConfigs returns cached challenge configs or looks them up
+func (cachedService *CachedRiotService) GetChallengeConfigs(ctx context.Context, region string) ([]models.ChallengeConfig, error) {
+	key := cacheKey("challenge-config", region)
+	var cachedConfigs []models.ChallengeConfig
+	if cachedService.lookup(ctx, cachedChallengeConfigs, key, &cachedConfigs) {
+		return cachedConfigs, nil
+	}
+
+	configs, err := cachedService.inner.GetChallengeConfigs(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, configs, cachedService.options.ChallengeConfigTTL)
+
+	return configs, nil
+}
+
+// GetChallengeLeaderboard returns a cached challenge leaderboard or looks it up
+func (cachedService *CachedRiotService) GetChallengeLeaderboard(ctx context.Context, region string, challengeID int64, level string, limit int) ([]models.ChallengeLeaderboardEntry, error) {
+	key := cacheKey("challenge-leaderboard", region, strconv.FormatInt(challengeID, 10), level, strconv.Itoa(limit))
+	var cachedEntries []models.ChallengeLeaderboardEntry
+	if cachedService.lookup(ctx, cachedChallengeLeaderboard, key, &cachedEntries) {
+		return cachedEntries, nil
+	}
+
+	entries, err := cachedService.inner.GetChallengeLeaderboard(ctx, region, challengeID, level, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedService.store(ctx, key, entries, cachedService.options.ChallengeTTL)
+
+	return entries, nil
+}
+
+// Verify CachedRiotService implements RiotServiceInterface
+var _ RiotServiceInterface = (*CachedRiotService)(nil)
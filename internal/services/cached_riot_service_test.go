@@ -0,0 +1,576 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/cache"
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// countingRiotService is a RiotServiceInterface stub that counts calls to each method
+type countingRiotService struct {
+	summonerByRiotIDCalls int32
+	summonerByPUUIDCalls  int32
+	accountByPUUIDCalls   int32
+	accountByRiotIDCalls  int32
+	matchIDsCalls         int32
+	matchDetailsCalls     int32
+	matchTimelineCalls    int32
+	rankedStatsCalls      int32
+	masteryCalls          int32
+	activeGameCalls       int32
+	leagueCalls           int32
+	challengeCalls        int32
+	// Error returned by every method when set
+	err error
+}
+
+func (service *countingRiotService) GetSummonerByRiotID(ctx context.Context, region string, gameName string, tagLine string) (*models.Summoner, error) {
+	atomic.AddInt32(&service.summonerByRiotIDCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return &models.Summoner{ID: "summoner-id", PUUID: "puuid-" + gameName, Name: gameName}, nil
+}
+
+func (service *countingRiotService) GetSummonerByPUUID(ctx context.Context, region string, puuid string) (*models.Summoner, error) {
+	atomic.AddInt32(&service.summonerByPUUIDCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return &models.Summoner{ID: "summoner-id", PUUID: puuid}, nil
+}
+
+func (service *countingRiotService) GetAccountByPUUID(ctx context.Context, region string, puuid string) (*models.Account, error) {
+	atomic.AddInt32(&service.accountByPUUIDCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return &models.Account{PUUID: puuid, GameName: "Player", TagLine: "NA1"}, nil
+}
+
+func (service *countingRiotService) GetAccountByRiotID(ctx context.Context, region string, gameName string, tagLine string) (*models.Account, error) {
+	atomic.AddInt32(&service.accountByRiotIDCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return &models.Account{PUUID: "puuid-" + gameName, GameName: gameName, TagLine: tagLine}, nil
+}
+
+func (service *countingRiotService) GetMatchIDs(ctx context.Context, region string, puuid string, options MatchListOptions) ([]string, error) {
+	atomic.AddInt32(&service.matchIDsCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return []string{"NA1_1", "NA1_2", "NA1_3"}[:options.Count], nil
+}
+
+func (service *countingRiotService) GetMatchHistory(ctx context.Context, region string, puuid string, options MatchListOptions) (*models.MatchHistory, error) {
+	return nil, errors.New("GetMatchHistory should not be delegated")
+}
+
+func (service *countingRiotService) GetMatchDetails(ctx context.Context, region string, matchID string) (*models.Match, error) {
+	atomic.AddInt32(&service.matchDetailsCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return &models.Match{
+		MatchID:      matchID,
+		Participants: []models.Participant{{PUUID: "player-puuid", SummonerName: "Player"}},
+	}, nil
+}
+
+func (service *countingRiotService) GetMatchTimeline(ctx context.Context, region string, matchID string) (*models.MatchTimeline, error) {
+	atomic.AddInt32(&service.matchTimelineCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return &models.MatchTimeline{MatchID: matchID, FrameInterval: 60000}, nil
+}
+
+func (service *countingRiotService) GetRankedStats(ctx context.Context, region string, encryptedSummonerID string) ([]models.RankedStats, error) {
+	atomic.AddInt32(&service.rankedStatsCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return []models.RankedStats{{QueueType: "RANKED_SOLO_5x5", Tier: "GOLD"}}, nil
+}
+
+func (service *countingRiotService) GetChampionMasteries(ctx context.Context, region string, puuid string) ([]models.ChampionMastery, error) {
+	atomic.AddInt32(&service.masteryCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return []models.ChampionMastery{{PUUID: puuid, ChampionID: 103, ChampionPoints: 50000}}, nil
+}
+
+func (service *countingRiotService) GetTopChampionMasteries(ctx context.Context, region string, puuid string, count int) ([]models.ChampionMastery, error) {
+	atomic.AddInt32(&service.masteryCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return []models.ChampionMastery{{PUUID: puuid, ChampionID: 103, ChampionPoints: 50000}}, nil
+}
+
+func (service *countingRiotService) GetChampionMastery(ctx context.Context, region string, puuid string, championID int) (*models.ChampionMastery, error) {
+	atomic.AddInt32(&service.masteryCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return &models.ChampionMastery{PUUID: puuid, ChampionID: championID}, nil
+}
+
+func (service *countingRiotService) GetMasteryScore(ctx context.Context, region string, puuid string) (int, error) {
+	atomic.AddInt32(&service.masteryCalls, 1)
+	if service.err != nil {
+		return 0, service.err
+	}
+	return 42, nil
+}
+
+func (service *countingRiotService) GetActiveGame(ctx context.Context, region string, puuid string) (*models.ActiveGame, error) {
+	atomic.AddInt32(&service.activeGameCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return &models.ActiveGame{InGame: true, GameID: 42}, nil
+}
+
+func (service *countingRiotService) GetApexLeague(ctx context.Context, region string, queue string, tier string) (*models.League, error) {
+	atomic.AddInt32(&service.leagueCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return &models.League{Queue: queue, Tier: tier, Entries: []models.LeagueEntry{{PUUID: "puuid-a"}}}, nil
+}
+
+func (service *countingRiotService) GetLeagueEntries(ctx context.Context, region string, queue string, tier string, division string, page int) ([]models.LeagueEntry, error) {
+	atomic.AddInt32(&service.leagueCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return []models.LeagueEntry{{PUUID: "puuid-a", Tier: tier, Rank: division}}, nil
+}
+
+func (service *countingRiotService) GetRankedStatsByPUUID(ctx context.Context, region string, puuid string) ([]models.RankedStats, error) {
+	atomic.AddInt32(&service.rankedStatsCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return []models.RankedStats{{QueueType: "RANKED_SOLO_5x5", Tier: "GOLD", Wins: 3, Losses: 1, WinRate: 75}}, nil
+}
+
+func (service *countingRiotService) GetPlayerChallenges(ctx context.Context, region string, puuid string) (*models.PlayerChallenges, error) {
+	atomic.AddInt32(&service.challengeCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return &models.PlayerChallenges{PUUID: puuid, Challenges: []models.ChallengeProgress{{ChallengeID: 101000, Level: "GOLD"}}}, nil
+}
+
+func (service *countingRiotService) GetChallengeConfigs(ctx context.Context, region string) ([]models.ChallengeConfig, error) {
+	atomic.AddInt32(&service.challengeCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return []models.ChallengeConfig{{ID: 101000, Thresholds: map[string]float64{"GOLD": 10}}}, nil
+}
+
+func (service *countingRiotService) GetChallengeLeaderboard(ctx context.Context, region string, challengeID int64, level string, limit int) ([]models.ChallengeLeaderboardEntry, error) {
+	atomic.AddInt32(&service.challengeCalls, 1)
+	if service.err != nil {
+		return nil, service.err
+	}
+	return []models.ChallengeLeaderboardEntry{{Position: 1, PUUID: "puuid-a", Value: 99}}, nil
+}
+
+// TestCachedRiotService_SummonerByRiotID tests that Riot ID lookups are cached case-insensitively
+func TestCachedRiotService_SummonerByRiotID(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+
+	for _, gameName := range []string{"Faker", "faker", "FAKER"} {
+		summoner, err := cachedService.GetSummonerByRiotID(context.Background(), "kr", gameName, "KR1")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if summoner.PUUID != "puuid-Faker" {
+			t.Errorf("Expected PUUID 'puuid-Faker', got '%s'", summoner.PUUID)
+		}
+	}
+
+	if inner.summonerByRiotIDCalls != 1 {
+		t.Errorf("Expected 1 upstream call, got %d", inner.summonerByRiotIDCalls)
+	}
+
+	stats := cachedService.Stats()[cachedSummonerByRiotID]
+	if stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Expected 2 hits and 1 miss, got %+v", stats)
+	}
+
+	// The Riot ID lookup also primes the PUUID cache
+	if _, err := cachedService.GetSummonerByPUUID(context.Background(), "kr", "puuid-Faker"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if inner.summonerByPUUIDCalls != 0 {
+		t.Errorf("Expected PUUID lookup to be served from cache, got %d upstream calls", inner.summonerByPUUIDCalls)
+	}
+}
+
+// TestCachedRiotService_RankedStats tests that ranked stats are cached per summoner
+func TestCachedRiotService_RankedStats(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+
+	cachedService.GetRankedStats(context.Background(), "na", "summoner-a")
+	cachedService.GetRankedStats(context.Background(), "na", "summoner-a")
+	cachedService.GetRankedStats(context.Background(), "na", "summoner-b")
+
+	if inner.rankedStatsCalls != 2 {
+		t.Errorf("Expected 2 upstream calls, got %d", inner.rankedStatsCalls)
+	}
+}
+
+// TestCachedRiotService_RankedStatsByPUUID tests that ranked stats by PUUID are cached per player
+func TestCachedRiotService_RankedStatsByPUUID(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+
+	cachedService.GetRankedStatsByPUUID(context.Background(), "na", "puuid-a")
+	rankedStats, _ := cachedService.GetRankedStatsByPUUID(context.Background(), "na", "puuid-a")
+	cachedService.GetRankedStatsByPUUID(context.Background(), "na", "puuid-b")
+
+	if inner.rankedStatsCalls != 2 {
+		t.Errorf("Expected 2 upstream calls, got %d", inner.rankedStatsCalls)
+	}
+
+	if len(rankedStats) != 1 || rankedStats[0].WinRate != 75 {
+		t.Errorf("Expected cached stats to keep the win rate, got %+v", rankedStats)
+	}
+}
+
+// TestCachedRiotService_MatchHistoryUsesMatchCache tests that match history reuses cached match details
+func TestCachedRiotService_MatchHistoryUsesMatchCache(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+
+	for i := 0; i < 3; i++ {
+		matchHistory, err := cachedService.GetMatchHistory(context.Background(), "na", "test-puuid", MatchListOptions{Count: 3})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(matchHistory.Matches) != 3 {
+			t.Fatalf("Expected 3 matches, got %d", len(matchHistory.Matches))
+		}
+	}
+
+	if inner.matchIDsCalls != 3 {
+		t.Errorf("Expected match IDs to be fetched on every request, got %d calls", inner.matchIDsCalls)
+	}
+
+	if inner.matchDetailsCalls != 3 {
+		t.Errorf("Expected each match to be fetched once, got %d calls", inner.matchDetailsCalls)
+	}
+}
+
+// TestCachedRiotService_MatchIsCopied tests that callers cannot modify cached matches
+func TestCachedRiotService_MatchIsCopied(t *testing.T) {
+	cachedService := NewCachedRiotService(&countingRiotService{}, cache.NewMemoryCache(100), DefaultCacheOptions())
+
+	match, _ := cachedService.GetMatchDetails(context.Background(), "na", "NA1_1")
+	match.Participants[0].SummonerName = "Modified"
+
+	cachedMatch, _ := cachedService.GetMatchDetails(context.Background(), "na", "NA1_1")
+	if cachedMatch.Participants[0].SummonerName != "Player" {
+		t.Errorf("Expected cached participant to be unchanged, got '%s'", cachedMatch.Participants[0].SummonerName)
+	}
+}
+
+// TestCachedRiotService_MatchTimeline tests that timelines are cached like match details
+func TestCachedRiotService_MatchTimeline(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+
+	for i := 0; i < 3; i++ {
+		timeline, err := cachedService.GetMatchTimeline(context.Background(), "na", "NA1_1")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if timeline.MatchID != "NA1_1" {
+			t.Errorf("Expected timeline for NA1_1, got '%s'", timeline.MatchID)
+		}
+	}
+
+	if inner.matchTimelineCalls != 1 {
+		t.Errorf("Expected 1 upstream call, got %d", inner.matchTimelineCalls)
+	}
+
+	if stats := cachedService.Stats()[cachedMatchTimeline]; stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Expected 2 hits and 1 miss, got %+v", stats)
+	}
+}
+
+// TestCachedRiotService_Mastery tests that each mastery lookup is cached under its own arguments
+func TestCachedRiotService_Mastery(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		cachedService.GetChampionMasteries(ctx, "na", "puuid-a")
+		cachedService.GetTopChampionMasteries(ctx, "na", "puuid-a", 3)
+		cachedService.GetChampionMastery(ctx, "na", "puuid-a", 103)
+		if score, _ := cachedService.GetMasteryScore(ctx, "na", "puuid-a"); score != 42 {
+			t.Errorf("Expected score 42, got %d", score)
+		}
+	}
+
+	if inner.masteryCalls != 4 {
+		t.Errorf("Expected 4 upstream calls, got %d", inner.masteryCalls)
+	}
+
+	cachedService.GetTopChampionMasteries(ctx, "na", "puuid-a", 5)
+	mastery, _ := cachedService.GetChampionMastery(ctx, "na", "puuid-a", 222)
+	if inner.masteryCalls != 6 {
+		t.Errorf("Expected a different count or champion to miss the cache, got %d upstream calls", inner.masteryCalls)
+	}
+	if mastery.ChampionID != 222 {
+		t.Errorf("Expected mastery for champion 222, got %d", mastery.ChampionID)
+	}
+}
+
+// TestCachedRiotService_ActiveGame tests that active games are cached with the live game TTL
+func TestCachedRiotService_ActiveGame(t *testing.T) {
+	inner := &countingRiotService{}
+	options := DefaultCacheOptions()
+	options.LiveGameTTL = 20 * time.Millisecond
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), options)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		game, err := cachedService.GetActiveGame(ctx, "na", "puuid-a")
+		if err != nil || !game.InGame || game.GameID != 42 {
+			t.Fatalf("Expected in-game result 42, got %+v (err %v)", game, err)
+		}
+	}
+
+	if inner.activeGameCalls != 1 {
+		t.Errorf("Expected 1 upstream call, got %d", inner.activeGameCalls)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	cachedService.GetActiveGame(ctx, "na", "puuid-a")
+
+	if inner.activeGameCalls != 2 {
+		t.Errorf("Expected the expired entry to be refetched, got %d upstream calls", inner.activeGameCalls)
+	}
+}
+
+// TestCachedRiotService_Leagues tests that apex leagues and league entry pages are cached per page
+func TestCachedRiotService_Leagues(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		league, _ := cachedService.GetApexLeague(ctx, "na", RankedSoloQueue, TierChallenger)
+		if league.Tier != TierChallenger || len(league.Entries) != 1 {
+			t.Errorf("Unexpected league: %+v", league)
+		}
+		cachedService.GetLeagueEntries(ctx, "na", RankedSoloQueue, "GOLD", "II", 1)
+	}
+
+	if inner.leagueCalls != 2 {
+		t.Errorf("Expected 2 upstream calls, got %d", inner.leagueCalls)
+	}
+
+	cachedService.GetApexLeague(ctx, "na", RankedSoloQueue, TierMaster)
+	cachedService.GetLeagueEntries(ctx, "na", RankedSoloQueue, "GOLD", "II", 2)
+
+	if inner.leagueCalls != 4 {
+		t.Errorf("Expected another tier and page to miss the cache, got %d upstream calls", inner.leagueCalls)
+	}
+}
+
+// TestCachedRiotService_Challenges tests that challenge data, configs and leaderboards are cached
+func TestCachedRiotService_Challenges(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		player, _ := cachedService.GetPlayerChallenges(ctx, "na", "puuid-a")
+		if len(player.Challenges) != 1 || player.Challenges[0].Level != "GOLD" {
+			t.Errorf("Unexpected player challenges: %+v", player)
+		}
+		configs, _ := cachedService.GetChallengeConfigs(ctx, "na")
+		if len(configs) != 1 || configs[0].Thresholds["GOLD"] != 10 {
+			t.Errorf("Unexpected challenge configs: %+v", configs)
+		}
+		cachedService.GetChallengeLeaderboard(ctx, "na", 101000, "CHALLENGER", 10)
+	}
+
+	if inner.challengeCalls != 3 {
+		t.Errorf("Expected 3 upstream calls, got %d", inner.challengeCalls)
+	}
+
+	cachedService.GetChallengeLeaderboard(ctx, "na", 101000, "MASTER", 10)
+
+	if inner.challengeCalls != 4 {
+		t.Errorf("Expected another level to miss the cache, got %d upstream calls", inner.challengeCalls)
+	}
+}
+
+// TestCachedRiotService_ErrorsNotCached tests that failed lookups are retried on the next call
+func TestCachedRiotService_ErrorsNotCached(t *testing.T) {
+	inner := &countingRiotService{err: errors.New("upstream failure")}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+
+	for i := 0; i < 2; i++ {
+		if _, err := cachedService.GetMatchDetails(context.Background(), "na", "NA1_1"); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	}
+
+	if inner.matchDetailsCalls != 2 {
+		t.Errorf("Expected 2 upstream calls, got %d", inner.matchDetailsCalls)
+	}
+
+	stats := cachedService.Stats()[cachedMatchDetails]
+	if stats.Misses != 2 {
+		t.Errorf("Expected 2 misses, got %d", stats.Misses)
+	}
+}
+
+// failingCache is a cache.Cache whose every operation fails
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("cache unavailable")
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("cache unavailable")
+}
+
+// TestCachedRiotService_BackendFailure tests that lookups fall through to the Riot API when the backend fails
+func TestCachedRiotService_BackendFailure(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, failingCache{}, DefaultCacheOptions())
+
+	for i := 0; i < 2; i++ {
+		summoner, err := cachedService.GetSummonerByPUUID(context.Background(), "na", "test-puuid")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if summoner.PUUID != "test-puuid" {
+			t.Errorf("Expected PUUID 'test-puuid', got '%s'", summoner.PUUID)
+		}
+	}
+
+	if inner.summonerByPUUIDCalls != 2 {
+		t.Errorf("Expected 2 upstream calls, got %d", inner.summonerByPUUIDCalls)
+	}
+}
+
+// TestCachedRiotService_SharedBackend tests that replicas sharing a backend reuse each other's entries
+func TestCachedRiotService_SharedBackend(t *testing.T) {
+	backend := cache.NewMemoryCache(100)
+	firstInner := &countingRiotService{}
+	secondInner := &countingRiotService{}
+	firstReplica := NewCachedRiotService(firstInner, backend, DefaultCacheOptions())
+	secondReplica := NewCachedRiotService(secondInner, backend, DefaultCacheOptions())
+
+	firstReplica.GetMatchDetails(context.Background(), "na", "NA1_1")
+
+	match, err := secondReplica.GetMatchDetails(context.Background(), "na", "NA1_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if match.MatchID != "NA1_1" || len(match.Participants) != 1 {
+		t.Errorf("Expected decoded match NA1_1 with 1 participant, got %+v", match)
+	}
+
+	if secondInner.matchDetailsCalls != 0 {
+		t.Errorf("Expected second replica to be served from the shared cache, got %d upstream calls", secondInner.matchDetailsCalls)
+	}
+}
+
+// TestCachedRiotService_VersionedKeys tests that entries are stored under the current schema version
+func TestCachedRiotService_VersionedKeys(t *testing.T) {
+	backend := cache.NewMemoryCache(100)
+	cachedService := NewCachedRiotService(&countingRiotService{}, backend, DefaultCacheOptions())
+
+	cachedService.GetRankedStats(context.Background(), "na", "summoner-a")
+
+	data, found, _ := backend.Get(context.Background(), fmt.Sprintf("opgl:v%d:ranked:na:summoner-a", cacheSchemaVersion))
+	if !found {
+		t.Fatal("Expected ranked stats under the versioned key")
+	}
+
+	var rankedStats []models.RankedStats
+	if err := json.Unmarshal(data, &rankedStats); err != nil || len(rankedStats) != 1 {
+		t.Errorf("Expected serialized ranked stats, got %q (err=%v)", data, err)
+	}
+
+	// Entries written under another schema version are ignored
+	backend.Set(context.Background(), "opgl:v0:ranked:na:summoner-b", data, 0)
+	if _, found, _ := backend.Get(context.Background(), cacheKey("ranked", "na", "summoner-b")); found {
+		t.Error("Expected entries from an older schema version not to be visible")
+	}
+}
+
+// TestCachedRiotService_AccountByPUUID tests that account lookups are cached independently of region
+func TestCachedRiotService_AccountByPUUID(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+
+	for _, region := range []string{"na", "euw"} {
+		account, err := cachedService.GetAccountByPUUID(context.Background(), region, "test-puuid")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if account.GameName != "Player" || account.TagLine != "NA1" {
+			t.Errorf("Expected Player#NA1, got %s#%s", account.GameName, account.TagLine)
+		}
+	}
+
+	if inner.accountByPUUIDCalls != 1 {
+		t.Errorf("Expected 1 upstream call, got %d", inner.accountByPUUIDCalls)
+	}
+}
+
+// TestCachedRiotService_AccountByRiotID tests that Riot ID account lookups are cached across regions and case
+func TestCachedRiotService_AccountByRiotID(t *testing.T) {
+	inner := &countingRiotService{}
+	cachedService := NewCachedRiotService(inner, cache.NewMemoryCache(100), DefaultCacheOptions())
+
+	for _, lookup := range []struct{ region, gameName string }{{"kr", "Faker"}, {"kr", "faker"}, {"na", "FAKER"}} {
+		account, err := cachedService.GetAccountByRiotID(context.Background(), lookup.region, lookup.gameName, "KR1")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if account.PUUID != "puuid-Faker" {
+			t.Errorf("Expected PUUID 'puuid-Faker', got '%s'", account.PUUID)
+		}
+	}
+
+	if inner.accountByRiotIDCalls != 1 || inner.summonerByRiotIDCalls != 0 {
+		t.Errorf("Expected 1 account lookup and no summoner lookups, got %d and %d", inner.accountByRiotIDCalls, inner.summonerByRiotIDCalls)
+	}
+
+	// The Riot ID lookup also primes the account-by-PUUID cache
+	if _, err := cachedService.GetAccountByPUUID(context.Background(), "kr", "puuid-Faker"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if inner.accountByPUUIDCalls != 0 {
+		t.Errorf("Expected PUUID lookup to be served from cache, got %d upstream calls", inner.accountByPUUIDCalls)
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// DefaultChallengeLocale is the locale used for challenge names when none is requested or the requested one is missing
+const DefaultChallengeLocale = "en_US"
+
+// MaxChallengeLeaderboardLimit is the largest number of players accepted for one challenge leaderboard request
+const MaxChallengeLeaderboardLimit = 300
+
+// ChallengeLeaderboardOptions selects a challenge leaderboard
+type ChallengeLeaderboardOptions struct {
+	// Apex level (MASTER, GRANDMASTER or CHALLENGER)
+	Level string
+	// Players to return (1 to MaxChallengeLeaderboardLimit)
+	Limit int
+}
+
+// Validate checks the options against challenges-v1's leaderboard levels and limits
+func (options ChallengeLeaderboardOptions) Validate() error {
+	switch {
+	case !IsApexTier(options.Level):
+		return &InvalidLeaderboardOptionError{Field: "level", Message: "must be MASTER, GRANDMASTER or CHALLENGER"}
+	case options.Limit < 1 || options.Limit > MaxChallengeLeaderboardLimit:
+		return &InvalidLeaderboardOptionError{Field: "limit", Message: fmt.Sprintf("must be between 1 and %d", MaxChallengeLeaderboardLimit)}
+	}
+	return nil
+}
+
+// rawChallengePoints is a challenges-v1 points summary
+type rawChallengePoints struct {
+	Level      string  `json:"level"`
+	Current    int     `json:"current"`
+	Max        int     `json:"max"`
+	Percentile float64 `json:"percentile"`
+}
+
+// rawPlayerChallenges is a challenges-v1 player data response
+type rawPlayerChallenges struct {
+	TotalPoints    rawChallengePoints            `json:"totalPoints"`
+	CategoryPoints map[string]rawChallengePoints `json:"categoryPoints"`
+	Challenges     []struct {
+		ChallengeID int64   `json:"challengeId"`
+		Level       string  `json:"level"`
+		Value       float64 `json:"value"`
+		Percentile  float64 `json:"percentile"`
+		// Milliseconds since the Unix epoch (absent for some challenges)
+		AchievedTime int64 `json:"achievedTime"`
+	} `json:"challenges"`
+}
+
+// rawChallengeConfig is a challenges-v1 challenge config
+type rawChallengeConfig struct {
+	ID             int64                           `json:"id"`
+	LocalizedNames map[string]models.ChallengeName `json:"localizedNames"`
+	State          string                          `json:"state"`
+	Tracking       string                          `json:"tracking"`
+	Leaderboard    bool                            `json:"leaderboard"`
+	Thresholds     map[string]float64              `json:"thresholds"`
+	// Milliseconds since the Unix epoch (absent for challenges without a window)
+	StartTimestamp int64 `json:"startTimestamp"`
+	EndTimestamp   int64 `json:"endTimestamp"`
+}
+
+// convertPlayerChallenges converts raw challenges-v1 player data into our model
+func convertPlayerChallenges(puuid string, rawPlayer rawPlayerChallenges) *models.PlayerChallenges {
+	player := &models.PlayerChallenges{
+		PUUID:          puuid,
+		TotalPoints:    models.ChallengePoints(rawPlayer.TotalPoints),
+		CategoryPoints: make(map[string]models.ChallengePoints, len(rawPlayer.CategoryPoints)),
+		Challenges:     make([]models.ChallengeProgress, len(rawPlayer.Challenges)),
+	}
+
+	for category, points := range rawPlayer.CategoryPoints {
+		player.CategoryPoints[category] = models.ChallengePoints(points)
+	}
+
+	for i, challenge := range rawPlayer.Challenges {
+		player.Challenges[i] = models.ChallengeProgress{
+			ChallengeID:  challenge.ChallengeID,
+			Level:        challenge.Level,
+			Value:        challenge.Value,
+			Percentile:   challenge.Percentile,
+			AchievedTime: optionalUnixMilli(challenge.AchievedTime),
+		}
+	}
+
+	return player
+}
+
+// convertChallengeConfig converts a raw challenges-v1 config into our model
+func convertChallengeConfig(rawConfig rawChallengeConfig) models.ChallengeConfig {
+	config := models.ChallengeConfig{
+		ID:             rawConfig.ID,
+		LocalizedNames: rawConfig.LocalizedNames,
+		State:          rawConfig.State,
+		Tracking:       rawConfig.Tracking,
+		Leaderboard:    rawConfig.Leaderboard,
+		Thresholds:     rawConfig.Thresholds,
+	}
+
+	if config.LocalizedNames == nil {
+		config.LocalizedNames = map[string]models.ChallengeName{}
+	}
+	if config.Thresholds == nil {
+		config.Thresholds = map[string]float64{}
+	}
+	config.StartTime = optionalUnixMilli(rawConfig.StartTimestamp)
+	config.EndTime = optionalUnixMilli(rawConfig.EndTimestamp)
+
+	return config
+}
+
+// JoinChallengeConfigs fills in each challenge's name, descriptions and thresholds from its config
+// Names use locale, falling back to DefaultChallengeLocale; challenges without a config are left unchanged
+func JoinChallengeConfigs(player *models.PlayerChallenges, configs []models.ChallengeConfig, locale string) {
+	configsByID := make(map[int64]models.ChallengeConfig, len(configs))
+	for _, config := range configs {
+		configsByID[config.ID] = config
+	}
+
+	for i := range player.Challenges {
+		challenge := &player.Challenges[i]
+
+		config, exists := configsByID[challenge.ChallengeID]
+		if !exists {
+			continue
+		}
+
+		names, exists := config.LocalizedNames[locale]
+		if !exists {
+			names = config.LocalizedNames[DefaultChallengeLocale]
+		}
+
+		challenge.Name = names.Name
+		challenge.ShortDescription = names.ShortDescription
+		challenge.Description = names.Description
+		challenge.Thresholds = config.Thresholds
+	}
+}
+
+// GetPlayerChallenges retrieves a player's progress on every challenge they have started
+func (riotService *RiotService) GetPlayerChallenges(ctx context.Context, region string, puuid string) (*models.PlayerChallenges, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/challenges/v1/player-data/%s", url.PathEscape(puuid))
+	url := riotService.buildURL(baseURL, path)
+
+	var rawPlayer rawPlayerChallenges
+	if err := riotService.makeRequest(ctx, playerChallengesEndpoint, url, &rawPlayer); err != nil {
+		return nil, fmt.Errorf("failed to get player challenges: %w", err)
+	}
+
+	return convertPlayerChallenges(puuid, rawPlayer), nil
+}
+
+// GetChallengeConfigs retrieves the definition of every challenge
+func (riotService *RiotService) GetChallengeConfigs(ctx context.Context, region string) ([]models.ChallengeConfig, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	url := riotService.buildURL(baseURL, "/lol/challenges/v1/challenges/config")
+
+	var rawConfigs []rawChallengeConfig
+	if err := riotService.makeRequest(ctx, challengeConfigsEndpoint, url, &rawConfigs); err != nil {
+		return nil, fmt.Errorf("failed to get challenge configs: %w", err)
+	}
+
+	configs := make([]models.ChallengeConfig, len(rawConfigs))
+	for i, rawConfig := range rawConfigs {
+		configs[i] = convertChallengeConfig(rawConfig)
+	}
+
+	return configs, nil
+}
+
+// GetChallengeLeaderboard retrieves the top limit players of a challenge at an apex level
+// Level and limit are validated before any request is made; see ChallengeLeaderboardOptions
+func (riotService *RiotService) GetChallengeLeaderboard(ctx context.Context, region string, challengeID int64, level string, limit int) ([]models.ChallengeLeaderboardEntry, error) {
+	if err := (ChallengeLeaderboardOptions{Level: level, Limit: limit}).Validate(); err != nil {
+		return nil, err
+	}
+
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/challenges/v1/challenges/%d/leaderboards/by-level/%s?limit=%d", challengeID, level, limit)
+	url := riotService.buildURL(baseURL, path)
+
+	var entries []models.ChallengeLeaderboardEntry
+	if err := riotService.makeRequest(ctx, challengeLeaderboardEndpoint, url, &entries); err != nil {
+		return nil, fmt.Errorf("failed to get challenge leaderboard: %w", err)
+	}
+
+	if entries == nil {
+		entries = []models.ChallengeLeaderboardEntry{}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// assertJSONFieldsAbsent fails the test if value encodes any of the given JSON fields
+func assertJSONFieldsAbsent(t *testing.T, value interface{}, fields ...string) {
+	t.Helper()
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Failed to encode %T: %v", value, err)
+	}
+
+	for _, field := range fields {
+		if strings.Contains(string(encoded), `"`+field+`"`) {
+			t.Errorf("Expected %s to be omitted, got %s", field, encoded)
+		}
+	}
+}
+
+// TestGetPlayerChallenges tests decoding of points, categories and per-challenge progress
+func TestGetPlayerChallenges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/challenges/v1/player-data/test-puuid" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{
+			"totalPoints": {"level": "GOLD", "current": 4200, "max": 30000, "percentile": 0.42},
+			"categoryPoints": {"TEAMWORK": {"level": "SILVER", "current": 800, "max": 5000, "percentile": 0.3}},
+			"challenges": [
+				{"challengeId": 101101, "level": "PLATINUM", "value": 125000.5, "percentile": 0.12, "achievedTime": 1700000000000},
+				{"challengeId": 2022001, "level": "NONE", "value": 0, "percentile": 1}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	player, err := service.GetPlayerChallenges(context.Background(), "na", "test-puuid")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if player.PUUID != "test-puuid" || player.TotalPoints != (models.ChallengePoints{Level: "GOLD", Current: 4200, Max: 30000, Percentile: 0.42}) {
+		t.Errorf("Unexpected player totals: %+v", player)
+	}
+	if player.CategoryPoints["TEAMWORK"].Level != "SILVER" {
+		t.Errorf("Expected TEAMWORK category points, got %+v", player.CategoryPoints)
+	}
+
+	if len(player.Challenges) != 2 {
+		t.Fatalf("Expected 2 challenges, got %d", len(player.Challenges))
+	}
+
+	challenge := player.Challenges[0]
+	if challenge.ChallengeID != 101101 || challenge.Level != "PLATINUM" || challenge.Value != 125000.5 || challenge.Percentile != 0.12 {
+		t.Errorf("Unexpected challenge: %+v", challenge)
+	}
+	if challenge.AchievedTime == nil || !challenge.AchievedTime.Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("Expected achieved time from epoch milliseconds, got %v", challenge.AchievedTime)
+	}
+	assertJSONFieldsAbsent(t, player.Challenges[1], "achievedTime")
+}
+
+// TestGetChallengeConfigs tests decoding of names, thresholds and the active window
+func TestGetChallengeConfigs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/challenges/v1/challenges/config" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`[
+			{"id": 101101, "state": "ENABLED", "tracking": "LIFETIME", "leaderboard": true,
+			 "localizedNames": {"en_US": {"name": "Damage Dealer", "shortDescription": "Deal damage", "description": "Deal damage to champions"}},
+			 "thresholds": {"GOLD": 100000, "PLATINUM": 120000}, "startTimestamp": 1700000000000},
+			{"id": 0, "state": "DISABLED"}
+		]`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	configs, err := service.GetChallengeConfigs(context.Background(), "na")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(configs) != 2 {
+		t.Fatalf("Expected 2 configs, got %d", len(configs))
+	}
+
+	config := configs[0]
+	if config.LocalizedNames["en_US"].Name != "Damage Dealer" || config.Thresholds["PLATINUM"] != 120000 || !config.Leaderboard {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+	if config.StartTime == nil || !config.StartTime.Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("Expected start time from epoch milliseconds, got %v", config.StartTime)
+	}
+	assertJSONFieldsAbsent(t, config, "endTime")
+	assertJSONFieldsAbsent(t, configs[1], "startTime", "endTime")
+
+	if configs[1].LocalizedNames == nil || configs[1].Thresholds == nil {
+		t.Error("Expected non-nil maps so they encode as {}")
+	}
+}
+
+// TestGetChallengeLeaderboard tests the leaderboard path, limit and entries
+func TestGetChallengeLeaderboard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/challenges/v1/challenges/101101/leaderboards/by-level/CHALLENGER" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		if limit := request.URL.Query().Get("limit"); limit != "2" {
+			t.Errorf("Expected limit 2, got '%s'", limit)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`[{"puuid": "puuid-a", "value": 900000, "position": 1}, {"puuid": "puuid-b", "value": 850000, "position": 2}]`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	entries, err := service.GetChallengeLeaderboard(context.Background(), "na", 101101, "CHALLENGER", 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(entries) != 2 || entries[1] != (models.ChallengeLeaderboardEntry{Position: 2, PUUID: "puuid-b", Value: 850000}) {
+		t.Errorf("Unexpected leaderboard: %+v", entries)
+	}
+}
+
+// TestGetChallengeLeaderboard_InvalidOptions tests that a bad level or limit is rejected without calling Riot
+func TestGetChallengeLeaderboard_InvalidOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		t.Errorf("Expected no request, got %s", request.URL.Path)
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	testCases := []struct {
+		level string
+		limit int
+		field string
+	}{
+		{"DIAMOND", 10, "level"},
+		{"challenger", 10, "level"},
+		{"CHALLENGER", 0, "limit"},
+		{"MASTER", MaxChallengeLeaderboardLimit + 1, "limit"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := service.GetChallengeLeaderboard(context.Background(), "na", 101101, testCase.level, testCase.limit)
+
+		var invalidOptionError *InvalidLeaderboardOptionError
+		if !errors.As(err, &invalidOptionError) || invalidOptionError.Field != testCase.field {
+			t.Errorf("Expected %s to be rejected for level %q and limit %d, got: %v", testCase.field, testCase.level, testCase.limit, err)
+		}
+	}
+}
+
+// TestJoinChallengeConfigs tests locale fallback and that challenges without a config are untouched
+func TestJoinChallengeConfigs(t *testing.T) {
+	player := &models.PlayerChallenges{Challenges: []models.ChallengeProgress{
+		{ChallengeID: 1, Level: "GOLD"},
+		{ChallengeID: 2, Level: "SILVER"},
+		{ChallengeID: 3, Level: "NONE"},
+	}}
+	configs := []models.ChallengeConfig{
+		{
+			ID: 1,
+			LocalizedNames: map[string]models.ChallengeName{
+				"en_US": {Name: "Damage Dealer"},
+				"ko_KR": {Name: "딜러"},
+			},
+			Thresholds: map[string]float64{"GOLD": 10},
+		},
+		{
+			ID:             2,
+			LocalizedNames: map[string]models.ChallengeName{"en_US": {Name: "Team Player", Description: "Assist teammates"}},
+		},
+	}
+
+	JoinChallengeConfigs(player, configs, "ko_KR")
+
+	if player.Challenges[0].Name != "딜러" || player.Challenges[0].Thresholds["GOLD"] != 10 {
+		t.Errorf("Expected localized name and thresholds, got %+v", player.Challenges[0])
+	}
+	if player.Challenges[1].Name != "Team Player" || player.Challenges[1].Description != "Assist teammates" {
+		t.Errorf("Expected fallback to %s, got %+v", DefaultChallengeLocale, player.Challenges[1])
+	}
+	if player.Challenges[2].Name != "" || player.Challenges[2].Thresholds != nil {
+		t.Errorf("Expected challenge without config to be untouched, got %+v", player.Challenges[2])
+	}
+}
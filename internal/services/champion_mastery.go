@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// rawChampionMastery is a champion-mastery-v4 entry
+type rawChampionMastery struct {
+	PUUID                        string   `json:"puuid"`
+	ChampionID                   int      `json:"championId"`
+	ChampionLevel                int      `json:"championLevel"`
+	ChampionPoints               int      `json:"championPoints"`
+	ChampionPointsSinceLastLevel int      `json:"championPointsSinceLastLevel"`
+	ChampionPointsUntilNextLevel int      `json:"championPointsUntilNextLevel"`
+	MarkRequiredForNextLevel     int      `json:"markRequiredForNextLevel"`
+	TokensEarned                 int      `json:"tokensEarned"`
+	ChampionSeasonMilestone      int      `json:"championSeasonMilestone"`
+	MilestoneGrades              []string `json:"milestoneGrades"`
+	// Milliseconds since the Unix epoch
+	LastPlayTime int64 `json:"lastPlayTime"`
+}
+
+// convertChampionMastery converts a raw champion-mastery-v4 entry into our model
+func convertChampionMastery(mastery rawChampionMastery) models.ChampionMastery {
+	milestoneGrades := mastery.MilestoneGrades
+	if milestoneGrades == nil {
+		milestoneGrades = []string{}
+	}
+
+	return models.ChampionMastery{
+		PUUID:                        mastery.PUUID,
+		ChampionID:                   mastery.ChampionID,
+		ChampionLevel:                mastery.ChampionLevel,
+		ChampionPoints:               mastery.ChampionPoints,
+		ChampionPointsSinceLastLevel: mastery.ChampionPointsSinceLastLevel,
+		ChampionPointsUntilNextLevel: mastery.ChampionPointsUntilNextLevel,
+		MarkRequiredForNextLevel:     mastery.MarkRequiredForNextLevel,
+		TokensEarned:                 mastery.TokensEarned,
+		ChampionSeasonMilestone:      mastery.ChampionSeasonMilestone,
+		MilestoneGrades:              milestoneGrades,
+		LastPlayTime:                 time.UnixMilli(mastery.LastPlayTime),
+	}
+}
+
+// convertChampionMasteries converts a list of raw champion-mastery-v4 entries
+func convertChampionMasteries(rawMasteries []rawChampionMastery) []models.ChampionMastery {
+	masteries := make([]models.ChampionMastery, len(rawMasteries))
+	for i, mastery := range rawMasteries {
+		masteries[i] = convertChampionMastery(mastery)
+	}
+	return masteries
+}
+
+// GetChampionMasteries retrieves a player's mastery of every champion they have played, highest points first
+func (riotService *RiotService) GetChampionMasteries(ctx context.Context, region string, puuid string) ([]models.ChampionMastery, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/champion-mastery/v4/champion-masteries/by-puuid/%s", url.PathEscape(puuid))
+	url := riotService.buildURL(baseURL, path)
+
+	var rawMasteries []rawChampionMastery
+	if err := riotService.makeRequest(ctx, championMasteriesByPUUIDEndpoint, url, &rawMasteries); err != nil {
+		return nil, fmt.Errorf("failed to get champion masteries: %w", err)
+	}
+
+	return convertChampionMasteries(rawMasteries), nil
+}
+
+// GetTopChampionMasteries retrieves a player's count highest champion masteries
+func (riotService *RiotService) GetTopChampionMasteries(ctx context.Context, region string, puuid string, count int) ([]models.ChampionMastery, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/champion-mastery/v4/champion-masteries/by-puuid/%s/top?count=%d", url.PathEscape(puuid), count)
+	url := riotService.buildURL(baseURL, path)
+
+	var rawMasteries []rawChampionMastery
+	if err := riotService.makeRequest(ctx, topChampionMasteriesByPUUIDEndpoint, url, &rawMasteries); err != nil {
+		return nil, fmt.Errorf("failed to get top champion masteries: %w", err)
+	}
+
+	return convertChampionMasteries(rawMasteries), nil
+}
+
+// GetChampionMastery retrieves a player's mastery of a single champion
+// Riot returns 404 for champions the player has never played
+func (riotService *RiotService) GetChampionMastery(ctx context.Context, region string, puuid string, championID int) (*models.ChampionMastery, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/champion-mastery/v4/champion-masteries/by-puuid/%s/by-champion/%d", url.PathEscape(puuid), championID)
+	url := riotService.buildURL(baseURL, path)
+
+	var rawMastery rawChampionMastery
+	if err := riotService.makeRequest(ctx, championMasteryByPUUIDEndpoint, url, &rawMastery); err != nil {
+		return nil, fmt.Errorf("failed to get champion mastery: %w", err)
+	}
+
+	mastery := convertChampionMastery(rawMastery)
+	return &mastery, nil
+}
+
+// GetMasteryScore retrieves a player's total mastery score (the sum of their champion mastery levels)
+func (riotService *RiotService) GetMasteryScore(ctx context.Context, region string, puuid string) (int, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return 0, err
+	}
+	path := fmt.Sprintf("/lol/champion-mastery/v4/scores/by-puuid/%s", url.PathEscape(puuid))
+	url := riotService.buildURL(baseURL, path)
+
+	var score int
+	if err := riotService.makeRequest(ctx, masteryScoreByPUUIDEndpoint, url, &score); err != nil {
+		return 0, fmt.Errorf("failed to get mastery score: %w", err)
+	}
+
+	return score, nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// masteryEntry is a champion-mastery-v4 entry for champion 103
+const masteryEntry = `{
+	"puuid": "test-puuid", "championId": 103, "championLevel": 12, "championPoints": 123456,
+	"championPointsSinceLastLevel": 10056, "championPointsUntilNextLevel": 800,
+	"markRequiredForNextLevel": 2, "tokensEarned": 1, "championSeasonMilestone": 3,
+	"milestoneGrades": ["S+", "A"], "lastPlayTime": 1700000000000
+}`
+
+// TestGetChampionMasteries tests decoding of the full mastery list
+func TestGetChampionMasteries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/champion-mastery/v4/champion-masteries/by-puuid/test-puuid" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`[` + masteryEntry + `, {"puuid": "test-puuid", "championId": 222}]`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	masteries, err := service.GetChampionMasteries(context.Background(), "na", "test-puuid")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(masteries) != 2 {
+		t.Fatalf("Expected 2 masteries, got %d", len(masteries))
+	}
+
+	mastery := masteries[0]
+	if mastery.ChampionID != 103 || mastery.ChampionLevel != 12 || mastery.ChampionPoints != 123456 {
+		t.Errorf("Unexpected mastery: %+v", mastery)
+	}
+	if mastery.ChampionPointsUntilNextLevel != 800 || mastery.MarkRequiredForNextLevel != 2 || mastery.ChampionSeasonMilestone != 3 {
+		t.Errorf("Expected progress fields to be decoded, got %+v", mastery)
+	}
+	if len(mastery.MilestoneGrades) != 2 || mastery.MilestoneGrades[0] != "S+" {
+		t.Errorf("Expected milestone grades [S+ A], got %v", mastery.MilestoneGrades)
+	}
+	if !mastery.LastPlayTime.Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("Expected last play time from epoch milliseconds, got %v", mastery.LastPlayTime)
+	}
+
+	if masteries[1].MilestoneGrades == nil {
+		t.Error("Expected non-nil milestone grades so they encode as []")
+	}
+}
+
+// TestGetTopChampionMasteries tests that the count is passed to Riot
+func TestGetTopChampionMasteries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/champion-mastery/v4/champion-masteries/by-puuid/test-puuid/top" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		if count := request.URL.Query().Get("count"); count != "3" {
+			t.Errorf("Expected count 3, got '%s'", count)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`[` + masteryEntry + `]`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	masteries, err := service.GetTopChampionMasteries(context.Background(), "na", "test-puuid", 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(masteries) != 1 || masteries[0].ChampionID != 103 {
+		t.Errorf("Expected the single top mastery, got %+v", masteries)
+	}
+}
+
+// TestGetChampionMastery tests the single-champion lookup and its not-found error
+func TestGetChampionMastery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/lol/champion-mastery/v4/champion-masteries/by-puuid/test-puuid/by-champion/103":
+			writer.Header().Set("Content-Type", "application/json")
+			writer.Write([]byte(masteryEntry))
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	mastery, err := service.GetChampionMastery(context.Background(), "na", "test-puuid", 103)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if mastery.ChampionID != 103 || mastery.PUUID != "test-puuid" {
+		t.Errorf("Unexpected mastery: %+v", mastery)
+	}
+
+	_, err = service.GetChampionMastery(context.Background(), "na", "test-puuid", 1)
+	if err == nil {
+		t.Fatal("Expected error for an unplayed champion")
+	}
+}
+
+// TestGetMasteryScore tests decoding of the bare integer score
+func TestGetMasteryScore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/champion-mastery/v4/scores/by-puuid/test-puuid" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`512`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	score, err := service.GetMasteryScore(context.Background(), "na", "test-puuid")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if score != 512 {
+		t.Errorf("Expected score 512, got %d", score)
+	}
+}
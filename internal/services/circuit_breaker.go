@@ -0,0 +1,194 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a host's circuit breaker
+type CircuitState string
+
+// Circuit breaker states
+const (
+	// Requests flow normally while failures are counted
+	CircuitClosed CircuitState = "closed"
+	// Requests fail fast without reaching the host
+	CircuitOpen CircuitState = "open"
+	// A limited number of probe requests test whether the host has recovered
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreakerPolicy controls when a host's circuit opens and how it recovers
+type CircuitBreakerPolicy struct {
+	// Consecutive failures that open the circuit
+	FailureThreshold int
+	// How long the circuit stays open before allowing probe requests
+	OpenDuration time.Duration
+	// Number of concurrent probe requests allowed while half-open
+	HalfOpenMaxRequests int
+}
+
+// DefaultCircuitBreakerPolicy returns the circuit breaker policy used when none is configured
+func DefaultCircuitBreakerPolicy() CircuitBreakerPolicy {
+	return CircuitBreakerPolicy{
+		FailureThreshold:    5,
+		OpenDuration:        30 * time.Second,
+		HalfOpenMaxRequests: 1,
+	}
+}
+
+// CircuitOpenError is returned without contacting Riot when a host's circuit is open
+type CircuitOpenError struct {
+	// Routing host whose circuit is open (e.g., europe.api.riotgames.com)
+	Host string
+	// Time until the circuit allows a probe request
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (circuitOpenError *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open for %s, retry in %s", circuitOpenError.Host, circuitOpenError.RetryAfter)
+}
+
+// CircuitBreakerStatus is a snapshot of a host's circuit breaker
+type CircuitBreakerStatus struct {
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutiveFailures"`
+	// When the circuit last opened (omitted while closed)
+	OpenedAt *time.Time `json:"openedAt,omitempty"`
+}
+
+// hostCircuit tracks the breaker state of a single host
+type hostCircuit struct {
+	state               CircuitState
+	consecutiveFailures int
+	openedAt            time.Time
+	// Probe requests currently in flight while half-open
+	probesInFlight int
+}
+
+// CircuitBreaker tracks a circuit per Riot routing host
+type CircuitBreaker struct {
+	mutex  sync.Mutex
+	policy CircuitBreakerPolicy
+	// Circuits keyed by host (e.g., na1.api.riotgames.com)
+	circuits map[string]*hostCircuit
+	// Clock used for open durations (overridable for testing)
+	now func() time.Time
+}
+
+// NewCircuitBreaker creates a CircuitBreaker with every host initially closed
+func NewCircuitBreaker(policy CircuitBreakerPolicy) *CircuitBreaker {
+	if policy.FailureThreshold < 1 {
+		policy.FailureThreshold = 1
+	}
+	if policy.HalfOpenMaxRequests < 1 {
+		policy.HalfOpenMaxRequests = 1
+	}
+
+	return &CircuitBreaker{
+		policy:   policy,
+		circuits: make(map[string]*hostCircuit),
+		now:      time.Now,
+	}
+}
+
+// circuit returns the circuit for host, creating a closed one if needed; the caller must hold the mutex
+func (breaker *CircuitBreaker) circuit(host string) *hostCircuit {
+	circuit, exists := breaker.circuits[host]
+	if !exists {
+		circuit = &hostCircuit{state: CircuitClosed}
+		breaker.circuits[host] = circuit
+	}
+	return circuit
+}
+
+// Allow reports whether a request to host may proceed
+// Returns a *CircuitOpenError while the circuit is open or its half-open probes are in use
+func (breaker *CircuitBreaker) Allow(host string) error {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	circuit := breaker.circuit(host)
+
+	if circuit.state == CircuitOpen {
+		remaining := circuit.openedAt.Add(breaker.policy.OpenDuration).Sub(breaker.now())
+		if remaining > 0 {
+			return &CircuitOpenError{Host: host, RetryAfter: remaining}
+		}
+		circuit.state = CircuitHalfOpen
+		circuit.probesInFlight = 0
+	}
+
+	if circuit.state == CircuitHalfOpen {
+		if circuit.probesInFlight >= breaker.policy.HalfOpenMaxRequests {
+			return &CircuitOpenError{Host: host, RetryAfter: breaker.policy.OpenDuration}
+		}
+		circuit.probesInFlight++
+	}
+
+	return nil
+}
+
+// Record reports the outcome of a request allowed by Allow
+// Successes close the circuit; failures count towards opening it, and any failed probe reopens it
+func (breaker *CircuitBreaker) Record(host string, success bool) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	circuit := breaker.circuit(host)
+	if circuit.state == CircuitHalfOpen && circuit.probesInFlight > 0 {
+		circuit.probesInFlight--
+	}
+
+	if success {
+		circuit.state = CircuitClosed
+		circuit.consecutiveFailures = 0
+		return
+	}
+
+	circuit.consecutiveFailures++
+	if circuit.state == CircuitHalfOpen || circuit.consecutiveFailures >= breaker.policy.FailureThreshold {
+		circuit.state = CircuitOpen
+		circuit.openedAt = breaker.now()
+	}
+}
+
+// Release gives back a half-open probe slot for a request that was allowed but never sent
+func (breaker *CircuitBreaker) Release(host string) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	circuit := breaker.circuit(host)
+	if circuit.state == CircuitHalfOpen && circuit.probesInFlight > 0 {
+		circuit.probesInFlight--
+	}
+}
+
+// Status returns a snapshot of every host's circuit
+func (breaker *CircuitBreaker) Status() map[string]CircuitBreakerStatus {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	status := make(map[string]CircuitBreakerStatus, len(breaker.circuits))
+	for host, circuit := range breaker.circuits {
+		hostStatus := CircuitBreakerStatus{
+			State:               circuit.state,
+			ConsecutiveFailures: circuit.consecutiveFailures,
+		}
+
+		// An open circuit whose duration has elapsed will let the next request through as a probe
+		if circuit.state == CircuitOpen && !breaker.now().Before(circuit.openedAt.Add(breaker.policy.OpenDuration)) {
+			hostStatus.State = CircuitHalfOpen
+		}
+
+		if circuit.state != CircuitClosed {
+			openedAt := circuit.openedAt
+			hostStatus.OpenedAt = &openedAt
+		}
+
+		status[host] = hostStatus
+	}
+	return status
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestCircuitBreaker creates a CircuitBreaker with a controllable clock
+func newTestCircuitBreaker(policy CircuitBreakerPolicy, currentTime *time.Time) *CircuitBreaker {
+	breaker := NewCircuitBreaker(policy)
+	breaker.now = func() time.Time { return *currentTime }
+	return breaker
+}
+
+// TestCircuitBreaker_OpensAfterThreshold tests that consecutive failures open the circuit
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	currentTime := time.Now()
+	breaker := newTestCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 3, OpenDuration: time.Minute}, &currentTime)
+	host := "europe.api.riotgames.com"
+
+	for i := 0; i < 3; i++ {
+		if err := breaker.Allow(host); err != nil {
+			t.Fatalf("Expected request %d to be allowed, got: %v", i, err)
+		}
+		breaker.Record(host, false)
+	}
+
+	err := breaker.Allow(host)
+
+	var circuitOpenError *CircuitOpenError
+	if !errors.As(err, &circuitOpenError) {
+		t.Fatalf("Expected CircuitOpenError, got: %v", err)
+	}
+
+	if circuitOpenError.Host != host || circuitOpenError.RetryAfter != time.Minute {
+		t.Errorf("Expected open circuit for %s retrying in 1m, got %+v", host, circuitOpenError)
+	}
+
+	if state := breaker.Status()[host].State; state != CircuitOpen {
+		t.Errorf("Expected state open, got %s", state)
+	}
+}
+
+// TestCircuitBreaker_SuccessResetsFailures tests that a success resets the failure count
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	currentTime := time.Now()
+	breaker := newTestCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 2, OpenDuration: time.Minute}, &currentTime)
+	host := "na1.api.riotgames.com"
+
+	breaker.Record(host, false)
+	breaker.Record(host, true)
+	breaker.Record(host, false)
+
+	if err := breaker.Allow(host); err != nil {
+		t.Errorf("Expected circuit to stay closed, got: %v", err)
+	}
+}
+
+// TestCircuitBreaker_HalfOpenProbe tests recovery through a half-open probe
+func TestCircuitBreaker_HalfOpenProbe(t *testing.T) {
+	currentTime := time.Now()
+	breaker := newTestCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 1, OpenDuration: time.Minute}, &currentTime)
+	host := "asia.api.riotgames.com"
+
+	breaker.Allow(host)
+	breaker.Record(host, false)
+
+	currentTime = currentTime.Add(time.Minute)
+	if state := breaker.Status()[host].State; state != CircuitHalfOpen {
+		t.Errorf("Expected state half-open once the open duration elapsed, got %s", state)
+	}
+
+	if err := breaker.Allow(host); err != nil {
+		t.Fatalf("Expected probe to be allowed, got: %v", err)
+	}
+
+	if err := breaker.Allow(host); err == nil {
+		t.Error("Expected a second concurrent probe to be rejected")
+	}
+
+	breaker.Record(host, true)
+
+	if state := breaker.Status()[host].State; state != CircuitClosed {
+		t.Errorf("Expected state closed after a successful probe, got %s", state)
+	}
+
+	if err := breaker.Allow(host); err != nil {
+		t.Errorf("Expected requests to flow after recovery, got: %v", err)
+	}
+}
+
+// TestCircuitBreaker_FailedProbeReopens tests that a failed probe reopens the circuit
+func TestCircuitBreaker_FailedProbeReopens(t *testing.T) {
+	currentTime := time.Now()
+	breaker := newTestCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 3, OpenDuration: time.Minute}, &currentTime)
+	host := "kr.api.riotgames.com"
+
+	for i := 0; i < 3; i++ {
+		breaker.Record(host, false)
+	}
+
+	currentTime = currentTime.Add(time.Minute)
+	breaker.Allow(host)
+	breaker.Record(host, false)
+
+	if err := breaker.Allow(host); err == nil {
+		t.Error("Expected circuit to reopen after a failed probe")
+	}
+}
+
+// TestCircuitBreaker_SeparateHosts tests that each host has its own circuit
+func TestCircuitBreaker_SeparateHosts(t *testing.T) {
+	currentTime := time.Now()
+	breaker := newTestCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 1, OpenDuration: time.Minute}, &currentTime)
+
+	breaker.Record("europe.api.riotgames.com", false)
+
+	if err := breaker.Allow("americas.api.riotgames.com"); err != nil {
+		t.Errorf("Expected americas to be unaffected by europe, got: %v", err)
+	}
+}
+
+// TestMakeRequest_CircuitBreakerFailsFast tests that an open circuit stops requests from reaching Riot
+func TestMakeRequest_CircuitBreakerFailsFast(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+	service.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+	service.SetCircuitBreakerPolicy(CircuitBreakerPolicy{FailureThreshold: 2, OpenDuration: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		service.GetSummonerByPUUID(context.Background(), "na", "test-puuid")
+	}
+
+	_, err := service.GetSummonerByPUUID(context.Background(), "na", "test-puuid")
+
+	var circuitOpenError *CircuitOpenError
+	if !errors.As(err, &circuitOpenError) {
+		t.Fatalf("Expected CircuitOpenError, got: %v", err)
+	}
+
+	if requestCount != 2 {
+		t.Errorf("Expected 2 requests to reach the server, got %d", requestCount)
+	}
+
+	serverURL, _ := url.Parse(server.URL)
+	if state := service.CircuitBreakerStatus()[serverURL.Host].State; state != CircuitOpen {
+		t.Errorf("Expected state open, got %s", state)
+	}
+}
+
+// TestMakeRequest_ClientErrorsKeepCircuitClosed tests that 4xx responses do not count as host failures
+func TestMakeRequest_ClientErrorsKeepCircuitClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+	service.SetCircuitBreakerPolicy(CircuitBreakerPolicy{FailureThreshold: 1, OpenDuration: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		_, err := service.GetSummonerByPUUID(context.Background(), "na", "test-puuid")
+
+		var riotAPIError *RiotAPIError
+		if !errors.As(err, &riotAPIError) {
+			t.Fatalf("Expected RiotAPIError on request %d, got: %v", i, err)
+		}
+	}
+}
+
+// TestMakeRequest_RateLimitWaitNotRecordedAsFailure tests that a rate limit wait longer than the request timeout
+// neither fails the request nor counts against the host's circuit
+func TestMakeRequest_RateLimitWaitNotRecordedAsFailure(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	service := NewRiotService("test-api-key")
+	service.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+	service.SetRequestTimeout(20 * time.Millisecond)
+	service.SetCircuitBreakerPolicy(CircuitBreakerPolicy{FailureThreshold: 1, OpenDuration: time.Minute, HalfOpenMaxRequests: 1})
+
+	serverURL, _ := url.Parse(server.URL)
+	service.rateLimiter.appScopes[serverURL.Host] = &rateScope{blockedUntil: time.Now().Add(100 * time.Millisecond)}
+
+	var result map[string]string
+	if err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	status := service.CircuitBreakerStatus()[serverURL.Host]
+	if status.State != CircuitClosed || status.ConsecutiveFailures != 0 {
+		t.Errorf("Expected a closed circuit without failures, got %+v", status)
+	}
+
+	if atomic.LoadInt32(&requestCount) != 1 {
+		t.Errorf("Expected 1 request to reach the server, got %d", requestCount)
+	}
+}
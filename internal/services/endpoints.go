@@ -0,0 +1,39 @@
+package services
+
+// riotEndpoint identifies a single Riot API method
+// Used as the key for per-method rate limits
+type riotEndpoint struct {
+	// Riot API service name (e.g., match-v5)
+	service string
+	// Method name within the service (e.g., getMatch)
+	method string
+}
+
+// key returns the unique identifier for the endpoint
+func (endpoint riotEndpoint) key() string {
+	return endpoint.service + "." + endpoint.method
+}
+
+// Riot API endpoints used by RiotService
+var (
+	accountByRiotIDEndpoint             = riotEndpoint{service: "account-v1", method: "getByRiotId"}
+	accountByPUUIDEndpoint              = riotEndpoint{service: "account-v1", method: "getByPuuid"}
+	summonerByPUUIDEndpoint             = riotEndpoint{service: "summoner-v4", method: "getByPUUID"}
+	matchIDsByPUUIDEndpoint             = riotEndpoint{service: "match-v5", method: "getMatchIdsByPUUID"}
+	matchEndpoint                       = riotEndpoint{service: "match-v5", method: "getMatch"}
+	matchTimelineEndpoint               = riotEndpoint{service: "match-v5", method: "getTimeline"}
+	leagueEntriesBySummonerEndpoint     = riotEndpoint{service: "league-v4", method: "getLeagueEntriesForSummoner"}
+	leagueEntriesByPUUIDEndpoint        = riotEndpoint{service: "league-v4", method: "getLeagueEntriesByPUUID"}
+	championMasteriesByPUUIDEndpoint    = riotEndpoint{service: "champion-mastery-v4", method: "getAllChampionMasteriesByPUUID"}
+	topChampionMasteriesByPUUIDEndpoint = riotEndpoint{service: "champion-mastery-v4", method: "getTopChampionMasteriesByPUUID"}
+	championMasteryByPUUIDEndpoint      = riotEndpoint{service: "champion-mastery-v4", method: "getChampionMasteryByPUUID"}
+	masteryScoreByPUUIDEndpoint         = riotEndpoint{service: "champion-mastery-v4", method: "getChampionMasteryScoreByPUUID"}
+	activeGameByPUUIDEndpoint           = riotEndpoint{service: "spectator-v5", method: "getCurrentGameInfoByPuuid"}
+	challengerLeagueEndpoint            = riotEndpoint{service: "league-v4", method: "getChallengerLeague"}
+	grandmasterLeagueEndpoint           = riotEndpoint{service: "league-v4", method: "getGrandmasterLeague"}
+	masterLeagueEndpoint                = riotEndpoint{service: "league-v4", method: "getMasterLeague"}
+	leagueExpEntriesEndpoint            = riotEndpoint{service: "league-exp-v4", method: "getLeagueEntries"}
+	playerChallengesEndpoint            = riotEndpoint{service: "challenges-v1", method: "getPlayerData"}
+	challengeConfigsEndpoint            = riotEndpoint{service: "challenges-v1", method: "getAllChallengeConfigs"}
+	challengeLeaderboardEndpoint        = riotEndpoint{service: "challenges-v1", method: "getChallengeLeaderboards"}
+)
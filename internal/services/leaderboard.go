@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// Limits on leaderboard pages
+const (
+	// Entries returned per page when no page size is given
+	DefaultLeaderboardPageSize = 50
+	// Largest page size accepted for apex tiers
+	MaxLeaderboardPageSize = 200
+	// Entries in a full league-exp-v4 page; a shorter page is the division's last
+	leagueExpPageSize = 205
+)
+
+// LeaderboardOptions selects a page of a ranked ladder
+type LeaderboardOptions struct {
+	// Queue type (RANKED_SOLO_5x5 or RANKED_FLEX_SR)
+	Queue string
+	// Rank tier (IRON through CHALLENGER)
+	Tier string
+	// Division within the tier (I to IV); required below the apex tiers and ignored for them
+	Division string
+	// 1-based page number
+	Page int
+	// Entries per page (1 to MaxLeaderboardPageSize) for apex tiers; must be left unset below them,
+	// where each page is one league-exp-v4 page because Riot does not expose a division's
+	// full ordering without fetching every page
+	PageSize int
+}
+
+// InvalidLeaderboardOptionError reports a leaderboard option Riot would reject
+type InvalidLeaderboardOptionError struct {
+	// Option name as it appears in requests (e.g., "tier")
+	Field   string
+	Message string
+}
+
+// Error implements the error interface
+func (invalidOptionError *InvalidLeaderboardOptionError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", invalidOptionError.Field, invalidOptionError.Message)
+}
+
+// Validate checks the options against league-v4's queues, tiers and divisions
+func (options LeaderboardOptions) Validate() error {
+	apexTier := IsApexTier(options.Tier)
+
+	switch {
+	case !rankedQueues[options.Queue]:
+		return &InvalidLeaderboardOptionError{Field: "queue", Message: "must be RANKED_SOLO_5x5 or RANKED_FLEX_SR"}
+	case !apexTier && !divisionTiers[options.Tier]:
+		return &InvalidLeaderboardOptionError{Field: "tier", Message: "must be a ranked tier from IRON to CHALLENGER"}
+	case !apexTier && !divisions[options.Division]:
+		return &InvalidLeaderboardOptionError{Field: "division", Message: "must be I, II, III or IV"}
+	case options.Page < 1:
+		return &InvalidLeaderboardOptionError{Field: "page", Message: "must be at least 1"}
+	case apexTier && (options.PageSize < 1 || options.PageSize > MaxLeaderboardPageSize):
+		return &InvalidLeaderboardOptionError{Field: "pageSize", Message: fmt.Sprintf("must be between 1 and %d", MaxLeaderboardPageSize)}
+	case !apexTier && options.PageSize != 0:
+		return &InvalidLeaderboardOptionError{Field: "pageSize", Message: "only applies to MASTER, GRANDMASTER and CHALLENGER"}
+	}
+	return nil
+}
+
+// GetLeaderboard returns one page of a ranked ladder, highest league points first
+// Apex tiers are sorted and paginated across the whole league; lower tiers are sorted within the league-exp-v4 page
+func GetLeaderboard(ctx context.Context, riotService RiotServiceInterface, region string, options LeaderboardOptions) (*models.Leaderboard, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	leaderboard := &models.Leaderboard{
+		Queue: options.Queue,
+		Tier:  options.Tier,
+		Page:  options.Page,
+	}
+
+	if IsApexTier(options.Tier) {
+		league, err := riotService.GetApexLeague(ctx, region, options.Queue, options.Tier)
+		if err != nil {
+			return nil, err
+		}
+
+		// Copy before sorting so a cached league is never reordered in place
+		entries := append([]models.LeagueEntry{}, league.Entries...)
+		sortLeagueEntries(entries)
+
+		// Compare before multiplying so huge client-supplied pages cannot overflow into a negative offset
+		start := len(entries)
+		if options.Page-1 <= len(entries)/options.PageSize {
+			start = min((options.Page-1)*options.PageSize, len(entries))
+		}
+		end := min(start+options.PageSize, len(entries))
+
+		leaderboard.PageSize = options.PageSize
+		leaderboard.Total = len(entries)
+		leaderboard.HasMore = end < len(entries)
+		leaderboard.Entries = entries[start:end]
+		return leaderboard, nil
+	}
+
+	entries, err := riotService.GetLeagueEntries(ctx, region, options.Queue, options.Tier, options.Division, options.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	entries = append([]models.LeagueEntry{}, entries...)
+	sortLeagueEntries(entries)
+
+	leaderboard.Division = options.Division
+	// league-exp-v4 does not report a page count; only a full page can be followed by another
+	leaderboard.HasMore = len(entries) >= leagueExpPageSize
+	leaderboard.Entries = entries
+	return leaderboard, nil
+}
+
+// sortLeagueEntries orders entries by league points, then wins, then PUUID so pages are stable
+func sortLeagueEntries(entries []models.LeagueEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].LeaguePoints != entries[j].LeaguePoints {
+			return entries[i].LeaguePoints > entries[j].LeaguePoints
+		}
+		if entries[i].Wins != entries[j].Wins {
+			return entries[i].Wins > entries[j].Wins
+		}
+		return entries[i].PUUID < entries[j].PUUID
+	})
+}
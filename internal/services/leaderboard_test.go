@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// leagueRiotService serves a fixed apex league and league entry page
+type leagueRiotService struct {
+	countingRiotService
+	league  models.League
+	entries []models.LeagueEntry
+	// Page requested from GetLeagueEntries
+	requestedPage int
+}
+
+func (service *leagueRiotService) GetApexLeague(ctx context.Context, region string, queue string, tier string) (*models.League, error) {
+	return &service.league, nil
+}
+
+func (service *leagueRiotService) GetLeagueEntries(ctx context.Context, region string, queue string, tier string, division string, page int) ([]models.LeagueEntry, error) {
+	service.requestedPage = page
+	return service.entries, nil
+}
+
+// entryPUUIDs returns the PUUIDs of entries in order
+func entryPUUIDs(entries []models.LeagueEntry) []string {
+	puuids := make([]string, len(entries))
+	for i, entry := range entries {
+		puuids[i] = entry.PUUID
+	}
+	return puuids
+}
+
+// TestLeaderboardOptions_Validate tests the accepted queues, tiers, divisions and page bounds
+func TestLeaderboardOptions_Validate(t *testing.T) {
+	valid := LeaderboardOptions{Queue: RankedSoloQueue, Tier: TierChallenger, Page: 1, PageSize: 50}
+
+	testCases := []struct {
+		name          string
+		modify        func(options *LeaderboardOptions)
+		expectedField string
+	}{
+		{"valid apex", func(options *LeaderboardOptions) {}, ""},
+		{"valid division", func(options *LeaderboardOptions) { options.Tier, options.Division, options.PageSize = "GOLD", "IV", 0 }, ""},
+		{"unknown queue", func(options *LeaderboardOptions) { options.Queue = "RANKED_TFT" }, "queue"},
+		{"unknown tier", func(options *LeaderboardOptions) { options.Tier = "WOOD" }, "tier"},
+		{"missing division", func(options *LeaderboardOptions) { options.Tier = "GOLD" }, "division"},
+		{"zero page", func(options *LeaderboardOptions) { options.Page = 0 }, "page"},
+		{"page size too large", func(options *LeaderboardOptions) { options.PageSize = MaxLeaderboardPageSize + 1 }, "pageSize"},
+		{"page size below apex", func(options *LeaderboardOptions) { options.Tier, options.Division = "GOLD", "IV" }, "pageSize"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			options := valid
+			testCase.modify(&options)
+
+			err := options.Validate()
+
+			var invalidOptionError *InvalidLeaderboardOptionError
+			switch {
+			case testCase.expectedField == "" && err != nil:
+				t.Errorf("Expected no error, got: %v", err)
+			case testCase.expectedField != "" && (!errors.As(err, &invalidOptionError) || invalidOptionError.Field != testCase.expectedField):
+				t.Errorf("Expected invalid %s, got: %v", testCase.expectedField, err)
+			}
+		})
+	}
+}
+
+// TestGetLeaderboard_Apex tests sorting and pagination across a whole apex league
+func TestGetLeaderboard_Apex(t *testing.T) {
+	service := &leagueRiotService{league: models.League{Entries: []models.LeagueEntry{
+		{PUUID: "c", LeaguePoints: 900, Wins: 100},
+		{PUUID: "a", LeaguePoints: 1200, Wins: 150},
+		{PUUID: "d", LeaguePoints: 900, Wins: 120},
+		{PUUID: "b", LeaguePoints: 700, Wins: 90},
+		{PUUID: "e", LeaguePoints: 900, Wins: 120},
+	}}}
+	options := LeaderboardOptions{Queue: RankedSoloQueue, Tier: TierChallenger, Page: 1, PageSize: 3}
+
+	firstPage, err := GetLeaderboard(context.Background(), service, "na", options)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if puuids := entryPUUIDs(firstPage.Entries); len(puuids) != 3 || puuids[0] != "a" || puuids[1] != "d" || puuids[2] != "e" {
+		t.Errorf("Expected a, d, e on the first page, got %v", puuids)
+	}
+	if firstPage.Total != 5 || !firstPage.HasMore {
+		t.Errorf("Expected total 5 with more pages, got %+v", firstPage)
+	}
+
+	options.Page = 2
+	secondPage, _ := GetLeaderboard(context.Background(), service, "na", options)
+	if puuids := entryPUUIDs(secondPage.Entries); len(puuids) != 2 || puuids[0] != "c" || puuids[1] != "b" || secondPage.HasMore {
+		t.Errorf("Expected c, b on the last page, got %v (hasMore %t)", puuids, secondPage.HasMore)
+	}
+
+	options.Page = 3
+	emptyPage, _ := GetLeaderboard(context.Background(), service, "na", options)
+	if emptyPage.Entries == nil || len(emptyPage.Entries) != 0 || emptyPage.HasMore {
+		t.Errorf("Expected an empty final page, got %+v", emptyPage)
+	}
+
+	if service.league.Entries[0].PUUID != "c" {
+		t.Error("Expected the source league to be left in Riot's order")
+	}
+}
+
+// TestGetLeaderboard_HugePage tests that pages far past the end return an empty page instead of overflowing
+func TestGetLeaderboard_HugePage(t *testing.T) {
+	service := &leagueRiotService{league: models.League{Entries: []models.LeagueEntry{{PUUID: "a"}, {PUUID: "b"}}}}
+
+	for _, pageSize := range []int{3, MaxLeaderboardPageSize} {
+		options := LeaderboardOptions{Queue: RankedSoloQueue, Tier: TierChallenger, Page: 4611686018427387905, PageSize: pageSize}
+
+		leaderboard, err := GetLeaderboard(context.Background(), service, "na", options)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(leaderboard.Entries) != 0 || leaderboard.HasMore || leaderboard.Total != 2 {
+			t.Errorf("Expected an empty page for page size %d, got %+v", pageSize, leaderboard)
+		}
+	}
+}
+
+// TestGetLeaderboard_Division tests that division tiers use the league-exp page and sort within it
+func TestGetLeaderboard_Division(t *testing.T) {
+	service := &leagueRiotService{entries: []models.LeagueEntry{
+		{PUUID: "low", LeaguePoints: 10},
+		{PUUID: "high", LeaguePoints: 90},
+	}}
+	options := LeaderboardOptions{Queue: RankedFlexQueue, Tier: "GOLD", Division: "II", Page: 4}
+
+	leaderboard, err := GetLeaderboard(context.Background(), service, "na", options)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if service.requestedPage != 4 {
+		t.Errorf("Expected league-exp page 4, got %d", service.requestedPage)
+	}
+	if puuids := entryPUUIDs(leaderboard.Entries); puuids[0] != "high" || puuids[1] != "low" {
+		t.Errorf("Expected entries sorted by league points, got %v", puuids)
+	}
+	if leaderboard.Division != "II" || leaderboard.HasMore {
+		t.Errorf("Expected division II with no more pages after a short page, got %+v", leaderboard)
+	}
+}
+
+// TestGetLeaderboard_DivisionFullPage tests that only a full league-exp page reports more pages
+func TestGetLeaderboard_DivisionFullPage(t *testing.T) {
+	service := &leagueRiotService{entries: make([]models.LeagueEntry, leagueExpPageSize)}
+	options := LeaderboardOptions{Queue: RankedSoloQueue, Tier: "SILVER", Division: "I", Page: 1}
+
+	leaderboard, err := GetLeaderboard(context.Background(), service, "na", options)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !leaderboard.HasMore {
+		t.Error("Expected more pages after a full league-exp page")
+	}
+
+	service.entries = service.entries[:leagueExpPageSize-1]
+	if leaderboard, _ = GetLeaderboard(context.Background(), service, "na", options); leaderboard.HasMore {
+		t.Error("Expected no more pages after a short last page")
+	}
+}
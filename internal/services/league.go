@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// Ranked queues with leagues
+const (
+	RankedSoloQueue = "RANKED_SOLO_5x5"
+	RankedFlexQueue = "RANKED_FLEX_SR"
+)
+
+// Apex tiers, which have a single league per queue instead of divisions
+const (
+	TierChallenger  = "CHALLENGER"
+	TierGrandmaster = "GRANDMASTER"
+	TierMaster      = "MASTER"
+)
+
+// rankedQueues lists the queues accepted by league-v4 and league-exp-v4
+var rankedQueues = map[string]bool{
+	RankedSoloQueue: true,
+	RankedFlexQueue: true,
+}
+
+// apexLeagues maps each apex tier to its league-v4 path segment and endpoint
+var apexLeagues = map[string]struct {
+	path     string
+	endpoint riotEndpoint
+}{
+	TierChallenger:  {path: "challengerleagues", endpoint: challengerLeagueEndpoint},
+	TierGrandmaster: {path: "grandmasterleagues", endpoint: grandmasterLeagueEndpoint},
+	TierMaster:      {path: "masterleagues", endpoint: masterLeagueEndpoint},
+}
+
+// divisionTiers lists the tiers that are split into divisions
+var divisionTiers = map[string]bool{
+	"IRON":     true,
+	"BRONZE":   true,
+	"SILVER":   true,
+	"GOLD":     true,
+	"PLATINUM": true,
+	"EMERALD":  true,
+	"DIAMOND":  true,
+}
+
+// divisions lists the divisions within a division tier
+var divisions = map[string]bool{
+	"I":   true,
+	"II":  true,
+	"III": true,
+	"IV":  true,
+}
+
+// IsApexTier reports whether tier has a single league per queue
+func IsApexTier(tier string) bool {
+	_, exists := apexLeagues[tier]
+	return exists
+}
+
+// rawMiniSeries is a league-v4 promotion series
+type rawMiniSeries struct {
+	Target   int    `json:"target"`
+	Wins     int    `json:"wins"`
+	Losses   int    `json:"losses"`
+	Progress string `json:"progress"`
+}
+
+// rawLeagueEntry is a league-v4 or league-exp-v4 entry
+// Entries nested in a league list omit leagueId, queueType and tier, which come from the list
+type rawLeagueEntry struct {
+	LeagueID     string         `json:"leagueId"`
+	PUUID        string         `json:"puuid"`
+	QueueType    string         `json:"queueType"`
+	Tier         string         `json:"tier"`
+	Rank         string         `json:"rank"`
+	LeaguePoints int            `json:"leaguePoints"`
+	Wins         int            `json:"wins"`
+	Losses       int            `json:"losses"`
+	HotStreak    bool           `json:"hotStreak"`
+	Veteran      bool           `json:"veteran"`
+	FreshBlood   bool           `json:"freshBlood"`
+	Inactive     bool           `json:"inactive"`
+	MiniSeries   *rawMiniSeries `json:"miniSeries"`
+}
+
+// rawLeague is a league-v4 league list
+type rawLeague struct {
+	LeagueID string           `json:"leagueId"`
+	Name     string           `json:"name"`
+	Queue    string           `json:"queue"`
+	Tier     string           `json:"tier"`
+	Entries  []rawLeagueEntry `json:"entries"`
+}
+
+// convertLeagueEntry converts a raw league entry into our model
+func convertLeagueEntry(entry rawLeagueEntry) models.LeagueEntry {
+	leagueEntry := models.LeagueEntry{
+		LeagueID:     entry.LeagueID,
+		PUUID:        entry.PUUID,
+		QueueType:    entry.QueueType,
+		Tier:         entry.Tier,
+		Rank:         entry.Rank,
+		LeaguePoints: entry.LeaguePoints,
+		Wins:         entry.Wins,
+		Losses:       entry.Losses,
+		HotStreak:    entry.HotStreak,
+		Veteran:      entry.Veteran,
+		FreshBlood:   entry.FreshBlood,
+		Inactive:     entry.Inactive,
+	}
+
+	if entry.MiniSeries != nil {
+		miniSeries := models.MiniSeries(*entry.MiniSeries)
+		leagueEntry.MiniSeries = &miniSeries
+	}
+
+	return leagueEntry
+}
+
+// convertRankedStats converts a player's raw league entries (one per queue) into ranked stats
+func convertRankedStats(rawEntries []rawLeagueEntry) []models.RankedStats {
+	rankedStats := make([]models.RankedStats, len(rawEntries))
+	for i, entry := range rawEntries {
+		rankedStats[i] = models.RankedStats{
+			QueueType:    entry.QueueType,
+			Tier:         entry.Tier,
+			Rank:         entry.Rank,
+			LeaguePoints: entry.LeaguePoints,
+			Wins:         entry.Wins,
+			Losses:       entry.Losses,
+			WinRate:      winRate(entry.Wins, entry.Losses),
+			LeagueID:     entry.LeagueID,
+			HotStreak:    entry.HotStreak,
+			Veteran:      entry.Veteran,
+			FreshBlood:   entry.FreshBlood,
+			Inactive:     entry.Inactive,
+		}
+
+		if entry.MiniSeries != nil {
+			miniSeries := models.MiniSeries(*entry.MiniSeries)
+			rankedStats[i].MiniSeries = &miniSeries
+		}
+	}
+	return rankedStats
+}
+
+// winRate returns the percentage of games won, rounded to one decimal, or 0 when no games were played
+func winRate(wins int, losses int) float64 {
+	games := wins + losses
+	if games == 0 {
+		return 0
+	}
+	return math.Round(float64(wins)*1000/float64(games)) / 10
+}
+
+// convertLeague converts a raw league list into our model, filling in the fields its entries omit
+func convertLeague(rawList rawLeague) *models.League {
+	league := &models.League{
+		LeagueID: rawList.LeagueID,
+		Name:     rawList.Name,
+		Queue:    rawList.Queue,
+		Tier:     rawList.Tier,
+		Entries:  make([]models.LeagueEntry, len(rawList.Entries)),
+	}
+
+	for i, rawEntry := range rawList.Entries {
+		entry := convertLeagueEntry(rawEntry)
+		entry.LeagueID = rawList.LeagueID
+		entry.QueueType = rawList.Queue
+		entry.Tier = rawList.Tier
+		league.Entries[i] = entry
+	}
+
+	return league
+}
+
+// GetApexLeague retrieves a queue's whole Challenger, Grandmaster or Master league
+func (riotService *RiotService) GetApexLeague(ctx context.Context, region string, queue string, tier string) (*models.League, error) {
+	apexLeague, exists := apexLeagues[tier]
+	if !exists {
+		return nil, &InvalidLeaderboardOptionError{Field: "tier", Message: "must be CHALLENGER, GRANDMASTER or MASTER"}
+	}
+
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/league/v4/%s/by-queue/%s", apexLeague.path, queue)
+	url := riotService.buildURL(baseURL, path)
+
+	var rawList rawLeague
+	if err := riotService.makeRequest(ctx, apexLeague.endpoint, url, &rawList); err != nil {
+		return nil, fmt.Errorf("failed to get %s league: %w", tier, err)
+	}
+
+	return convertLeague(rawList), nil
+}
+
+// GetLeagueEntries retrieves one page of a queue's entries in a tier and division
+// Pages are 1-based; a page past the end is empty
+func (riotService *RiotService) GetLeagueEntries(ctx context.Context, region string, queue string, tier string, division string, page int) ([]models.LeagueEntry, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/league-exp/v4/entries/%s/%s/%s?page=%d", queue, tier, division, page)
+	url := riotService.buildURL(baseURL, path)
+
+	var rawEntries []rawLeagueEntry
+	if err := riotService.makeRequest(ctx, leagueExpEntriesEndpoint, url, &rawEntries); err != nil {
+		return nil, fmt.Errorf("failed to get league entries: %w", err)
+	}
+
+	entries := make([]models.LeagueEntry, len(rawEntries))
+	for i, rawEntry := range rawEntries {
+		entries[i] = convertLeagueEntry(rawEntry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// TestGetApexLeague tests that entries inherit the league's ID, queue and tier
+func TestGetApexLeague(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/league/v4/grandmasterleagues/by-queue/RANKED_SOLO_5x5" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{
+			"leagueId": "league-1", "name": "Sejuani's Spellslingers", "queue": "RANKED_SOLO_5x5", "tier": "GRANDMASTER",
+			"entries": [
+				{"puuid": "puuid-a", "rank": "I", "leaguePoints": 512, "wins": 200, "losses": 150,
+				 "hotStreak": true, "veteran": true, "freshBlood": false, "inactive": false}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	league, err := service.GetApexLeague(context.Background(), "na", RankedSoloQueue, TierGrandmaster)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if league.LeagueID != "league-1" || league.Name != "Sejuani's Spellslingers" || len(league.Entries) != 1 {
+		t.Fatalf("Unexpected league: %+v", league)
+	}
+
+	expected := models.LeagueEntry{
+		LeagueID:     "league-1",
+		PUUID:        "puuid-a",
+		QueueType:    RankedSoloQueue,
+		Tier:         TierGrandmaster,
+		Rank:         "I",
+		LeaguePoints: 512,
+		Wins:         200,
+		Losses:       150,
+		HotStreak:    true,
+		Veteran:      true,
+	}
+	if league.Entries[0] != expected {
+		t.Errorf("Expected entry %+v, got %+v", expected, league.Entries[0])
+	}
+}
+
+// TestGetApexLeague_InvalidTier tests that division tiers are rejected before calling Riot
+func TestGetApexLeague_InvalidTier(t *testing.T) {
+	service := NewRiotServiceWithBaseURL("test-api-key", "http://127.0.0.1:0", http.DefaultClient)
+
+	_, err := service.GetApexLeague(context.Background(), "na", RankedSoloQueue, "GOLD")
+
+	invalidOptionError, ok := err.(*InvalidLeaderboardOptionError)
+	if !ok || invalidOptionError.Field != "tier" {
+		t.Errorf("Expected invalid tier error, got %v", err)
+	}
+}
+
+// TestGetLeagueEntries tests the league-exp-v4 path, page and mini series decoding
+func TestGetLeagueEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/league-exp/v4/entries/RANKED_FLEX_SR/GOLD/II" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		if page := request.URL.Query().Get("page"); page != "3" {
+			t.Errorf("Expected page 3, got '%s'", page)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`[
+			{"leagueId": "league-2", "puuid": "puuid-a", "queueType": "RANKED_FLEX_SR", "tier": "GOLD", "rank": "II",
+			 "leaguePoints": 100, "wins": 20, "losses": 18, "freshBlood": true,
+			 "miniSeries": {"target": 2, "wins": 1, "losses": 0, "progress": "WNN"}},
+			{"leagueId": "league-3", "puuid": "puuid-b", "queueType": "RANKED_FLEX_SR", "tier": "GOLD", "rank": "II",
+			 "leaguePoints": 40, "wins": 9, "losses": 12, "inactive": true}
+		]`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	entries, err := service.GetLeagueEntries(context.Background(), "na", RankedFlexQueue, "GOLD", "II", 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	promoting := entries[0]
+	if !promoting.FreshBlood || promoting.MiniSeries == nil || *promoting.MiniSeries != (models.MiniSeries{Target: 2, Wins: 1, Progress: "WNN"}) {
+		t.Errorf("Expected fresh blood entry in a promotion series, got %+v", promoting)
+	}
+
+	if !entries[1].Inactive || entries[1].MiniSeries != nil || entries[1].LeagueID != "league-3" {
+		t.Errorf("Expected inactive entry without a series, got %+v", entries[1])
+	}
+}
+
+// TestGetRankedStatsByPUUID tests the by-puuid path and the full entry fields
+func TestGetRankedStatsByPUUID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/league/v4/entries/by-puuid/test-puuid" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`[
+			{"leagueId": "league-1", "puuid": "test-puuid", "queueType": "RANKED_SOLO_5x5", "tier": "GOLD", "rank": "I",
+			 "leaguePoints": 100, "wins": 2, "losses": 1, "hotStreak": true, "veteran": false, "freshBlood": true, "inactive": false,
+			 "miniSeries": {"target": 3, "wins": 2, "losses": 1, "progress": "WLWNN"}},
+			{"leagueId": "league-2", "puuid": "test-puuid", "queueType": "RANKED_FLEX_SR", "tier": "SILVER", "rank": "IV",
+			 "leaguePoints": 0, "wins": 0, "losses": 0}
+		]`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	rankedStats, err := service.GetRankedStatsByPUUID(context.Background(), "na", "test-puuid")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(rankedStats) != 2 {
+		t.Fatalf("Expected 2 ranked entries, got %d", len(rankedStats))
+	}
+
+	expected := models.RankedStats{
+		QueueType:    RankedSoloQueue,
+		Tier:         "GOLD",
+		Rank:         "I",
+		LeaguePoints: 100,
+		Wins:         2,
+		Losses:       1,
+		WinRate:      66.7,
+		LeagueID:     "league-1",
+		HotStreak:    true,
+		FreshBlood:   true,
+		MiniSeries:   &models.MiniSeries{Target: 3, Wins: 2, Losses: 1, Progress: "WLWNN"},
+	}
+	if !reflect.DeepEqual(rankedStats[0], expected) {
+		t.Errorf("Expected %+v, got %+v", expected, rankedStats[0])
+	}
+
+	if rankedStats[1].WinRate != 0 || rankedStats[1].MiniSeries != nil {
+		t.Errorf("Expected zero win rate and no series without games, got %+v", rankedStats[1])
+	}
+}
+
+// TestWinRate tests rounding of the win percentage
+func TestWinRate(t *testing.T) {
+	testCases := []struct {
+		wins, losses int
+		expected     float64
+	}{
+		{0, 0, 0},
+		{1, 0, 100},
+		{1, 1, 50},
+		{1, 2, 33.3},
+		{55, 45, 55},
+		{104, 97, 51.7},
+	}
+
+	for _, testCase := range testCases {
+		if rate := winRate(testCase.wins, testCase.losses); rate != testCase.expected {
+			t.Errorf("Expected win rate %v for %d-%d, got %v", testCase.expected, testCase.wins, testCase.losses, rate)
+		}
+	}
+}
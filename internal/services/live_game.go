@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// rawActiveGame is a spectator-v5 current game
+type rawActiveGame struct {
+	GameID            int64  `json:"gameId"`
+	GameMode          string `json:"gameMode"`
+	GameType          string `json:"gameType"`
+	GameQueueConfigID int    `json:"gameQueueConfigId"`
+	MapID             int    `json:"mapId"`
+	PlatformID        string `json:"platformId"`
+	// Milliseconds since the Unix epoch (0 while players are still loading)
+	GameStartTime   int64 `json:"gameStartTime"`
+	GameLength      int   `json:"gameLength"`
+	BannedChampions []struct {
+		ChampionID int `json:"championId"`
+		TeamID     int `json:"teamId"`
+		PickTurn   int `json:"pickTurn"`
+	} `json:"bannedChampions"`
+	Participants []struct {
+		PUUID         string `json:"puuid"`
+		RiotID        string `json:"riotId"`
+		TeamID        int    `json:"teamId"`
+		ChampionID    int    `json:"championId"`
+		Spell1ID      int    `json:"spell1Id"`
+		Spell2ID      int    `json:"spell2Id"`
+		ProfileIconID int    `json:"profileIconId"`
+		Bot           bool   `json:"bot"`
+		Perks         struct {
+			PerkIDs      []int `json:"perkIds"`
+			PerkStyle    int   `json:"perkStyle"`
+			PerkSubStyle int   `json:"perkSubStyle"`
+		} `json:"perks"`
+	} `json:"participants"`
+}
+
+// convertActiveGame converts a raw spectator-v5 game into our model
+func convertActiveGame(rawGame rawActiveGame) *models.ActiveGame {
+	game := &models.ActiveGame{
+		InGame:       true,
+		GameID:       rawGame.GameID,
+		GameMode:     rawGame.GameMode,
+		GameType:     rawGame.GameType,
+		QueueID:      rawGame.GameQueueConfigID,
+		QueueName:    QueueName(rawGame.GameQueueConfigID),
+		MapID:        rawGame.MapID,
+		PlatformID:   rawGame.PlatformID,
+		GameLength:   rawGame.GameLength,
+		Bans:         make([]models.ActiveGameBan, len(rawGame.BannedChampions)),
+		Participants: make([]models.ActiveGameParticipant, len(rawGame.Participants)),
+	}
+
+	// Riot reports 0 while players are still loading
+	game.GameStartTime = optionalUnixMilli(rawGame.GameStartTime)
+
+	for i, ban := range rawGame.BannedChampions {
+		game.Bans[i] = models.ActiveGameBan{ChampionID: ban.ChampionID, TeamID: ban.TeamID, PickTurn: ban.PickTurn}
+	}
+
+	for i, participant := range rawGame.Participants {
+		perkIDs := participant.Perks.PerkIDs
+		if perkIDs == nil {
+			perkIDs = []int{}
+		}
+
+		game.Participants[i] = models.ActiveGameParticipant{
+			PUUID:         participant.PUUID,
+			RiotID:        participant.RiotID,
+			TeamID:        participant.TeamID,
+			Side:          teamSide(participant.TeamID),
+			ChampionID:    participant.ChampionID,
+			Spell1ID:      participant.Spell1ID,
+			Spell2ID:      participant.Spell2ID,
+			ProfileIconID: participant.ProfileIconID,
+			Bot:           participant.Bot,
+			PerkStyle:     participant.Perks.PerkStyle,
+			PerkSubStyle:  participant.Perks.PerkSubStyle,
+			PerkIDs:       perkIDs,
+		}
+	}
+
+	return game
+}
+
+// notInGame returns the result reported for a player who is not currently in a game
+func notInGame() *models.ActiveGame {
+	return &models.ActiveGame{
+		Bans:         []models.ActiveGameBan{},
+		Participants: []models.ActiveGameParticipant{},
+	}
+}
+
+// GetActiveGame retrieves the game a player is currently in
+// Riot answers 404 for players who are not in a game, which is reported as a result with InGame false
+func (riotService *RiotService) GetActiveGame(ctx context.Context, region string, puuid string) (*models.ActiveGame, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/spectator/v5/active-games/by-summoner/%s", url.PathEscape(puuid))
+	url := riotService.buildURL(baseURL, path)
+
+	var rawGame rawActiveGame
+	if err := riotService.makeRequest(ctx, activeGameByPUUIDEndpoint, url, &rawGame); err != nil {
+		var riotAPIError *RiotAPIError
+		if errors.As(err, &riotAPIError) && riotAPIError.StatusCode == http.StatusNotFound {
+			return notInGame(), nil
+		}
+		return nil, fmt.Errorf("failed to get active game: %w", err)
+	}
+
+	return convertActiveGame(rawGame), nil
+}
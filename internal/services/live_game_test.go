@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// activeGameResponse is a spectator-v5 ranked game with one player per team
+const activeGameResponse = `{
+	"gameId": 5012345678, "gameMode": "CLASSIC", "gameType": "MATCHED", "gameQueueConfigId": 420,
+	"mapId": 11, "platformId": "NA1", "gameStartTime": 1700000000000, "gameLength": 754,
+	"bannedChampions": [{"championId": 157, "teamId": 100, "pickTurn": 1}, {"championId": -1, "teamId": 200, "pickTurn": 6}],
+	"participants": [
+		{"puuid": "test-puuid", "riotId": "TestPlayer#NA1", "teamId": 100, "championId": 103, "spell1Id": 4, "spell2Id": 14,
+		 "profileIconId": 29, "bot": false, "perks": {"perkIds": [8112, 8139], "perkStyle": 8100, "perkSubStyle": 8200}},
+		{"puuid": "enemy-puuid", "riotId": "Enemy#EUW", "teamId": 200, "championId": 238, "spell1Id": 4, "spell2Id": 12,
+		 "profileIconId": 1, "bot": false, "perks": {}}
+	]
+}`
+
+// TestGetActiveGame tests decoding of an in-progress game
+func TestGetActiveGame(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/spectator/v5/active-games/by-summoner/test-puuid" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(activeGameResponse))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	game, err := service.GetActiveGame(context.Background(), "na", "test-puuid")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !game.InGame || game.GameID != 5012345678 || game.QueueID != 420 || game.QueueName != "Ranked Solo/Duo" {
+		t.Errorf("Unexpected game: %+v", game)
+	}
+	if game.GameLength != 754 || game.GameStartTime == nil || !game.GameStartTime.Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("Expected game length and start time to be decoded, got %d and %v", game.GameLength, game.GameStartTime)
+	}
+
+	if len(game.Bans) != 2 || game.Bans[0] != (models.ActiveGameBan{ChampionID: 157, TeamID: 100, PickTurn: 1}) {
+		t.Errorf("Unexpected bans: %+v", game.Bans)
+	}
+
+	if len(game.Participants) != 2 {
+		t.Fatalf("Expected 2 participants, got %d", len(game.Participants))
+	}
+
+	player := game.Participants[0]
+	if player.ChampionID != 103 || player.Side != models.SideBlue || player.PerkStyle != 8100 || len(player.PerkIDs) != 2 {
+		t.Errorf("Unexpected player: %+v", player)
+	}
+
+	enemy := game.Participants[1]
+	if enemy.RiotID != "Enemy#EUW" || enemy.Side != models.SideRed || enemy.PerkIDs == nil {
+		t.Errorf("Expected red side enemy with non-nil perk list, got %+v", enemy)
+	}
+}
+
+// TestGetActiveGame_NotInGame tests that Riot's 404 is reported as a not-in-game result
+func TestGetActiveGame_NotInGame(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+		writer.Write([]byte(`{"status": {"message": "Data not found", "status_code": 404}}`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	game, err := service.GetActiveGame(context.Background(), "na", "test-puuid")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if game.InGame || game.Participants == nil || game.Bans == nil {
+		t.Errorf("Expected not-in-game result with empty lists, got %+v", game)
+	}
+
+	encoded, err := json.Marshal(game)
+	if err != nil {
+		t.Fatalf("Failed to encode game: %v", err)
+	}
+
+	if strings.Contains(string(encoded), `"gameStartTime"`) {
+		t.Errorf("Expected gameStartTime to be omitted, got %s", encoded)
+	}
+}
+
+// TestGetActiveGame_Error tests that other Riot errors are still returned
+func TestGetActiveGame_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	if _, err := service.GetActiveGame(context.Background(), "na", "test-puuid"); err == nil {
+		t.Fatal("Expected error for a forbidden response")
+	}
+}
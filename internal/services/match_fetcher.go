@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// defaultMatchFetchParallelism is the number of match details fetched concurrently by default
+const defaultMatchFetchParallelism = 5
+
+// matchDetailsFetcher retrieves the details of a single match
+type matchDetailsFetcher func(ctx context.Context, matchID string) (*models.Match, error)
+
+// matchFetchResult holds the outcome of fetching one match
+type matchFetchResult struct {
+	match *models.Match
+	err   error
+}
+
+// fetchMatchDetails fetches the details of each match ID using at most parallelism workers
+// Results keep the order of matchIDs; matches that fail to load are reported as failures
+func fetchMatchDetails(ctx context.Context, matchIDs []string, parallelism int, fetch matchDetailsFetcher) (*models.MatchHistory, error) {
+	results := make([]matchFetchResult, len(matchIDs))
+	runParallel(ctx, len(matchIDs), parallelism, func(index int) {
+		match, err := fetch(ctx, matchIDs[index])
+		results[index] = matchFetchResult{match: match, err: err}
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("match history cancelled: %w", err)
+	}
+
+	matchHistory := &models.MatchHistory{
+		Matches:  make([]models.Match, 0, len(matchIDs)),
+		Failures: make([]models.MatchFailure, 0),
+	}
+	for index, result := range results {
+		if result.err != nil {
+			log.Warn().
+				Err(result.err).
+				Str("match_id", matchIDs[index]).
+				Msg("Failed to fetch match details")
+			matchHistory.Failures = append(matchHistory.Failures, newMatchFailure(matchIDs[index], result.err))
+			continue
+		}
+		matchHistory.Matches = append(matchHistory.Matches, *result.match)
+	}
+
+	return matchHistory, nil
+}
+
+// newMatchFailure describes why a match could not be fetched with a stable reason; callers log the full error
+func newMatchFailure(matchID string, err error) models.MatchFailure {
+	failure := models.MatchFailure{
+		MatchID: matchID,
+		Reason:  FailureReason(err),
+	}
+
+	var riotAPIError *RiotAPIError
+	if errors.As(err, &riotAPIError) {
+		failure.StatusCode = riotAPIError.StatusCode
+	}
+
+	return failure
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// TestFetchMatchDetails_PreservesOrder tests that results keep match ID order despite uneven latency
+func TestFetchMatchDetails_PreservesOrder(t *testing.T) {
+	matchIDs := []string{"NA1_1", "NA1_2", "NA1_3", "NA1_4", "NA1_5", "NA1_6"}
+
+	matchHistory, err := fetchMatchDetails(context.Background(), matchIDs, 3, func(ctx context.Context, matchID string) (*models.Match, error) {
+		// Earlier matches take longer so they finish last
+		var index int
+		fmt.Sscanf(matchID, "NA1_%d", &index)
+		time.Sleep(time.Duration(len(matchIDs)-index) * 5 * time.Millisecond)
+		return &models.Match{MatchID: matchID}, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(matchHistory.Matches) != len(matchIDs) {
+		t.Fatalf("Expected %d matches, got %d", len(matchIDs), len(matchHistory.Matches))
+	}
+
+	for i, match := range matchHistory.Matches {
+		if match.MatchID != matchIDs[i] {
+			t.Errorf("Expected match %d to be '%s', got '%s'", i, matchIDs[i], match.MatchID)
+		}
+	}
+}
+
+// TestFetchMatchDetails_BoundedParallelism tests that no more than parallelism fetches run at once
+func TestFetchMatchDetails_BoundedParallelism(t *testing.T) {
+	matchIDs := make([]string, 20)
+	for i := range matchIDs {
+		matchIDs[i] = fmt.Sprintf("NA1_%d", i)
+	}
+
+	var inFlight, maxInFlight int32
+	_, err := fetchMatchDetails(context.Background(), matchIDs, 4, func(ctx context.Context, matchID string) (*models.Match, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return &models.Match{MatchID: matchID}, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if maxInFlight > 4 {
+		t.Errorf("Expected at most 4 concurrent fetches, observed %d", maxInFlight)
+	}
+}
+
+// TestFetchMatchDetails_ReportsFailures tests that failed matches are reported with their reasons
+func TestFetchMatchDetails_ReportsFailures(t *testing.T) {
+	matchIDs := []string{"NA1_1", "NA1_2", "NA1_3"}
+
+	matchHistory, err := fetchMatchDetails(context.Background(), matchIDs, 2, func(ctx context.Context, matchID string) (*models.Match, error) {
+		if matchID == "NA1_2" {
+			return nil, fmt.Errorf("failed to get match details: %w", &RiotAPIError{
+				StatusCode: http.StatusNotFound,
+				Service:    "match-v5",
+				Endpoint:   "getMatch",
+				Message:    "Data not found",
+			})
+		}
+		return &models.Match{MatchID: matchID}, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(matchHistory.Matches) != 2 || matchHistory.Matches[0].MatchID != "NA1_1" || matchHistory.Matches[1].MatchID != "NA1_3" {
+		t.Errorf("Expected NA1_1 and NA1_3, got %+v", matchHistory.Matches)
+	}
+
+	if len(matchHistory.Failures) != 1 {
+		t.Fatalf("Expected 1 failure, got %d", len(matchHistory.Failures))
+	}
+
+	failure := matchHistory.Failures[0]
+	if failure.MatchID != "NA1_2" {
+		t.Errorf("Expected failed match 'NA1_2', got '%s'", failure.MatchID)
+	}
+
+	if failure.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected failure status 404, got %d", failure.StatusCode)
+	}
+
+	if failure.Reason != FailureReasonNotFound {
+		t.Errorf("Expected failure reason '%s' without Riot's response body, got '%s'", FailureReasonNotFound, failure.Reason)
+	}
+}
+
+// TestFetchMatchDetails_NoFailures tests that Failures is an empty list rather than nil
+func TestFetchMatchDetails_NoFailures(t *testing.T) {
+	matchHistory, err := fetchMatchDetails(context.Background(), []string{}, 2, func(ctx context.Context, matchID string) (*models.Match, error) {
+		return &models.Match{MatchID: matchID}, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if matchHistory.Failures == nil {
+		t.Error("Expected empty Failures slice, got nil")
+	}
+}
+
+// TestFetchMatchDetails_Cancelled tests that remaining work is abandoned once the context is cancelled
+func TestFetchMatchDetails_Cancelled(t *testing.T) {
+	matchIDs := make([]string, 50)
+	for i := range matchIDs {
+		matchIDs[i] = fmt.Sprintf("NA1_%d", i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var fetchCount int32
+
+	_, err := fetchMatchDetails(ctx, matchIDs, 2, func(ctx context.Context, matchID string) (*models.Match, error) {
+		if atomic.AddInt32(&fetchCount, 1) == 3 {
+			cancel()
+		}
+		return &models.Match{MatchID: matchID}, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+
+	if fetchCount >= int32(len(matchIDs)) {
+		t.Errorf("Expected remaining fetches to be skipped, got %d", fetchCount)
+	}
+}
+
+// newLatencyMatchServer creates a Riot API stand-in that answers every request after a fixed delay
+func newLatencyMatchServer(matchCount int, latency time.Duration) *httptest.Server {
+	matchIDs := make([]string, matchCount)
+	for i := range matchIDs {
+		matchIDs[i] = fmt.Sprintf("NA1_%d", i)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		time.Sleep(latency)
+		writer.Header().Set("Content-Type", "application/json")
+
+		if strings.Contains(request.URL.Path, "/ids") {
+			json.NewEncoder(writer).Encode(matchIDs)
+			return
+		}
+
+		matchID := request.URL.Path[strings.LastIndex(request.URL.Path, "/")+1:]
+		json.NewEncoder(writer).Encode(map[string]interface{}{
+			"metadata": map[string]interface{}{"matchId": matchID},
+			"info":     map[string]interface{}{"participants": []map[string]interface{}{}},
+		})
+	}))
+}
+
+// benchmarkGetMatchHistory measures GetMatchHistory for 20 matches with 10ms of latency per call
+func benchmarkGetMatchHistory(b *testing.B, parallelism int) {
+	server := newLatencyMatchServer(20, 10*time.Millisecond)
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+	service.SetMatchFetchParallelism(parallelism)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := service.GetMatchHistory(context.Background(), "na", "test-puuid", MatchListOptions{Count: 20}); err != nil {
+			b.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+}
+
+// BenchmarkGetMatchHistory_Sequential fetches match details one at a time
+func BenchmarkGetMatchHistory_Sequential(b *testing.B) {
+	benchmarkGetMatchHistory(b, 1)
+}
+
+// BenchmarkGetMatchHistory_Parallel5 fetches up to 5 match details concurrently
+func BenchmarkGetMatchHistory_Parallel5(b *testing.B) {
+	benchmarkGetMatchHistory(b, 5)
+}
+
+// BenchmarkGetMatchHistory_Parallel10 fetches up to 10 match details concurrently
+func BenchmarkGetMatchHistory_Parallel10(b *testing.B) {
+	benchmarkGetMatchHistory(b, 10)
+}
+
+// TestSetMatchFetchParallelism tests that parallelism is clamped to at least one worker
+func TestSetMatchFetchParallelism(t *testing.T) {
+	service := NewRiotService("test-key")
+
+	if service.matchFetchParallelism != defaultMatchFetchParallelism {
+		t.Errorf("Expected default parallelism %d, got %d", defaultMatchFetchParallelism, service.matchFetchParallelism)
+	}
+
+	service.SetMatchFetchParallelism(0)
+	if service.matchFetchParallelism != 1 {
+		t.Errorf("Expected parallelism to be clamped to 1, got %d", service.matchFetchParallelism)
+	}
+}
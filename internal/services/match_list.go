@@ -0,0 +1,135 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Limits on match list pages imposed by match-v5
+const (
+	// Matches returned per page when no count is given
+	DefaultMatchCount = 20
+	// Largest page match-v5 will return
+	MaxMatchCount = 100
+)
+
+// Match types accepted by match-v5's type filter
+var matchTypes = map[string]bool{
+	"ranked":   true,
+	"normal":   true,
+	"tourney":  true,
+	"tutorial": true,
+}
+
+// MatchListOptions selects a page of a player's match IDs, mirroring match-v5's ids query parameters
+type MatchListOptions struct {
+	// Index of the first match to return, newest first
+	Start int `json:"start"`
+	// Number of matches to return (1 to MaxMatchCount)
+	Count int `json:"count"`
+	// Queue ID filter (e.g., 420 for ranked solo); 0 means any queue
+	Queue int `json:"queue,omitempty"`
+	// Match type filter: ranked, normal, tourney or tutorial; empty means any type
+	Type string `json:"type,omitempty"`
+	// Only matches starting at or after this epoch second; 0 means unbounded
+	StartTime int64 `json:"startTime,omitempty"`
+	// Only matches starting at or before this epoch second; 0 means unbounded
+	EndTime int64 `json:"endTime,omitempty"`
+}
+
+// InvalidMatchListOptionError reports a match list option Riot would reject
+type InvalidMatchListOptionError struct {
+	// Option name as it appears in requests (e.g., "count")
+	Field   string
+	Message string
+}
+
+// Error implements the error interface
+func (invalidOptionError *InvalidMatchListOptionError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", invalidOptionError.Field, invalidOptionError.Message)
+}
+
+// Validate checks the options against match-v5's limits
+func (options MatchListOptions) Validate() error {
+	switch {
+	case options.Start < 0:
+		return &InvalidMatchListOptionError{Field: "start", Message: "must not be negative"}
+	case options.Count < 1 || options.Count > MaxMatchCount:
+		return &InvalidMatchListOptionError{Field: "count", Message: fmt.Sprintf("must be between 1 and %d", MaxMatchCount)}
+	case options.Queue < 0:
+		return &InvalidMatchListOptionError{Field: "queue", Message: "must not be negative"}
+	case options.Type != "" && !matchTypes[options.Type]:
+		return &InvalidMatchListOptionError{Field: "type", Message: "must be one of ranked, normal, tourney or tutorial"}
+	case options.StartTime < 0:
+		return &InvalidMatchListOptionError{Field: "startTime", Message: "must not be negative"}
+	case options.EndTime < 0:
+		return &InvalidMatchListOptionError{Field: "endTime", Message: "must not be negative"}
+	case options.EndTime != 0 && options.EndTime < options.StartTime:
+		return &InvalidMatchListOptionError{Field: "endTime", Message: "must not be before startTime"}
+	}
+	return nil
+}
+
+// query encodes the options as match-v5 ids query parameters
+func (options MatchListOptions) query() string {
+	query := url.Values{}
+	query.Set("start", strconv.Itoa(options.Start))
+	query.Set("count", strconv.Itoa(options.Count))
+	if options.Queue != 0 {
+		query.Set("queue", strconv.Itoa(options.Queue))
+	}
+	if options.Type != "" {
+		query.Set("type", options.Type)
+	}
+	if options.StartTime != 0 {
+		query.Set("startTime", strconv.FormatInt(options.StartTime, 10))
+	}
+	if options.EndTime != 0 {
+		query.Set("endTime", strconv.FormatInt(options.EndTime, 10))
+	}
+	return query.Encode()
+}
+
+// nextMatchCursor returns the cursor for the page after one that returned matchCount IDs
+// A short page means there are no more matches, so no cursor is returned
+func nextMatchCursor(options MatchListOptions, matchCount int) string {
+	if matchCount < options.Count {
+		return ""
+	}
+
+	options.Start += options.Count
+	return EncodeMatchCursor(options)
+}
+
+// EncodeMatchCursor encodes match list options as an opaque cursor
+func EncodeMatchCursor(options MatchListOptions) string {
+	data, _ := json.Marshal(options)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeMatchCursor decodes a cursor produced by EncodeMatchCursor
+func DecodeMatchCursor(cursor string) (MatchListOptions, error) {
+	invalidCursor := &InvalidMatchListOptionError{Field: "cursor", Message: "malformed cursor"}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return MatchListOptions{}, invalidCursor
+	}
+
+	var options MatchListOptions
+	if err := json.Unmarshal(data, &options); err != nil {
+		return MatchListOptions{}, invalidCursor
+	}
+
+	// A tampered cursor must not smuggle options Riot would reject
+	var invalidOptionError *InvalidMatchListOptionError
+	if err := options.Validate(); errors.As(err, &invalidOptionError) {
+		return MatchListOptions{}, invalidCursor
+	}
+
+	return options, nil
+}
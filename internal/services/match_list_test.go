@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestMatchListOptions_Query tests encoding options as match-v5 query parameters
+func TestMatchListOptions_Query(t *testing.T) {
+	options := MatchListOptions{Start: 100, Count: 50, Queue: 420, Type: "ranked", StartTime: 1700000000, EndTime: 1710000000}
+
+	expected := "count=50&endTime=1710000000&queue=420&start=100&startTime=1700000000&type=ranked"
+	if query := options.query(); query != expected {
+		t.Errorf("Expected query '%s', got '%s'", expected, query)
+	}
+
+	if query := (MatchListOptions{Count: 20}).query(); query != "count=20&start=0" {
+		t.Errorf("Expected unset filters to be omitted, got '%s'", query)
+	}
+}
+
+// TestMatchListOptions_Validate tests rejection of options match-v5 does not accept
+func TestMatchListOptions_Validate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		options       MatchListOptions
+		expectedField string
+	}{
+		{"valid", MatchListOptions{Count: 100, Queue: 420, Type: "normal", StartTime: 1, EndTime: 2}, ""},
+		{"negative start", MatchListOptions{Start: -1, Count: 20}, "start"},
+		{"zero count", MatchListOptions{Count: 0}, "count"},
+		{"count too large", MatchListOptions{Count: 101}, "count"},
+		{"unknown type", MatchListOptions{Count: 20, Type: "arena"}, "type"},
+		{"end before start", MatchListOptions{Count: 20, StartTime: 200, EndTime: 100}, "endTime"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.options.Validate()
+			if testCase.expectedField == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+
+			var invalidOptionError *InvalidMatchListOptionError
+			if !errors.As(err, &invalidOptionError) || invalidOptionError.Field != testCase.expectedField {
+				t.Errorf("Expected invalid '%s', got: %v", testCase.expectedField, err)
+			}
+		})
+	}
+}
+
+// TestMatchCursor_RoundTrip tests that cursors carry every option
+func TestMatchCursor_RoundTrip(t *testing.T) {
+	options := MatchListOptions{Start: 40, Count: 20, Queue: 440, Type: "ranked", StartTime: 1700000000, EndTime: 1710000000}
+
+	decoded, err := DecodeMatchCursor(EncodeMatchCursor(options))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if decoded != options {
+		t.Errorf("Expected %+v, got %+v", options, decoded)
+	}
+}
+
+// TestDecodeMatchCursor_Invalid tests rejection of malformed or tampered cursors
+func TestDecodeMatchCursor_Invalid(t *testing.T) {
+	tampered, _ := json.Marshal(MatchListOptions{Count: 1000})
+
+	for _, cursor := range []string{"not a cursor!", base64.RawURLEncoding.EncodeToString([]byte("{")), base64.RawURLEncoding.EncodeToString(tampered)} {
+		var invalidOptionError *InvalidMatchListOptionError
+		if _, err := DecodeMatchCursor(cursor); !errors.As(err, &invalidOptionError) || invalidOptionError.Field != "cursor" {
+			t.Errorf("Expected invalid cursor for '%s', got: %v", cursor, err)
+		}
+	}
+}
+
+// TestNextMatchCursor tests that only full pages produce a cursor
+func TestNextMatchCursor(t *testing.T) {
+	options := MatchListOptions{Start: 20, Count: 20, Queue: 420}
+
+	if cursor := nextMatchCursor(options, 19); cursor != "" {
+		t.Errorf("Expected no cursor after a short page, got '%s'", cursor)
+	}
+
+	nextOptions, err := DecodeMatchCursor(nextMatchCursor(options, 20))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if nextOptions.Start != 40 || nextOptions.Count != 20 || nextOptions.Queue != 420 {
+		t.Errorf("Expected next page at start 40 keeping filters, got %+v", nextOptions)
+	}
+}
+
+// TestGetMatchHistory_FiltersAndCursor tests that filters reach Riot and a full page returns a cursor
+func TestGetMatchHistory_FiltersAndCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if strings.HasSuffix(request.URL.Path, "/ids") {
+			query := request.URL.Query()
+			if query.Get("start") != "20" || query.Get("count") != "2" || query.Get("queue") != "420" || query.Get("type") != "ranked" || query.Get("startTime") != "1700000000" {
+				t.Errorf("Unexpected match list query: %s", request.URL.RawQuery)
+			}
+			json.NewEncoder(writer).Encode([]string{"NA1_1", "NA1_2"})
+			return
+		}
+		json.NewEncoder(writer).Encode(map[string]interface{}{"metadata": map[string]string{"matchId": "NA1_1"}})
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	matchHistory, err := service.GetMatchHistory(context.Background(), "na", "test-puuid", MatchListOptions{
+		Start:     20,
+		Count:     2,
+		Queue:     420,
+		Type:      "ranked",
+		StartTime: 1700000000,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	nextOptions, err := DecodeMatchCursor(matchHistory.NextCursor)
+	if err != nil {
+		t.Fatalf("Expected a valid next cursor, got: %v", err)
+	}
+
+	if nextOptions.Start != 22 || nextOptions.Type != "ranked" {
+		t.Errorf("Expected next page at start 22 keeping filters, got %+v", nextOptions)
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import "github.com/OPGLOL/opgl-data-service/internal/models"
+
+// Rune style descriptions used by match-v5 to tell the primary and secondary paths apart
+const (
+	primaryPerkStyle = "primaryStyle"
+	subPerkStyle     = "subStyle"
+)
+
+// rawPerks is a participant's rune page as returned by match-v5
+type rawPerks struct {
+	StatPerks struct {
+		Defense int `json:"defense"`
+		Flex    int `json:"flex"`
+		Offense int `json:"offense"`
+	} `json:"statPerks"`
+	Styles []struct {
+		Description string `json:"description"`
+		Style       int    `json:"style"`
+		Selections  []struct {
+			Perk int `json:"perk"`
+		} `json:"selections"`
+	} `json:"styles"`
+}
+
+// rawParticipant is a match-v5 participant, limited to the fields we expose
+type rawParticipant struct {
+	ParticipantID                  int      `json:"participantId"`
+	TeamID                         int      `json:"teamId"`
+	PUUID                          string   `json:"puuid"`
+	SummonerName                   string   `json:"summonerName"`
+	RiotIDGameName                 string   `json:"riotIdGameName"`
+	RiotIDTagline                  string   `json:"riotIdTagline"`
+	ChampionID                     int      `json:"championId"`
+	ChampionName                   string   `json:"championName"`
+	ChampLevel                     int      `json:"champLevel"`
+	Kills                          int      `json:"kills"`
+	Deaths                         int      `json:"deaths"`
+	Assists                        int      `json:"assists"`
+	GoldEarned                     int      `json:"goldEarned"`
+	TotalDamageDealtToChampions    int      `json:"totalDamageDealtToChampions"`
+	PhysicalDamageDealtToChampions int      `json:"physicalDamageDealtToChampions"`
+	MagicDamageDealtToChampions    int      `json:"magicDamageDealtToChampions"`
+	TrueDamageDealtToChampions     int      `json:"trueDamageDealtToChampions"`
+	TotalDamageDealt               int      `json:"totalDamageDealt"`
+	TotalDamageTaken               int      `json:"totalDamageTaken"`
+	DamageDealtToBuildings         int      `json:"damageDealtToBuildings"`
+	DamageDealtToObjectives        int      `json:"damageDealtToObjectives"`
+	DamageSelfMitigated            int      `json:"damageSelfMitigated"`
+	TotalHeal                      int      `json:"totalHeal"`
+	TotalDamageShieldedOnTeammates int      `json:"totalDamageShieldedOnTeammates"`
+	VisionScore                    int      `json:"visionScore"`
+	WardsPlaced                    int      `json:"wardsPlaced"`
+	WardsKilled                    int      `json:"wardsKilled"`
+	VisionWardsBoughtInGame        int      `json:"visionWardsBoughtInGame"`
+	DetectorWardsPlaced            int      `json:"detectorWardsPlaced"`
+	TotalMinionsKilled             int      `json:"totalMinionsKilled"`
+	NeutralMinionsKilled           int      `json:"neutralMinionsKilled"`
+	TurretKills                    int      `json:"turretKills"`
+	InhibitorKills                 int      `json:"inhibitorKills"`
+	DragonKills                    int      `json:"dragonKills"`
+	BaronKills                     int      `json:"baronKills"`
+	ObjectivesStolen               int      `json:"objectivesStolen"`
+	DoubleKills                    int      `json:"doubleKills"`
+	TripleKills                    int      `json:"tripleKills"`
+	QuadraKills                    int      `json:"quadraKills"`
+	PentaKills                     int      `json:"pentaKills"`
+	LargestMultiKill               int      `json:"largestMultiKill"`
+	LargestKillingSpree            int      `json:"largestKillingSpree"`
+	Win                            bool     `json:"win"`
+	TeamPosition                   string   `json:"teamPosition"`
+	Item0                          int      `json:"item0"`
+	Item1                          int      `json:"item1"`
+	Item2                          int      `json:"item2"`
+	Item3                          int      `json:"item3"`
+	Item4                          int      `json:"item4"`
+	Item5                          int      `json:"item5"`
+	Item6                          int      `json:"item6"`
+	Summoner1ID                    int      `json:"summoner1Id"`
+	Summoner2ID                    int      `json:"summoner2Id"`
+	Perks                          rawPerks `json:"perks"`
+	AllInPings                     int      `json:"allInPings"`
+	AssistMePings                  int      `json:"assistMePings"`
+	BasicPings                     int      `json:"basicPings"`
+	CommandPings                   int      `json:"commandPings"`
+	DangerPings                    int      `json:"dangerPings"`
+	EnemyMissingPings              int      `json:"enemyMissingPings"`
+	EnemyVisionPings               int      `json:"enemyVisionPings"`
+	GetBackPings                   int      `json:"getBackPings"`
+	HoldPings                      int      `json:"holdPings"`
+	NeedVisionPings                int      `json:"needVisionPings"`
+	OnMyWayPings                   int      `json:"onMyWayPings"`
+	PushPings                      int      `json:"pushPings"`
+	VisionClearedPings             int      `json:"visionClearedPings"`
+}
+
+// convertParticipant converts a raw match-v5 participant into our model
+func convertParticipant(participant rawParticipant) models.Participant {
+	return models.Participant{
+		ParticipantID:               participant.ParticipantID,
+		TeamID:                      participant.TeamID,
+		PUUID:                       participant.PUUID,
+		SummonerName:                participant.SummonerName,
+		RiotIDGameName:              participant.RiotIDGameName,
+		RiotIDTagline:               participant.RiotIDTagline,
+		ChampionID:                  participant.ChampionID,
+		ChampionName:                participant.ChampionName,
+		Kills:                       participant.Kills,
+		Deaths:                      participant.Deaths,
+		Assists:                     participant.Assists,
+		GoldEarned:                  participant.GoldEarned,
+		TotalDamageDealtToChampions: participant.TotalDamageDealtToChampions,
+		TotalDamageTaken:            participant.TotalDamageTaken,
+		VisionScore:                 participant.VisionScore,
+		TotalMinionsKilled:          participant.TotalMinionsKilled,
+		Win:                         participant.Win,
+		TeamPosition:                participant.TeamPosition,
+		ChampLevel:                  participant.ChampLevel,
+		Items: models.ParticipantItems{
+			Slots:   []int{participant.Item0, participant.Item1, participant.Item2, participant.Item3, participant.Item4, participant.Item5},
+			Trinket: participant.Item6,
+		},
+		Summoner1ID:                    participant.Summoner1ID,
+		Summoner2ID:                    participant.Summoner2ID,
+		Perks:                          convertPerks(participant.Perks),
+		NeutralMinionsKilled:           participant.NeutralMinionsKilled,
+		WardsPlaced:                    participant.WardsPlaced,
+		WardsKilled:                    participant.WardsKilled,
+		VisionWardsBoughtInGame:        participant.VisionWardsBoughtInGame,
+		DetectorWardsPlaced:            participant.DetectorWardsPlaced,
+		PhysicalDamageDealtToChampions: participant.PhysicalDamageDealtToChampions,
+		MagicDamageDealtToChampions:    participant.MagicDamageDealtToChampions,
+		TrueDamageDealtToChampions:     participant.TrueDamageDealtToChampions,
+		TotalDamageDealt:               participant.TotalDamageDealt,
+		DamageDealtToBuildings:         participant.DamageDealtToBuildings,
+		DamageDealtToObjectives:        participant.DamageDealtToObjectives,
+		DamageSelfMitigated:            participant.DamageSelfMitigated,
+		TotalHeal:                      participant.TotalHeal,
+		TotalDamageShieldedOnTeammates: participant.TotalDamageShieldedOnTeammates,
+		TurretKills:                    participant.TurretKills,
+		InhibitorKills:                 participant.InhibitorKills,
+		DragonKills:                    participant.DragonKills,
+		BaronKills:                     participant.BaronKills,
+		ObjectivesStolen:               participant.ObjectivesStolen,
+		DoubleKills:                    participant.DoubleKills,
+		TripleKills:                    participant.TripleKills,
+		QuadraKills:                    participant.QuadraKills,
+		PentaKills:                     participant.PentaKills,
+		LargestMultiKill:               participant.LargestMultiKill,
+		LargestKillingSpree:            participant.LargestKillingSpree,
+		Pings: models.ParticipantPings{
+			AllIn:         participant.AllInPings,
+			AssistMe:      participant.AssistMePings,
+			Basic:         participant.BasicPings,
+			Command:       participant.CommandPings,
+			Danger:        participant.DangerPings,
+			EnemyMissing:  participant.EnemyMissingPings,
+			EnemyVision:   participant.EnemyVisionPings,
+			GetBack:       participant.GetBackPings,
+			Hold:          participant.HoldPings,
+			NeedVision:    participant.NeedVisionPings,
+			OnMyWay:       participant.OnMyWayPings,
+			Push:          participant.PushPings,
+			VisionCleared: participant.VisionClearedPings,
+		},
+	}
+}
+
+// convertPerks flattens a raw rune page into rune IDs per path
+func convertPerks(rawPage rawPerks) models.Perks {
+	perks := models.Perks{
+		PrimaryRunes: []int{},
+		SubRunes:     []int{},
+		StatPerks: models.StatPerks{
+			Offense: rawPage.StatPerks.Offense,
+			Flex:    rawPage.StatPerks.Flex,
+			Defense: rawPage.StatPerks.Defense,
+		},
+	}
+
+	for _, style := range rawPage.Styles {
+		runes := make([]int, len(style.Selections))
+		for i, selection := range style.Selections {
+			runes[i] = selection.Perk
+		}
+
+		switch style.Description {
+		case primaryPerkStyle:
+			perks.PrimaryStyle = style.Style
+			perks.PrimaryRunes = runes
+		case subPerkStyle:
+			perks.SubStyle = style.Style
+			perks.SubRunes = runes
+		}
+	}
+
+	return perks
+}
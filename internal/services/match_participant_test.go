@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// participantResponse is a match-v5 match with one fully populated participant
+const participantResponse = `{
+	"metadata": {"matchId": "NA1_123"},
+	"info": {
+		"gameCreation": 1700000000000,
+		"gameDuration": 1800,
+		"gameMode": "CLASSIC",
+		"gameType": "MATCHED_GAME",
+		"participants": [{
+			"participantId": 4, "teamId": 100, "puuid": "test-puuid", "summonerName": "TestPlayer",
+			"championId": 103, "championName": "Ahri", "champLevel": 16,
+			"kills": 10, "deaths": 5, "assists": 15, "goldEarned": 15000,
+			"totalDamageDealtToChampions": 25000, "physicalDamageDealtToChampions": 2000,
+			"magicDamageDealtToChampions": 21000, "trueDamageDealtToChampions": 2000,
+			"totalDamageDealt": 150000, "totalDamageTaken": 18000, "damageDealtToBuildings": 3000,
+			"damageDealtToObjectives": 5000, "damageSelfMitigated": 9000, "totalHeal": 4000,
+			"totalDamageShieldedOnTeammates": 500,
+			"visionScore": 30, "wardsPlaced": 12, "wardsKilled": 4, "visionWardsBoughtInGame": 3, "detectorWardsPlaced": 2,
+			"totalMinionsKilled": 180, "neutralMinionsKilled": 12,
+			"turretKills": 2, "inhibitorKills": 1, "dragonKills": 1, "baronKills": 0, "objectivesStolen": 1,
+			"doubleKills": 2, "tripleKills": 1, "quadraKills": 0, "pentaKills": 0,
+			"largestMultiKill": 3, "largestKillingSpree": 6,
+			"win": true, "teamPosition": "MIDDLE",
+			"item0": 6655, "item1": 3020, "item2": 0, "item3": 4645, "item4": 3089, "item5": 3157, "item6": 3340,
+			"summoner1Id": 4, "summoner2Id": 14,
+			"perks": {
+				"statPerks": {"defense": 5001, "flex": 5008, "offense": 5005},
+				"styles": [
+					{"description": "primaryStyle", "style": 8100, "selections": [{"perk": 8112, "var1": 1200}, {"perk": 8139}, {"perk": 8138}, {"perk": 8135}]},
+					{"description": "subStyle", "style": 8200, "selections": [{"perk": 8226}, {"perk": 8210}]}
+				]
+			},
+			"allInPings": 1, "assistMePings": 2, "basicPings": 3, "commandPings": 4, "dangerPings": 5,
+			"enemyMissingPings": 6, "enemyVisionPings": 7, "getBackPings": 8, "holdPings": 9,
+			"needVisionPings": 10, "onMyWayPings": 11, "pushPings": 12, "visionClearedPings": 13
+		}]
+	}
+}`
+
+// TestGetMatchDetails_FullParticipant tests decoding of every exposed participant field
+func TestGetMatchDetails_FullParticipant(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(participantResponse))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	match, err := service.GetMatchDetails(context.Background(), "na", "NA1_123")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := models.Participant{
+		ParticipantID:                  4,
+		TeamID:                         100,
+		PUUID:                          "test-puuid",
+		SummonerName:                   "TestPlayer",
+		ChampionID:                     103,
+		ChampionName:                   "Ahri",
+		Kills:                          10,
+		Deaths:                         5,
+		Assists:                        15,
+		GoldEarned:                     15000,
+		TotalDamageDealtToChampions:    25000,
+		TotalDamageTaken:               18000,
+		VisionScore:                    30,
+		TotalMinionsKilled:             180,
+		Win:                            true,
+		TeamPosition:                   "MIDDLE",
+		ChampLevel:                     16,
+		Items:                          models.ParticipantItems{Slots: []int{6655, 3020, 0, 4645, 3089, 3157}, Trinket: 3340},
+		Summoner1ID:                    4,
+		Summoner2ID:                    14,
+		NeutralMinionsKilled:           12,
+		WardsPlaced:                    12,
+		WardsKilled:                    4,
+		VisionWardsBoughtInGame:        3,
+		DetectorWardsPlaced:            2,
+		PhysicalDamageDealtToChampions: 2000,
+		MagicDamageDealtToChampions:    21000,
+		TrueDamageDealtToChampions:     2000,
+		TotalDamageDealt:               150000,
+		DamageDealtToBuildings:         3000,
+		DamageDealtToObjectives:        5000,
+		DamageSelfMitigated:            9000,
+		TotalHeal:                      4000,
+		TotalDamageShieldedOnTeammates: 500,
+		TurretKills:                    2,
+		InhibitorKills:                 1,
+		DragonKills:                    1,
+		ObjectivesStolen:               1,
+		DoubleKills:                    2,
+		TripleKills:                    1,
+		LargestMultiKill:               3,
+		LargestKillingSpree:            6,
+		Perks: models.Perks{
+			PrimaryStyle: 8100,
+			PrimaryRunes: []int{8112, 8139, 8138, 8135},
+			SubStyle:     8200,
+			SubRunes:     []int{8226, 8210},
+			StatPerks:    models.StatPerks{Offense: 5005, Flex: 5008, Defense: 5001},
+		},
+		Pings: models.ParticipantPings{
+			AllIn: 1, AssistMe: 2, Basic: 3, Command: 4, Danger: 5, EnemyMissing: 6, EnemyVision: 7,
+			GetBack: 8, Hold: 9, NeedVision: 10, OnMyWay: 11, Push: 12, VisionCleared: 13,
+		},
+	}
+
+	if !reflect.DeepEqual(match.Participants[0], expected) {
+		t.Errorf("Expected participant %+v, got %+v", expected, match.Participants[0])
+	}
+}
+
+// TestParticipant_BackwardCompatibleJSON tests that the original participant JSON fields are unchanged
+func TestParticipant_BackwardCompatibleJSON(t *testing.T) {
+	data, err := json.Marshal(models.Participant{PUUID: "test-puuid", ChampionName: "Ahri", Kills: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+
+	originalFields := []string{
+		"puuid", "summonerName", "championId", "championName", "kills", "deaths", "assists", "goldEarned",
+		"totalDamageDealtToChampions", "totalDamageTaken", "visionScore", "totalMinionsKilled", "win", "teamPosition",
+	}
+	for _, field := range originalFields {
+		if _, exists := fields[field]; !exists {
+			t.Errorf("Expected original field '%s' in participant JSON", field)
+		}
+	}
+
+	if fields["championName"] != "Ahri" || fields["kills"] != float64(10) {
+		t.Errorf("Expected original field values to be preserved, got %v", fields)
+	}
+}
+
+// TestConvertPerks_Empty tests that a participant without runes encodes empty rune lists
+func TestConvertPerks_Empty(t *testing.T) {
+	perks := convertPerks(rawPerks{})
+
+	if perks.PrimaryRunes == nil || perks.SubRunes == nil {
+		t.Error("Expected non-nil rune lists so they encode as []")
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import "github.com/OPGLOL/opgl-data-service/internal/models"
+
+// rawObjective is a team's result for one objective type as returned by match-v5
+type rawObjective struct {
+	First bool `json:"first"`
+	Kills int  `json:"kills"`
+}
+
+// rawTeam is a match-v5 team
+type rawTeam struct {
+	TeamID int  `json:"teamId"`
+	Win    bool `json:"win"`
+	Bans   []struct {
+		ChampionID int `json:"championId"`
+		PickTurn   int `json:"pickTurn"`
+	} `json:"bans"`
+	Objectives struct {
+		Baron      rawObjective `json:"baron"`
+		Champion   rawObjective `json:"champion"`
+		Dragon     rawObjective `json:"dragon"`
+		Horde      rawObjective `json:"horde"`
+		Inhibitor  rawObjective `json:"inhibitor"`
+		RiftHerald rawObjective `json:"riftHerald"`
+		Tower      rawObjective `json:"tower"`
+	} `json:"objectives"`
+}
+
+// convertTeam converts a raw match-v5 team into our model
+func convertTeam(team rawTeam) models.Team {
+	bans := make([]models.Ban, len(team.Bans))
+	for i, ban := range team.Bans {
+		bans[i] = models.Ban{ChampionID: ban.ChampionID, PickTurn: ban.PickTurn}
+	}
+
+	return models.Team{
+		TeamID: team.TeamID,
+		Side:   teamSide(team.TeamID),
+		Win:    team.Win,
+		Bans:   bans,
+		Objectives: models.TeamObjectives{
+			Baron:      models.Objective(team.Objectives.Baron),
+			Champion:   models.Objective(team.Objectives.Champion),
+			Dragon:     models.Objective(team.Objectives.Dragon),
+			Horde:      models.Objective(team.Objectives.Horde),
+			Inhibitor:  models.Objective(team.Objectives.Inhibitor),
+			RiftHerald: models.Objective(team.Objectives.RiftHerald),
+			Tower:      models.Objective(team.Objectives.Tower),
+		},
+	}
+}
+
+// teamSide returns the map side of a team ID, or "" for IDs outside Summoner's Rift's two teams
+// (e.g., Arena's subteams)
+func teamSide(teamID int) string {
+	switch teamID {
+	case models.TeamIDBlue:
+		return models.SideBlue
+	case models.TeamIDRed:
+		return models.SideRed
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// teamsResponse is a match-v5 match with both teams and one participant per team
+const teamsResponse = `{
+	"metadata": {"matchId": "NA1_123"},
+	"info": {
+		"participants": [
+			{"participantId": 1, "teamId": 100, "puuid": "blue-puuid", "win": false},
+			{"participantId": 6, "teamId": 200, "puuid": "red-puuid", "win": true}
+		],
+		"teams": [
+			{
+				"teamId": 100,
+				"win": false,
+				"bans": [{"championId": 157, "pickTurn": 1}, {"championId": -1, "pickTurn": 2}],
+				"objectives": {
+					"baron": {"first": false, "kills": 0},
+					"champion": {"first": true, "kills": 18},
+					"dragon": {"first": true, "kills": 2},
+					"horde": {"first": false, "kills": 2},
+					"inhibitor": {"first": false, "kills": 0},
+					"riftHerald": {"first": false, "kills": 0},
+					"tower": {"first": false, "kills": 3}
+				}
+			},
+			{
+				"teamId": 200,
+				"win": true,
+				"bans": [{"championId": 238, "pickTurn": 6}],
+				"objectives": {
+					"baron": {"first": true, "kills": 1},
+					"champion": {"first": false, "kills": 25},
+					"dragon": {"first": false, "kills": 3},
+					"horde": {"first": true, "kills": 4},
+					"inhibitor": {"first": true, "kills": 2},
+					"riftHerald": {"first": true, "kills": 1},
+					"tower": {"first": true, "kills": 9}
+				}
+			}
+		]
+	}
+}`
+
+// TestGetMatchDetails_Teams tests decoding of team bans, objectives, result and side
+func TestGetMatchDetails_Teams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(teamsResponse))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	match, err := service.GetMatchDetails(context.Background(), "na", "NA1_123")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(match.Teams) != 2 {
+		t.Fatalf("Expected 2 teams, got %d", len(match.Teams))
+	}
+
+	expectedRed := models.Team{
+		TeamID: 200,
+		Side:   models.SideRed,
+		Win:    true,
+		Bans:   []models.Ban{{ChampionID: 238, PickTurn: 6}},
+		Objectives: models.TeamObjectives{
+			Baron:      models.Objective{First: true, Kills: 1},
+			Champion:   models.Objective{Kills: 25},
+			Dragon:     models.Objective{Kills: 3},
+			Horde:      models.Objective{First: true, Kills: 4},
+			Inhibitor:  models.Objective{First: true, Kills: 2},
+			RiftHerald: models.Objective{First: true, Kills: 1},
+			Tower:      models.Objective{First: true, Kills: 9},
+		},
+	}
+	if !reflect.DeepEqual(match.Teams[1], expectedRed) {
+		t.Errorf("Expected red team %+v, got %+v", expectedRed, match.Teams[1])
+	}
+
+	blueTeam := match.Teams[0]
+	if blueTeam.Side != models.SideBlue || blueTeam.Win || len(blueTeam.Bans) != 2 || blueTeam.Bans[1].ChampionID != -1 {
+		t.Errorf("Expected losing blue team with a skipped ban, got %+v", blueTeam)
+	}
+
+	// Participants link to their team by ID
+	for _, participant := range match.Participants {
+		var team *models.Team
+		for i := range match.Teams {
+			if match.Teams[i].TeamID == participant.TeamID {
+				team = &match.Teams[i]
+			}
+		}
+		if team == nil || team.Win != participant.Win {
+			t.Errorf("Expected participant %s to link to a team with the same result", participant.PUUID)
+		}
+	}
+}
+
+// TestTeamSide tests the mapping from team ID to map side
+func TestTeamSide(t *testing.T) {
+	testCases := map[int]string{100: "blue", 200: "red", 0: "", 300: ""}
+
+	for teamID, expectedSide := range testCases {
+		if side := teamSide(teamID); side != expectedSide {
+			t.Errorf("Expected side '%s' for team %d, got '%s'", expectedSide, teamID, side)
+		}
+	}
+}
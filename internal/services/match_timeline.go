@@ -0,0 +1,191 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// rawPosition is a map position as returned by match-v5
+type rawPosition struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// rawTimelineEvent holds the fields of every decoded event type; Riot sends them flattened
+type rawTimelineEvent struct {
+	Type                    string      `json:"type"`
+	Timestamp               int64       `json:"timestamp"`
+	ParticipantID           int         `json:"participantId"`
+	KillerID                int         `json:"killerId"`
+	KillerTeamID            int         `json:"killerTeamId"`
+	VictimID                int         `json:"victimId"`
+	CreatorID               int         `json:"creatorId"`
+	TeamID                  int         `json:"teamId"`
+	AssistingParticipantIDs []int       `json:"assistingParticipantIds"`
+	Position                rawPosition `json:"position"`
+	Bounty                  int         `json:"bounty"`
+	ShutdownBounty          int         `json:"shutdownBounty"`
+	KillStreakLength        int         `json:"killStreakLength"`
+	ItemID                  int         `json:"itemId"`
+	MonsterType             string      `json:"monsterType"`
+	MonsterSubType          string      `json:"monsterSubType"`
+	BuildingType            string      `json:"buildingType"`
+	LaneType                string      `json:"laneType"`
+	TowerType               string      `json:"towerType"`
+	WardType                string      `json:"wardType"`
+	SkillSlot               int         `json:"skillSlot"`
+	LevelUpType             string      `json:"levelUpType"`
+}
+
+// rawMatchTimeline is the match-v5 timeline response
+type rawMatchTimeline struct {
+	Metadata struct {
+		MatchID string `json:"matchId"`
+	} `json:"metadata"`
+	Info struct {
+		FrameInterval int64 `json:"frameInterval"`
+		Participants  []struct {
+			ParticipantID int    `json:"participantId"`
+			PUUID         string `json:"puuid"`
+		} `json:"participants"`
+		Frames []struct {
+			Timestamp int64 `json:"timestamp"`
+			// Keyed by participant ID as a string ("1" to "10")
+			ParticipantFrames map[string]struct {
+				ParticipantID       int         `json:"participantId"`
+				Level               int         `json:"level"`
+				XP                  int         `json:"xp"`
+				CurrentGold         int         `json:"currentGold"`
+				TotalGold           int         `json:"totalGold"`
+				MinionsKilled       int         `json:"minionsKilled"`
+				JungleMinionsKilled int         `json:"jungleMinionsKilled"`
+				Position            rawPosition `json:"position"`
+			} `json:"participantFrames"`
+			Events []rawTimelineEvent `json:"events"`
+		} `json:"frames"`
+	} `json:"info"`
+}
+
+// convertMatchTimeline converts a raw match-v5 timeline into our model
+func convertMatchTimeline(rawTimeline *rawMatchTimeline) *models.MatchTimeline {
+	timeline := &models.MatchTimeline{
+		MatchID:       rawTimeline.Metadata.MatchID,
+		FrameInterval: rawTimeline.Info.FrameInterval,
+		Participants:  make([]models.TimelineParticipant, len(rawTimeline.Info.Participants)),
+		Frames:        make([]models.TimelineFrame, len(rawTimeline.Info.Frames)),
+	}
+
+	for i, participant := range rawTimeline.Info.Participants {
+		timeline.Participants[i] = models.TimelineParticipant{
+			ParticipantID: participant.ParticipantID,
+			PUUID:         participant.PUUID,
+		}
+	}
+
+	for i, rawFrame := range rawTimeline.Info.Frames {
+		frame := models.TimelineFrame{
+			Timestamp:         rawFrame.Timestamp,
+			ParticipantFrames: make([]models.ParticipantFrame, 0, len(rawFrame.ParticipantFrames)),
+			Events:            make([]models.TimelineEvent, len(rawFrame.Events)),
+		}
+
+		for key, participantFrame := range rawFrame.ParticipantFrames {
+			// Older timelines omit participantId inside the frame and only key by it
+			participantID := participantFrame.ParticipantID
+			if participantID == 0 {
+				participantID, _ = strconv.Atoi(key)
+			}
+
+			frame.ParticipantFrames = append(frame.ParticipantFrames, models.ParticipantFrame{
+				ParticipantID:       participantID,
+				Level:               participantFrame.Level,
+				XP:                  participantFrame.XP,
+				CurrentGold:         participantFrame.CurrentGold,
+				TotalGold:           participantFrame.TotalGold,
+				MinionsKilled:       participantFrame.MinionsKilled,
+				JungleMinionsKilled: participantFrame.JungleMinionsKilled,
+				Position:            models.Position(participantFrame.Position),
+			})
+		}
+		// Map iteration order is random, so order frames the way clients index them
+		sort.Slice(frame.ParticipantFrames, func(a, b int) bool {
+			return frame.ParticipantFrames[a].ParticipantID < frame.ParticipantFrames[b].ParticipantID
+		})
+
+		for j, rawEvent := range rawFrame.Events {
+			frame.Events[j] = convertTimelineEvent(rawEvent)
+		}
+
+		timeline.Frames[i] = frame
+	}
+
+	return timeline
+}
+
+// convertTimelineEvent decodes the payload of the event types we support
+func convertTimelineEvent(rawEvent rawTimelineEvent) models.TimelineEvent {
+	event := models.TimelineEvent{
+		Type:      rawEvent.Type,
+		Timestamp: rawEvent.Timestamp,
+	}
+	position := models.Position(rawEvent.Position)
+
+	switch rawEvent.Type {
+	case models.EventTypeChampionKill:
+		event.ChampionKill = &models.ChampionKillEvent{
+			KillerID:                rawEvent.KillerID,
+			VictimID:                rawEvent.VictimID,
+			AssistingParticipantIDs: nonNilIDs(rawEvent.AssistingParticipantIDs),
+			Position:                position,
+			Bounty:                  rawEvent.Bounty,
+			ShutdownBounty:          rawEvent.ShutdownBounty,
+			KillStreakLength:        rawEvent.KillStreakLength,
+		}
+	case models.EventTypeItemPurchased:
+		event.ItemPurchased = &models.ItemPurchasedEvent{
+			ParticipantID: rawEvent.ParticipantID,
+			ItemID:        rawEvent.ItemID,
+		}
+	case models.EventTypeEliteMonsterKill:
+		event.EliteMonsterKill = &models.EliteMonsterKillEvent{
+			KillerID:       rawEvent.KillerID,
+			KillerTeamID:   rawEvent.KillerTeamID,
+			MonsterType:    rawEvent.MonsterType,
+			MonsterSubType: rawEvent.MonsterSubType,
+			Position:       position,
+		}
+	case models.EventTypeBuildingKill:
+		event.BuildingKill = &models.BuildingKillEvent{
+			KillerID:                rawEvent.KillerID,
+			TeamID:                  rawEvent.TeamID,
+			BuildingType:            rawEvent.BuildingType,
+			LaneType:                rawEvent.LaneType,
+			TowerType:               rawEvent.TowerType,
+			AssistingParticipantIDs: nonNilIDs(rawEvent.AssistingParticipantIDs),
+			Position:                position,
+		}
+	case models.EventTypeWardPlaced:
+		event.WardPlaced = &models.WardPlacedEvent{
+			CreatorID: rawEvent.CreatorID,
+			WardType:  rawEvent.WardType,
+		}
+	case models.EventTypeSkillLevelUp:
+		event.SkillLevelUp = &models.SkillLevelUpEvent{
+			ParticipantID: rawEvent.ParticipantID,
+			SkillSlot:     rawEvent.SkillSlot,
+			LevelUpType:   rawEvent.LevelUpType,
+		}
+	}
+
+	return event
+}
+
+// nonNilIDs returns ids, or an empty slice so solo kills encode as [] rather than null
+func nonNilIDs(ids []int) []int {
+	if ids == nil {
+		return []int{}
+	}
+	return ids
+}
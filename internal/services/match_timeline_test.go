@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// timelineResponse is a trimmed match-v5 timeline with one of each decoded event type
+const timelineResponse = `{
+	"metadata": {"matchId": "NA1_123", "participants": ["puuid-1", "puuid-2"]},
+	"info": {
+		"frameInterval": 60000,
+		"participants": [
+			{"participantId": 1, "puuid": "puuid-1"},
+			{"participantId": 2, "puuid": "puuid-2"}
+		],
+		"frames": [
+			{
+				"timestamp": 0,
+				"participantFrames": {
+					"2": {"participantId": 2, "level": 1, "xp": 0, "currentGold": 500, "totalGold": 500, "position": {"x": 14340, "y": 14390}},
+					"1": {"participantId": 1, "level": 1, "xp": 0, "currentGold": 500, "totalGold": 500, "position": {"x": 554, "y": 581}}
+				},
+				"events": [
+					{"type": "ITEM_PURCHASED", "timestamp": 1200, "participantId": 1, "itemId": 1055},
+					{"type": "SKILL_LEVEL_UP", "timestamp": 1500, "participantId": 1, "skillSlot": 1, "levelUpType": "NORMAL"}
+				]
+			},
+			{
+				"timestamp": 60000,
+				"participantFrames": {
+					"1": {"participantId": 1, "level": 3, "xp": 520, "currentGold": 120, "totalGold": 820, "minionsKilled": 6, "jungleMinionsKilled": 1, "position": {"x": 5000, "y": 5100}},
+					"2": {"participantId": 2, "level": 2, "xp": 300, "currentGold": 50, "totalGold": 700, "minionsKilled": 4, "position": {"x": 6000, "y": 6100}}
+				},
+				"events": [
+					{"type": "WARD_PLACED", "timestamp": 61000, "creatorId": 2, "wardType": "YELLOW_TRINKET"},
+					{"type": "CHAMPION_KILL", "timestamp": 62000, "killerId": 1, "victimId": 2, "position": {"x": 5500, "y": 5600}, "bounty": 300, "shutdownBounty": 0, "killStreakLength": 1},
+					{"type": "ELITE_MONSTER_KILL", "timestamp": 63000, "killerId": 1, "killerTeamId": 100, "monsterType": "DRAGON", "monsterSubType": "FIRE_DRAGON", "position": {"x": 9866, "y": 4414}},
+					{"type": "BUILDING_KILL", "timestamp": 64000, "killerId": 1, "teamId": 200, "buildingType": "TOWER_BUILDING", "laneType": "MID_LANE", "towerType": "OUTER_TURRET", "assistingParticipantIds": [3, 4], "position": {"x": 8955, "y": 8510}},
+					{"type": "LEVEL_UP", "timestamp": 65000, "participantId": 1, "level": 4}
+				]
+			}
+		]
+	}
+}`
+
+// TestGetMatchTimeline_Success tests decoding of frames, participant frames and typed events
+func TestGetMatchTimeline_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/lol/match/v5/matches/NA1_123/timeline" {
+			t.Errorf("Unexpected path '%s'", request.URL.Path)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(timelineResponse))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	timeline, err := service.GetMatchTimeline(context.Background(), "na", "NA1_123")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if timeline.MatchID != "NA1_123" || timeline.FrameInterval != 60000 {
+		t.Errorf("Expected NA1_123 with 60000ms frames, got %s with %d", timeline.MatchID, timeline.FrameInterval)
+	}
+
+	if len(timeline.Participants) != 2 || timeline.Participants[1].PUUID != "puuid-2" {
+		t.Errorf("Expected participant 2 to be puuid-2, got %+v", timeline.Participants)
+	}
+
+	if len(timeline.Frames) != 2 {
+		t.Fatalf("Expected 2 frames, got %d", len(timeline.Frames))
+	}
+
+	firstFrames := timeline.Frames[0].ParticipantFrames
+	if len(firstFrames) != 2 || firstFrames[0].ParticipantID != 1 || firstFrames[1].ParticipantID != 2 {
+		t.Errorf("Expected participant frames ordered by ID, got %+v", firstFrames)
+	}
+
+	expectedFrame := models.ParticipantFrame{
+		ParticipantID:       1,
+		Level:               3,
+		XP:                  520,
+		CurrentGold:         120,
+		TotalGold:           820,
+		MinionsKilled:       6,
+		JungleMinionsKilled: 1,
+		Position:            models.Position{X: 5000, Y: 5100},
+	}
+	if timeline.Frames[1].ParticipantFrames[0] != expectedFrame {
+		t.Errorf("Expected participant frame %+v, got %+v", expectedFrame, timeline.Frames[1].ParticipantFrames[0])
+	}
+
+	startEvents := timeline.Frames[0].Events
+	if itemPurchased := startEvents[0].ItemPurchased; itemPurchased == nil || itemPurchased.ItemID != 1055 {
+		t.Errorf("Expected ITEM_PURCHASED of item 1055, got %+v", startEvents[0])
+	}
+	if skillLevelUp := startEvents[1].SkillLevelUp; skillLevelUp == nil || skillLevelUp.SkillSlot != 1 || skillLevelUp.LevelUpType != "NORMAL" {
+		t.Errorf("Expected SKILL_LEVEL_UP of slot 1, got %+v", startEvents[1])
+	}
+
+	events := timeline.Frames[1].Events
+	if wardPlaced := events[0].WardPlaced; wardPlaced == nil || wardPlaced.CreatorID != 2 || wardPlaced.WardType != "YELLOW_TRINKET" {
+		t.Errorf("Expected WARD_PLACED by participant 2, got %+v", events[0])
+	}
+
+	championKill := events[1].ChampionKill
+	if championKill == nil || championKill.KillerID != 1 || championKill.VictimID != 2 || championKill.Bounty != 300 {
+		t.Errorf("Expected CHAMPION_KILL of 2 by 1, got %+v", events[1])
+	} else {
+		if championKill.Position != (models.Position{X: 5500, Y: 5600}) {
+			t.Errorf("Expected kill position (5500, 5600), got %+v", championKill.Position)
+		}
+		if championKill.AssistingParticipantIDs == nil {
+			t.Error("Expected an empty, non-nil assist list for a solo kill")
+		}
+	}
+
+	if eliteMonsterKill := events[2].EliteMonsterKill; eliteMonsterKill == nil || eliteMonsterKill.MonsterSubType != "FIRE_DRAGON" || eliteMonsterKill.KillerTeamID != 100 {
+		t.Errorf("Expected ELITE_MONSTER_KILL of a fire dragon, got %+v", events[2])
+	}
+
+	buildingKill := events[3].BuildingKill
+	if buildingKill == nil || buildingKill.TowerType != "OUTER_TURRET" || buildingKill.TeamID != 200 || len(buildingKill.AssistingParticipantIDs) != 2 {
+		t.Errorf("Expected BUILDING_KILL of a red outer turret, got %+v", events[3])
+	}
+
+	otherEvent := events[4]
+	if otherEvent.Type != "LEVEL_UP" || otherEvent.Timestamp != 65000 || otherEvent.ChampionKill != nil || otherEvent.SkillLevelUp != nil {
+		t.Errorf("Expected undecoded LEVEL_UP with only type and timestamp, got %+v", otherEvent)
+	}
+}
+
+// TestGetMatchTimeline_KeyedParticipantFrames tests that participant IDs fall back to the frame map key
+func TestGetMatchTimeline_KeyedParticipantFrames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{"metadata": {"matchId": "NA1_1"}, "info": {"frames": [{"timestamp": 0, "participantFrames": {"7": {"level": 1}}, "events": []}]}}`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	timeline, err := service.GetMatchTimeline(context.Background(), "na", "NA1_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if participantID := timeline.Frames[0].ParticipantFrames[0].ParticipantID; participantID != 7 {
+		t.Errorf("Expected participant ID 7 from the frame key, got %d", participantID)
+	}
+}
+
+// TestGetMatchTimeline_NotFound tests that a missing timeline surfaces as a Riot API error
+func TestGetMatchTimeline_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	_, err := service.GetMatchTimeline(context.Background(), "na", "NA1_404")
+
+	var riotAPIError *RiotAPIError
+	if !errors.As(err, &riotAPIError) || riotAPIError.StatusCode != http.StatusNotFound || riotAPIError.Service != "match-v5" {
+		t.Errorf("Expected match-v5 404 error, got: %v", err)
+	}
+}
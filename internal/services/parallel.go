@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"sync"
+)
+
+// runParallel calls work once for each index in [0, count) using at most parallelism goroutines
+// Indexes stop being handed out once ctx is done; it returns after every started call has finished
+// Requests made by work still pass through the rate limiter, so parallelism only bounds in-flight calls
+func runParallel(ctx context.Context, count int, parallelism int, work func(index int)) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	indexes := make(chan int)
+
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < min(parallelism, count); worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for index := range indexes {
+				work(index)
+			}
+		}()
+	}
+
+	// Hand out work until every index is queued or the caller goes away
+dispatch:
+	for index := 0; index < count; index++ {
+		select {
+		case indexes <- index:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	waitGroup.Wait()
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRunParallel_BoundsConcurrency tests that every index runs once with at most parallelism calls in flight
+func TestRunParallel_BoundsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	calls := make([]int32, 20)
+
+	runParallel(context.Background(), len(calls), 3, func(index int) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		atomic.AddInt32(&calls[index], 1)
+		atomic.AddInt32(&inFlight, -1)
+	})
+
+	for index, count := range calls {
+		if count != 1 {
+			t.Errorf("Expected index %d to run once, ran %d times", index, count)
+		}
+	}
+
+	if maxInFlight > 3 {
+		t.Errorf("Expected at most 3 calls in flight, got %d", maxInFlight)
+	}
+}
+
+// TestRunParallel_StopsOnCancellation tests that no new work is handed out once the context is done
+func TestRunParallel_StopsOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+
+	runParallel(ctx, 100, 1, func(index int) {
+		if atomic.AddInt32(&calls, 1) == 5 {
+			cancel()
+		}
+	})
+
+	if calls > 6 {
+		t.Errorf("Expected work to stop shortly after cancellation, got %d calls", calls)
+	}
+}
@@ -0,0 +1,49 @@
+package services
+
+import "strings"
+
+// queueNames maps match-v5 queue IDs to human-readable names
+// Source: Riot's queues.json static data; retired queues that no longer appear in match-v5 are omitted
+var queueNames = map[int]string{
+	0:    "Custom",
+	400:  "Normal Draft",
+	420:  "Ranked Solo/Duo",
+	430:  "Normal Blind",
+	440:  "Ranked Flex",
+	450:  "ARAM",
+	480:  "Swiftplay",
+	490:  "Quickplay",
+	700:  "Clash",
+	720:  "ARAM Clash",
+	830:  "Co-op vs. AI Intro",
+	840:  "Co-op vs. AI Beginner",
+	850:  "Co-op vs. AI Intermediate",
+	870:  "Co-op vs. AI Intro",
+	880:  "Co-op vs. AI Beginner",
+	890:  "Co-op vs. AI Intermediate",
+	900:  "ARURF",
+	1020: "One for All",
+	1300: "Nexus Blitz",
+	1400: "Ultimate Spellbook",
+	1700: "Arena",
+	1710: "Arena",
+	1900: "URF",
+	2000: "Tutorial 1",
+	2010: "Tutorial 2",
+	2020: "Tutorial 3",
+}
+
+// QueueName returns the human-readable name of a queue ID, or "" if it is not in the table
+func QueueName(queueID int) string {
+	return queueNames[queueID]
+}
+
+// parsePatch returns the major.minor patch of a game version (e.g., "14.20.628.4212" becomes "14.20")
+// Versions without a minor component are returned as "" rather than guessed
+func parsePatch(gameVersion string) string {
+	parts := strings.SplitN(gameVersion, ".", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return ""
+	}
+	return parts[0] + "." + parts[1]
+}
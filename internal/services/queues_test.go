@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestQueueName tests queue name lookup for known and unknown queues
+func TestQueueName(t *testing.T) {
+	testCases := map[int]string{
+		420:  "Ranked Solo/Duo",
+		440:  "Ranked Flex",
+		450:  "ARAM",
+		1700: "Arena",
+		9999: "",
+	}
+
+	for queueID, expectedName := range testCases {
+		if name := QueueName(queueID); name != expectedName {
+			t.Errorf("Expected queue %d to be '%s', got '%s'", queueID, expectedName, name)
+		}
+	}
+}
+
+// TestParsePatch tests deriving the patch from a game version
+func TestParsePatch(t *testing.T) {
+	testCases := map[string]string{
+		"14.20.628.4212": "14.20",
+		"14.3.555.1234":  "14.3",
+		"14.20":          "14.20",
+		"14":             "",
+		"":               "",
+		".20.1":          "",
+	}
+
+	for gameVersion, expectedPatch := range testCases {
+		if patch := parsePatch(gameVersion); patch != expectedPatch {
+			t.Errorf("Expected patch '%s' for version '%s', got '%s'", expectedPatch, gameVersion, patch)
+		}
+	}
+}
+
+// TestGetMatchDetails_Metadata tests decoding of queue, version, map, platform and timestamps
+func TestGetMatchDetails_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{
+			"metadata": {"matchId": "NA1_123"},
+			"info": {
+				"gameCreation": 1700000000000,
+				"gameStartTimestamp": 1700000030000,
+				"gameEndTimestamp": 1700001830000,
+				"queueId": 420,
+				"gameVersion": "14.20.628.4212",
+				"mapId": 11,
+				"platformId": "NA1",
+				"participants": []
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	match, err := service.GetMatchDetails(context.Background(), "na", "NA1_123")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if match.QueueID != 420 || match.QueueName != "Ranked Solo/Duo" {
+		t.Errorf("Expected queue 420 'Ranked Solo/Duo', got %d '%s'", match.QueueID, match.QueueName)
+	}
+
+	if match.GameVersion != "14.20.628.4212" || match.Patch != "14.20" {
+		t.Errorf("Expected version 14.20.628.4212 on patch 14.20, got %s on %s", match.GameVersion, match.Patch)
+	}
+
+	if match.MapID != 11 || match.PlatformID != "NA1" {
+		t.Errorf("Expected map 11 on NA1, got %d on %s", match.MapID, match.PlatformID)
+	}
+
+	if match.GameStartTimestamp == nil || match.GameEndTimestamp == nil ||
+		!match.GameStartTimestamp.Equal(time.UnixMilli(1700000030000)) || !match.GameEndTimestamp.Equal(time.UnixMilli(1700001830000)) {
+		t.Errorf("Expected start and end timestamps, got %s and %s", match.GameStartTimestamp, match.GameEndTimestamp)
+	}
+}
+
+// TestGetMatchDetails_MissingTimestamps tests that older matches omit start and end timestamps from the JSON
+func TestGetMatchDetails_MissingTimestamps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{"metadata": {"matchId": "NA1_1"}, "info": {"gameCreation": 1600000000000, "participants": []}}`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	match, err := service.GetMatchDetails(context.Background(), "na", "NA1_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	encoded, err := json.Marshal(match)
+	if err != nil {
+		t.Fatalf("Failed to encode match: %v", err)
+	}
+
+	for _, field := range []string{`"gameStartTimestamp"`, `"gameEndTimestamp"`} {
+		if strings.Contains(string(encoded), field) {
+			t.Errorf("Expected %s to be omitted, got %s", field, encoded)
+		}
+	}
+}
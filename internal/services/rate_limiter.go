@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Riot API rate limit response headers
+const (
+	appRateLimitHeader         = "X-App-Rate-Limit"
+	appRateLimitCountHeader    = "X-App-Rate-Limit-Count"
+	methodRateLimitHeader      = "X-Method-Rate-Limit"
+	methodRateLimitCountHeader = "X-Method-Rate-Limit-Count"
+	rateLimitTypeHeader        = "X-Rate-Limit-Type"
+	retryAfterHeader           = "Retry-After"
+)
+
+// rateLimit is a single "limit:seconds" pair from a Riot rate limit header
+type rateLimit struct {
+	// Number of requests (or requests used, for -Count headers)
+	value int
+	// Length of the rate limit window
+	window time.Duration
+}
+
+// parseRateLimitHeader parses a Riot rate limit header such as "20:1,100:120"
+func parseRateLimitHeader(headerValue string) ([]rateLimit, error) {
+	if strings.TrimSpace(headerValue) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(headerValue, ",")
+	limits := make([]rateLimit, 0, len(parts))
+	for _, part := range parts {
+		valueAndWindow := strings.Split(strings.TrimSpace(part), ":")
+		if len(valueAndWindow) != 2 {
+			return nil, fmt.Errorf("invalid rate limit entry %q", part)
+		}
+
+		value, err := strconv.Atoi(valueAndWindow[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit value %q: %w", valueAndWindow[0], err)
+		}
+
+		windowSeconds, err := strconv.Atoi(valueAndWindow[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit window %q: %w", valueAndWindow[1], err)
+		}
+
+		limits = append(limits, rateLimit{
+			value:  value,
+			window: time.Duration(windowSeconds) * time.Second,
+		})
+	}
+
+	return limits, nil
+}
+
+// tokenBucket tracks the tokens available in one Riot rate limit window
+// Riot refills the full limit when the window that began with the first request expires
+type tokenBucket struct {
+	// Maximum number of requests allowed per window
+	limit int
+	// Length of the window
+	window time.Duration
+	// Number of requests made in the current window
+	used int
+	// Start of the current window (zero when no window is active)
+	windowStart time.Time
+}
+
+// refresh resets the bucket if its current window has expired
+func (bucket *tokenBucket) refresh(now time.Time) {
+	if !bucket.windowStart.IsZero() && !now.Before(bucket.windowStart.Add(bucket.window)) {
+		bucket.used = 0
+		bucket.windowStart = time.Time{}
+	}
+}
+
+// waitTime returns how long until the bucket has a token available
+func (bucket *tokenBucket) waitTime(now time.Time) time.Duration {
+	bucket.refresh(now)
+	if bucket.used < bucket.limit {
+		return 0
+	}
+	return bucket.windowStart.Add(bucket.window).Sub(now)
+}
+
+// take consumes a single token, starting a new window if none is active
+func (bucket *tokenBucket) take(now time.Time) {
+	bucket.refresh(now)
+	if bucket.windowStart.IsZero() {
+		bucket.windowStart = now
+	}
+	bucket.used++
+}
+
+// rateScope holds the buckets that apply to an app (per host) or a method (per host and method)
+type rateScope struct {
+	// Buckets for each window reported by Riot
+	buckets []*tokenBucket
+	// Requests are held until this time after Riot returns a 429 for this scope
+	blockedUntil time.Time
+}
+
+// sync updates the scope's buckets from the limits and counts reported by Riot
+func (scope *rateScope) sync(limits []rateLimit, counts []rateLimit, now time.Time) {
+	// Index existing buckets by window so active windows survive limit changes
+	existing := make(map[time.Duration]*tokenBucket, len(scope.buckets))
+	for _, bucket := range scope.buckets {
+		existing[bucket.window] = bucket
+	}
+
+	buckets := make([]*tokenBucket, 0, len(limits))
+	bucketsByWindow := make(map[time.Duration]*tokenBucket, len(limits))
+	for _, limit := range limits {
+		bucket, exists := existing[limit.window]
+		if !exists {
+			bucket = &tokenBucket{window: limit.window}
+		}
+		bucket.limit = limit.value
+		buckets = append(buckets, bucket)
+		bucketsByWindow[limit.window] = bucket
+	}
+	scope.buckets = buckets
+
+	// Riot's counts include requests from other instances sharing the API key
+	for _, count := range counts {
+		bucket, exists := bucketsByWindow[count.window]
+		if !exists {
+			continue
+		}
+
+		bucket.refresh(now)
+		if bucket.windowStart.IsZero() {
+			bucket.windowStart = now
+		}
+		if count.value > bucket.used {
+			bucket.used = count.value
+		}
+	}
+}
+
+// RateLimiter enforces Riot's application and method rate limits
+// Application limits are tracked per routing host (na1, americas, etc.)
+// and method limits per routing host and endpoint
+type RateLimiter struct {
+	mutex sync.Mutex
+	// Application rate limit scopes keyed by host
+	appScopes map[string]*rateScope
+	// Method rate limit scopes keyed by host and endpoint
+	methodScopes map[string]*rateScope
+	// Clock used for window calculations (overridable for testing)
+	now func() time.Time
+}
+
+// NewRateLimiter creates a RateLimiter with no known limits
+// Limits are learned from the headers of each Riot API response
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		appScopes:    make(map[string]*rateScope),
+		methodScopes: make(map[string]*rateScope),
+		now:          time.Now,
+	}
+}
+
+// methodScopeKey returns the method scope key for a host and endpoint
+func methodScopeKey(host string, method string) string {
+	return host + "|" + method
+}
+
+// Wait blocks until a request to the given host and method is within all known limits
+// Returns the context's error if it is cancelled while waiting
+func (rateLimiter *RateLimiter) Wait(ctx context.Context, host string, method string) error {
+	for {
+		waitDuration := rateLimiter.reserve(host, method)
+		if waitDuration <= 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, waitDuration); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token from every applicable bucket if all have capacity
+// Returns zero on success, otherwise how long the caller should wait before trying again
+func (rateLimiter *RateLimiter) reserve(host string, method string) time.Duration {
+	rateLimiter.mutex.Lock()
+	defer rateLimiter.mutex.Unlock()
+
+	now := rateLimiter.now()
+	scopes := make([]*rateScope, 0, 2)
+	if scope, exists := rateLimiter.appScopes[host]; exists {
+		scopes = append(scopes, scope)
+	}
+	if scope, exists := rateLimiter.methodScopes[methodScopeKey(host, method)]; exists {
+		scopes = append(scopes, scope)
+	}
+
+	var waitDuration time.Duration
+	for _, scope := range scopes {
+		if scope.blockedUntil.After(now) {
+			waitDuration = maxDuration(waitDuration, scope.blockedUntil.Sub(now))
+		}
+		for _, bucket := range scope.buckets {
+			waitDuration = maxDuration(waitDuration, bucket.waitTime(now))
+		}
+	}
+
+	if waitDuration > 0 {
+		return waitDuration
+	}
+
+	for _, scope := range scopes {
+		for _, bucket := range scope.buckets {
+			bucket.take(now)
+		}
+	}
+
+	return 0
+}
+
+// Update records the rate limit headers of a Riot API response
+// A 429 response additionally blocks the offending scope for the Retry-After duration
+func (rateLimiter *RateLimiter) Update(host string, method string, response *http.Response) {
+	rateLimiter.mutex.Lock()
+	defer rateLimiter.mutex.Unlock()
+
+	now := rateLimiter.now()
+	appScope := rateLimiter.syncScope(rateLimiter.appScopes, host, response.Header, appRateLimitHeader, appRateLimitCountHeader, now)
+	methodScope := rateLimiter.syncScope(rateLimiter.methodScopes, methodScopeKey(host, method), response.Header, methodRateLimitHeader, methodRateLimitCountHeader, now)
+
+	if response.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	retryAfter := parseRetryAfter(response.Header)
+	if retryAfter <= 0 {
+		return
+	}
+	blockedUntil := now.Add(retryAfter)
+
+	// Service limits are enforced by Riot's backing services and are not tied to our key
+	switch response.Header.Get(rateLimitTypeHeader) {
+	case "application":
+		if appScope != nil {
+			appScope.blockedUntil = blockedUntil
+		}
+	case "method":
+		if methodScope != nil {
+			methodScope.blockedUntil = blockedUntil
+		}
+	}
+}
+
+// syncScope updates (creating if needed) the scope for key from the given limit and count headers
+// Returns the scope, or nil if the response carried no usable limit header
+func (rateLimiter *RateLimiter) syncScope(scopes map[string]*rateScope, key string, header http.Header, limitHeader string, countHeader string, now time.Time) *rateScope {
+	limits, err := parseRateLimitHeader(header.Get(limitHeader))
+	if err != nil || len(limits) == 0 {
+		return scopes[key]
+	}
+
+	// A malformed count header is treated as no usage information
+	counts, _ := parseRateLimitHeader(header.Get(countHeader))
+
+	scope, exists := scopes[key]
+	if !exists {
+		scope = &rateScope{}
+		scopes[key] = scope
+	}
+	scope.sync(limits, counts, now)
+
+	return scope
+}
+
+// maxDuration returns the larger of two durations
+func maxDuration(first time.Duration, second time.Duration) time.Duration {
+	if first > second {
+		return first
+	}
+	return second
+}
+
+// sleepContext sleeps for the given duration or until the context is done
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
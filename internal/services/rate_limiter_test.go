@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newTestRateLimiter creates a RateLimiter with a controllable clock
+func newTestRateLimiter(currentTime *time.Time) *RateLimiter {
+	rateLimiter := NewRateLimiter()
+	rateLimiter.now = func() time.Time { return *currentTime }
+	return rateLimiter
+}
+
+// newRateLimitResponse builds a response carrying the given headers
+func newRateLimitResponse(statusCode int, headers map[string]string) *http.Response {
+	response := &http.Response{StatusCode: statusCode, Header: make(http.Header)}
+	for name, value := range headers {
+		response.Header.Set(name, value)
+	}
+	return response
+}
+
+// TestParseRateLimitHeader tests parsing of Riot rate limit headers
+func TestParseRateLimitHeader(t *testing.T) {
+	limits, err := parseRateLimitHeader("20:1,100:120")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(limits) != 2 {
+		t.Fatalf("Expected 2 limits, got %d", len(limits))
+	}
+
+	if limits[0].value != 20 || limits[0].window != time.Second {
+		t.Errorf("Expected 20 per 1s, got %d per %s", limits[0].value, limits[0].window)
+	}
+
+	if limits[1].value != 100 || limits[1].window != 120*time.Second {
+		t.Errorf("Expected 100 per 120s, got %d per %s", limits[1].value, limits[1].window)
+	}
+}
+
+// TestParseRateLimitHeader_Invalid tests rejection of malformed headers
+func TestParseRateLimitHeader_Invalid(t *testing.T) {
+	testCases := []string{"20", "a:1", "20:b", "20:1,100"}
+
+	for _, headerValue := range testCases {
+		t.Run(headerValue, func(t *testing.T) {
+			if _, err := parseRateLimitHeader(headerValue); err == nil {
+				t.Errorf("Expected error for header '%s'", headerValue)
+			}
+		})
+	}
+}
+
+// TestRateLimiter_NoKnownLimits tests that requests pass before any limits are learned
+func TestRateLimiter_NoKnownLimits(t *testing.T) {
+	currentTime := time.Now()
+	rateLimiter := newTestRateLimiter(&currentTime)
+
+	for i := 0; i < 100; i++ {
+		if waitDuration := rateLimiter.reserve("na1.api.riotgames.com", matchEndpoint.key()); waitDuration != 0 {
+			t.Fatalf("Expected no wait without known limits, got %s", waitDuration)
+		}
+	}
+}
+
+// TestRateLimiter_AppLimitExhausted tests that the app bucket blocks once exhausted and refills after the window
+func TestRateLimiter_AppLimitExhausted(t *testing.T) {
+	currentTime := time.Now()
+	rateLimiter := newTestRateLimiter(&currentTime)
+	host := "americas.api.riotgames.com"
+
+	rateLimiter.Update(host, matchEndpoint.key(), newRateLimitResponse(http.StatusOK, map[string]string{
+		appRateLimitHeader:      "3:10",
+		appRateLimitCountHeader: "1:10",
+	}))
+
+	for i := 0; i < 2; i++ {
+		if waitDuration := rateLimiter.reserve(host, matchEndpoint.key()); waitDuration != 0 {
+			t.Fatalf("Expected request %d to pass, got wait %s", i, waitDuration)
+		}
+	}
+
+	waitDuration := rateLimiter.reserve(host, matchEndpoint.key())
+	if waitDuration != 10*time.Second {
+		t.Errorf("Expected wait of 10s once exhausted, got %s", waitDuration)
+	}
+
+	currentTime = currentTime.Add(10 * time.Second)
+	if waitDuration := rateLimiter.reserve(host, matchEndpoint.key()); waitDuration != 0 {
+		t.Errorf("Expected request to pass after window reset, got wait %s", waitDuration)
+	}
+}
+
+// TestRateLimiter_SeparateHostBuckets tests that each routing host has independent app limits
+func TestRateLimiter_SeparateHostBuckets(t *testing.T) {
+	currentTime := time.Now()
+	rateLimiter := newTestRateLimiter(&currentTime)
+
+	for _, host := range []string{"na1.api.riotgames.com", "euw1.api.riotgames.com"} {
+		rateLimiter.Update(host, summonerByPUUIDEndpoint.key(), newRateLimitResponse(http.StatusOK, map[string]string{
+			appRateLimitHeader:      "1:1",
+			appRateLimitCountHeader: "0:1",
+		}))
+	}
+
+	if waitDuration := rateLimiter.reserve("na1.api.riotgames.com", summonerByPUUIDEndpoint.key()); waitDuration != 0 {
+		t.Fatalf("Expected first NA request to pass, got wait %s", waitDuration)
+	}
+
+	if waitDuration := rateLimiter.reserve("na1.api.riotgames.com", summonerByPUUIDEndpoint.key()); waitDuration == 0 {
+		t.Error("Expected second NA request to wait")
+	}
+
+	if waitDuration := rateLimiter.reserve("euw1.api.riotgames.com", summonerByPUUIDEndpoint.key()); waitDuration != 0 {
+		t.Errorf("Expected EUW request to be unaffected by NA usage, got wait %s", waitDuration)
+	}
+}
+
+// TestRateLimiter_MethodLimits tests that method limits only apply to their own endpoint
+func TestRateLimiter_MethodLimits(t *testing.T) {
+	currentTime := time.Now()
+	rateLimiter := newTestRateLimiter(&currentTime)
+	host := "europe.api.riotgames.com"
+
+	rateLimiter.Update(host, matchEndpoint.key(), newRateLimitResponse(http.StatusOK, map[string]string{
+		methodRateLimitHeader:      "2:10",
+		methodRateLimitCountHeader: "2:10",
+	}))
+
+	if waitDuration := rateLimiter.reserve(host, matchEndpoint.key()); waitDuration == 0 {
+		t.Error("Expected exhausted method to wait")
+	}
+
+	if waitDuration := rateLimiter.reserve(host, matchIDsByPUUIDEndpoint.key()); waitDuration != 0 {
+		t.Errorf("Expected other method to pass, got wait %s", waitDuration)
+	}
+}
+
+// TestRateLimiter_RetryAfterBlocksScope tests that a 429 blocks the offending scope for Retry-After
+func TestRateLimiter_RetryAfterBlocksScope(t *testing.T) {
+	currentTime := time.Now()
+	rateLimiter := newTestRateLimiter(&currentTime)
+	host := "kr.api.riotgames.com"
+
+	rateLimiter.Update(host, leagueEntriesBySummonerEndpoint.key(), newRateLimitResponse(http.StatusTooManyRequests, map[string]string{
+		methodRateLimitHeader: "50:10",
+		rateLimitTypeHeader:   "method",
+		retryAfterHeader:      "5",
+	}))
+
+	if waitDuration := rateLimiter.reserve(host, leagueEntriesBySummonerEndpoint.key()); waitDuration != 5*time.Second {
+		t.Errorf("Expected wait of 5s after 429, got %s", waitDuration)
+	}
+
+	currentTime = currentTime.Add(5 * time.Second)
+	if waitDuration := rateLimiter.reserve(host, leagueEntriesBySummonerEndpoint.key()); waitDuration != 0 {
+		t.Errorf("Expected request to pass after Retry-After elapsed, got wait %s", waitDuration)
+	}
+}
+
+// TestRateLimiter_WaitBlocksUntilCapacity tests that Wait sleeps until the window resets
+func TestRateLimiter_WaitBlocksUntilCapacity(t *testing.T) {
+	rateLimiter := NewRateLimiter()
+	host := "jp1.api.riotgames.com"
+	rateLimiter.appScopes[host] = &rateScope{
+		buckets: []*tokenBucket{{limit: 1, window: 50 * time.Millisecond}},
+	}
+
+	startTime := time.Now()
+	rateLimiter.Wait(context.Background(), host, summonerByPUUIDEndpoint.key())
+	rateLimiter.Wait(context.Background(), host, summonerByPUUIDEndpoint.key())
+	elapsed := time.Since(startTime)
+
+	if elapsed < 50*time.Millisecond {
+		t.Errorf("Expected second Wait to block for the window, returned after %s", elapsed)
+	}
+}
+
+// TestMakeRequest_LearnsRateLimits tests that makeRequest applies limits from Riot response headers
+func TestMakeRequest_LearnsRateLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set(appRateLimitHeader, "20:1,100:120")
+		writer.Header().Set(appRateLimitCountHeader, "1:1,100:120")
+		writer.Header().Set(methodRateLimitHeader, "2000:10")
+		writer.Header().Set(methodRateLimitCountHeader, "1:10")
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	service := NewRiotService("test-api-key")
+
+	var result map[string]string
+	if err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	serverURL, _ := url.Parse(server.URL)
+	if waitDuration := service.rateLimiter.reserve(serverURL.Host, matchEndpoint.key()); waitDuration <= 0 {
+		t.Errorf("Expected exhausted 120s app bucket to require waiting, got %s", waitDuration)
+	}
+}
+
+// TestRateLimiter_WaitCancelled tests that Wait returns when the context is cancelled
+func TestRateLimiter_WaitCancelled(t *testing.T) {
+	rateLimiter := NewRateLimiter()
+	host := "br1.api.riotgames.com"
+	rateLimiter.appScopes[host] = &rateScope{blockedUntil: time.Now().Add(time.Hour)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := rateLimiter.Wait(ctx, host, matchEndpoint.key()); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Regional routing hosts serving match-v5 and other region-wide APIs
+const (
+	americasHost = "americas.api.riotgames.com"
+	europeHost   = "europe.api.riotgames.com"
+	asiaHost     = "asia.api.riotgames.com"
+	seaHost      = "sea.api.riotgames.com"
+)
+
+// Region describes a League of Legends server and how requests for it are routed
+type Region struct {
+	// Canonical short code accepted by the API (e.g., "euw")
+	Code string
+	// Riot platform ID (e.g., "EUW1")
+	PlatformID string
+	// Platform routing host for summoner-v4, league-v4, etc.
+	PlatformHost string
+	// Regional routing host for match-v5
+	RegionalHost string
+	// Nearest regional host serving Account-V1, which is only available on americas, europe and asia
+	AccountHost string
+	// Human-readable server name
+	DisplayName string
+	// Alternative names accepted for the region, matched case-insensitively
+	Aliases []string
+}
+
+// regions lists every supported League of Legends server
+var regions = []Region{
+	{Code: "na", PlatformID: "NA1", PlatformHost: "na1.api.riotgames.com", RegionalHost: americasHost, AccountHost: americasHost, DisplayName: "North America", Aliases: []string{"na1"}},
+	{Code: "br", PlatformID: "BR1", PlatformHost: "br1.api.riotgames.com", RegionalHost: americasHost, AccountHost: americasHost, DisplayName: "Brazil", Aliases: []string{"br1"}},
+	{Code: "lan", PlatformID: "LA1", PlatformHost: "la1.api.riotgames.com", RegionalHost: americasHost, AccountHost: americasHost, DisplayName: "Latin America North", Aliases: []string{"la1"}},
+	{Code: "las", PlatformID: "LA2", PlatformHost: "la2.api.riotgames.com", RegionalHost: americasHost, AccountHost: americasHost, DisplayName: "Latin America South", Aliases: []string{"la2"}},
+	{Code: "euw", PlatformID: "EUW1", PlatformHost: "euw1.api.riotgames.com", RegionalHost: europeHost, AccountHost: europeHost, DisplayName: "Europe West", Aliases: []string{"euw1"}},
+	{Code: "eune", PlatformID: "EUN1", PlatformHost: "eun1.api.riotgames.com", RegionalHost: europeHost, AccountHost: europeHost, DisplayName: "Europe Nordic & East", Aliases: []string{"eun1"}},
+	{Code: "tr", PlatformID: "TR1", PlatformHost: "tr1.api.riotgames.com", RegionalHost: europeHost, AccountHost: europeHost, DisplayName: "Turkey", Aliases: []string{"tr1"}},
+	{Code: "ru", PlatformID: "RU", PlatformHost: "ru.api.riotgames.com", RegionalHost: europeHost, AccountHost: europeHost, DisplayName: "Russia"},
+	{Code: "me", PlatformID: "ME1", PlatformHost: "me1.api.riotgames.com", RegionalHost: europeHost, AccountHost: europeHost, DisplayName: "Middle East", Aliases: []string{"me1"}},
+	{Code: "kr", PlatformID: "KR", PlatformHost: "kr.api.riotgames.com", RegionalHost: asiaHost, AccountHost: asiaHost, DisplayName: "Korea"},
+	{Code: "jp", PlatformID: "JP1", PlatformHost: "jp1.api.riotgames.com", RegionalHost: asiaHost, AccountHost: asiaHost, DisplayName: "Japan", Aliases: []string{"jp1"}},
+	{Code: "oce", PlatformID: "OC1", PlatformHost: "oc1.api.riotgames.com", RegionalHost: seaHost, AccountHost: asiaHost, DisplayName: "Oceania", Aliases: []string{"oc1"}},
+	{Code: "ph", PlatformID: "PH2", PlatformHost: "ph2.api.riotgames.com", RegionalHost: seaHost, AccountHost: asiaHost, DisplayName: "Philippines", Aliases: []string{"ph2"}},
+	{Code: "sg", PlatformID: "SG2", PlatformHost: "sg2.api.riotgames.com", RegionalHost: seaHost, AccountHost: asiaHost, DisplayName: "Singapore, Malaysia & Indonesia", Aliases: []string{"sg2"}},
+	{Code: "th", PlatformID: "TH2", PlatformHost: "th2.api.riotgames.com", RegionalHost: seaHost, AccountHost: asiaHost, DisplayName: "Thailand", Aliases: []string{"th2"}},
+	{Code: "tw", PlatformID: "TW2", PlatformHost: "tw2.api.riotgames.com", RegionalHost: seaHost, AccountHost: asiaHost, DisplayName: "Taiwan, Hong Kong & Macao", Aliases: []string{"tw2"}},
+	{Code: "vn", PlatformID: "VN2", PlatformHost: "vn2.api.riotgames.com", RegionalHost: seaHost, AccountHost: asiaHost, DisplayName: "Vietnam", Aliases: []string{"vn2"}},
+}
+
+// accountClusters maps Account-V1 cluster names to their hosts
+var accountClusters = map[string]string{
+	"americas": americasHost,
+	"europe":   europeHost,
+	"asia":     asiaHost,
+}
+
+// regionsByName indexes regions by lowercased code and aliases
+var regionsByName = indexRegions(regions)
+
+// indexRegions builds the lookup table for LookupRegion
+func indexRegions(regions []Region) map[string]Region {
+	index := make(map[string]Region)
+	for _, region := range regions {
+		index[strings.ToLower(region.Code)] = region
+		for _, alias := range region.Aliases {
+			index[strings.ToLower(alias)] = region
+		}
+	}
+	return index
+}
+
+// UnknownRegionError is returned when a region name is not in the registry
+type UnknownRegionError struct {
+	Region string
+}
+
+// Error implements the error interface
+func (unknownRegionError *UnknownRegionError) Error() string {
+	return fmt.Sprintf("unknown region %q", unknownRegionError.Region)
+}
+
+// Regions returns every supported region
+func Regions() []Region {
+	return append([]Region(nil), regions...)
+}
+
+// RegionCodes returns the canonical code of every supported region
+func RegionCodes() []string {
+	codes := make([]string, len(regions))
+	for index, region := range regions {
+		codes[index] = region.Code
+	}
+	return codes
+}
+
+// LookupRegion resolves a region code or alias (e.g., "euw", "EUW", "euw1") case-insensitively
+func LookupRegion(name string) (Region, bool) {
+	region, exists := regionsByName[strings.ToLower(strings.TrimSpace(name))]
+	return region, exists
+}
+
+// resolveRegion looks up a region, returning an *UnknownRegionError if it is not supported
+func resolveRegion(name string) (Region, error) {
+	region, exists := LookupRegion(name)
+	if !exists {
+		return Region{}, &UnknownRegionError{Region: name}
+	}
+	return region, nil
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// TestLookupRegion tests resolving region codes and aliases
+func TestLookupRegion(t *testing.T) {
+	testCases := []struct {
+		name         string
+		expectedCode string
+	}{
+		{"euw", "euw"},
+		{"EUW", "euw"},
+		{"euw1", "euw"},
+		{"EUW1", "euw"},
+		{" na ", "na"},
+		{"oc1", "oce"},
+		{"la2", "las"},
+		{"vn2", "vn"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			region, exists := LookupRegion(testCase.name)
+			if !exists {
+				t.Fatalf("Expected region '%s' to exist", testCase.name)
+			}
+			if region.Code != testCase.expectedCode {
+				t.Errorf("Expected code '%s', got '%s'", testCase.expectedCode, region.Code)
+			}
+		})
+	}
+}
+
+// TestLookupRegion_Unknown tests that unknown regions are rejected rather than defaulted
+func TestLookupRegion_Unknown(t *testing.T) {
+	for _, name := range []string{"", "eu", "unknown", "americas"} {
+		if _, exists := LookupRegion(name); exists {
+			t.Errorf("Expected region '%s' to be unknown", name)
+		}
+	}
+}
+
+// TestRegions_Registry tests that every region is fully described and names are unambiguous
+func TestRegions_Registry(t *testing.T) {
+	regionalHosts := map[string]bool{americasHost: true, europeHost: true, asiaHost: true, seaHost: true}
+	seen := make(map[string]string)
+
+	for _, region := range Regions() {
+		if region.PlatformID == "" || region.PlatformHost == "" || region.DisplayName == "" {
+			t.Errorf("Expected region '%s' to be fully described, got %+v", region.Code, region)
+		}
+
+		if !regionalHosts[region.RegionalHost] {
+			t.Errorf("Expected region '%s' to use a known regional host, got '%s'", region.Code, region.RegionalHost)
+		}
+
+		if region.AccountHost != americasHost && region.AccountHost != europeHost && region.AccountHost != asiaHost {
+			t.Errorf("Expected region '%s' to use an Account-V1 cluster, got '%s'", region.Code, region.AccountHost)
+		}
+
+		for _, name := range append([]string{region.Code}, region.Aliases...) {
+			if owner, exists := seen[name]; exists {
+				t.Errorf("Name '%s' is used by both '%s' and '%s'", name, owner, region.Code)
+			}
+			seen[name] = region.Code
+		}
+	}
+}
+
+// TestGetSummonerByPUUID_UnknownRegion tests that unknown regions fail without contacting Riot
+func TestGetSummonerByPUUID_UnknownRegion(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	_, err := service.GetSummonerByPUUID(context.Background(), "eu", "test-puuid")
+
+	var unknownRegionError *UnknownRegionError
+	if !errors.As(err, &unknownRegionError) || unknownRegionError.Region != "eu" {
+		t.Fatalf("Expected UnknownRegionError for 'eu', got: %v", err)
+	}
+
+	if requestCount != 0 {
+		t.Errorf("Expected no requests to Riot, got %d", requestCount)
+	}
+}
+
+// TestGetAccountURL_EveryRegion tests that every region routes Account-V1 to its nearest supported cluster
+func TestGetAccountURL_EveryRegion(t *testing.T) {
+	service := NewRiotService("test-key")
+
+	expectedHosts := map[string]string{
+		"na":   americasHost,
+		"br":   americasHost,
+		"lan":  americasHost,
+		"las":  americasHost,
+		"euw":  europeHost,
+		"eune": europeHost,
+		"tr":   europeHost,
+		"ru":   europeHost,
+		"me":   europeHost,
+		"kr":   asiaHost,
+		"jp":   asiaHost,
+		"oce":  asiaHost,
+		"ph":   asiaHost,
+		"sg":   asiaHost,
+		"th":   asiaHost,
+		"tw":   asiaHost,
+		"vn":   asiaHost,
+	}
+
+	for _, region := range Regions() {
+		t.Run(region.Code, func(t *testing.T) {
+			expectedHost, exists := expectedHosts[region.Code]
+			if !exists {
+				t.Fatalf("No expected account host for region '%s'; add it to this test", region.Code)
+			}
+
+			host, err := service.getAccountURL(region.Code)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if host != expectedHost {
+				t.Errorf("Expected account host '%s', got '%s'", expectedHost, host)
+			}
+
+			if host == seaHost {
+				t.Errorf("Account-V1 is not served by %s", seaHost)
+			}
+		})
+	}
+}
+
+// TestSetAccountCluster tests overriding and restoring Account-V1 routing
+func TestSetAccountCluster(t *testing.T) {
+	service := NewRiotService("test-key")
+
+	if err := service.SetAccountCluster("Europe"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, region := range Regions() {
+		if host, _ := service.getAccountURL(region.Code); host != europeHost {
+			t.Errorf("Expected region '%s' to use %s, got '%s'", region.Code, europeHost, host)
+		}
+	}
+
+	if err := service.SetAccountCluster("sea"); err == nil {
+		t.Error("Expected error for cluster without Account-V1")
+	}
+
+	if err := service.SetAccountCluster("nearest"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if host, _ := service.getAccountURL("kr"); host != asiaHost {
+		t.Errorf("Expected nearest routing to be restored, got '%s'", host)
+	}
+}
+
+// TestGetAccountURL_UnknownRegion tests that account routing rejects unknown regions
+func TestGetAccountURL_UnknownRegion(t *testing.T) {
+	service := NewRiotService("test-key")
+
+	var unknownRegionError *UnknownRegionError
+	if _, err := service.getAccountURL("eu"); !errors.As(err, &unknownRegionError) {
+		t.Errorf("Expected UnknownRegionError, got: %v", err)
+	}
+}
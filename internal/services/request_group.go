@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// inflightRequest is an upstream request shared by concurrent identical callers
+type inflightRequest struct {
+	// Closed once body and err are set
+	done chan struct{}
+	body []byte
+	err  error
+	// Callers still waiting for the result
+	waiters int
+	// Cancels the shared request once every waiter has gone away
+	cancel context.CancelFunc
+}
+
+// requestGroup collapses concurrent identical Riot API requests into a single upstream call
+// Unlike a plain singleflight, one caller cancelling does not fail the others:
+// the shared request runs detached from any single caller and is only cancelled when all of them leave
+type requestGroup struct {
+	mutex sync.Mutex
+	// In-flight requests keyed by endpoint and URL
+	inflight map[string]*inflightRequest
+	// Number of calls served by another caller's request, keyed by endpoint
+	collapsed map[string]int64
+}
+
+// newRequestGroup creates an empty requestGroup
+func newRequestGroup() *requestGroup {
+	return &requestGroup{
+		inflight:  make(map[string]*inflightRequest),
+		collapsed: make(map[string]int64),
+	}
+}
+
+// do returns the result of fetch for key, joining an identical in-flight request if there is one
+// The returned body is shared between callers and must not be modified
+func (group *requestGroup) do(ctx context.Context, endpoint riotEndpoint, key string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	group.mutex.Lock()
+	request, exists := group.inflight[key]
+	if exists {
+		request.waiters++
+		group.collapsed[endpoint.key()]++
+		log.Debug().Str("endpoint", endpoint.key()).Int("waiters", request.waiters).Msg("Joined in-flight Riot API request")
+	} else {
+		// Keep the caller's values (e.g., request IDs) but not its cancellation
+		sharedContext, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		request = &inflightRequest{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		group.inflight[key] = request
+
+		go group.run(sharedContext, key, request, fetch)
+	}
+	group.mutex.Unlock()
+
+	select {
+	case <-request.done:
+		return request.body, request.err
+	case <-ctx.Done():
+		group.leave(key, request)
+		return nil, fmt.Errorf("request cancelled: %w", ctx.Err())
+	}
+}
+
+// run performs the shared request and publishes its result to all waiters
+func (group *requestGroup) run(ctx context.Context, key string, request *inflightRequest, fetch func(ctx context.Context) ([]byte, error)) {
+	body, err := fetch(ctx)
+
+	group.mutex.Lock()
+	if group.inflight[key] == request {
+		delete(group.inflight, key)
+	}
+	group.mutex.Unlock()
+
+	request.cancel()
+	request.body, request.err = body, err
+	close(request.done)
+}
+
+// leave removes a waiter, cancelling the shared request if nobody is left waiting for it
+func (group *requestGroup) leave(key string, request *inflightRequest) {
+	group.mutex.Lock()
+	defer group.mutex.Unlock()
+
+	request.waiters--
+	if request.waiters > 0 {
+		return
+	}
+
+	// Later callers must start a fresh request rather than join a cancelled one
+	if group.inflight[key] == request {
+		delete(group.inflight, key)
+	}
+	request.cancel()
+}
+
+// stats returns a snapshot of collapsed call counts keyed by endpoint
+func (group *requestGroup) stats() map[string]int64 {
+	group.mutex.Lock()
+	defer group.mutex.Unlock()
+
+	stats := make(map[string]int64, len(group.collapsed))
+	for endpoint, count := range group.collapsed {
+		stats[endpoint] = count
+	}
+	return stats
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// waitForWaiters blocks until the in-flight request for key has the expected number of waiters
+func waitForWaiters(t *testing.T, group *requestGroup, key string, expected int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+
+	for time.Now().Before(deadline) {
+		group.mutex.Lock()
+		request, exists := group.inflight[key]
+		waiters := 0
+		if exists {
+			waiters = request.waiters
+		}
+		group.mutex.Unlock()
+
+		if waiters == expected {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("Timed out waiting for %d waiters on '%s'", expected, key)
+}
+
+// TestRequestGroup_CollapsesIdenticalCalls tests that concurrent identical calls share one fetch and its result
+func TestRequestGroup_CollapsesIdenticalCalls(t *testing.T) {
+	group := newRequestGroup()
+	release := make(chan struct{})
+	var fetchCount int32
+
+	fetch := func(ctx context.Context) ([]byte, error) {
+		atomic.AddInt32(&fetchCount, 1)
+		<-release
+		return []byte("result"), nil
+	}
+
+	const callers = 10
+	results := make([]string, callers)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		waitGroup.Add(1)
+		go func(index int) {
+			defer waitGroup.Done()
+			body, _ := group.do(context.Background(), matchEndpoint, "key", fetch)
+			results[index] = string(body)
+		}(i)
+	}
+
+	waitForWaiters(t, group, "key", callers)
+	close(release)
+	waitGroup.Wait()
+
+	if fetchCount != 1 {
+		t.Errorf("Expected 1 fetch, got %d", fetchCount)
+	}
+
+	for index, result := range results {
+		if result != "result" {
+			t.Errorf("Expected caller %d to receive 'result', got '%s'", index, result)
+		}
+	}
+
+	if collapsed := group.stats()[matchEndpoint.key()]; collapsed != callers-1 {
+		t.Errorf("Expected %d collapsed calls, got %d", callers-1, collapsed)
+	}
+}
+
+// TestRequestGroup_SequentialCallsNotCollapsed tests that a finished request is not reused
+func TestRequestGroup_SequentialCallsNotCollapsed(t *testing.T) {
+	group := newRequestGroup()
+	var fetchCount int32
+
+	fetch := func(ctx context.Context) ([]byte, error) {
+		atomic.AddInt32(&fetchCount, 1)
+		return nil, errors.New("upstream failure")
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := group.do(context.Background(), matchEndpoint, "key", fetch); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	}
+
+	if fetchCount != 3 {
+		t.Errorf("Expected 3 fetches, got %d", fetchCount)
+	}
+
+	if collapsed := group.stats()[matchEndpoint.key()]; collapsed != 0 {
+		t.Errorf("Expected no collapsed calls, got %d", collapsed)
+	}
+}
+
+// TestRequestGroup_CancelledWaiterDoesNotFailOthers tests that one caller leaving does not cancel the shared request
+func TestRequestGroup_CancelledWaiterDoesNotFailOthers(t *testing.T) {
+	group := newRequestGroup()
+	release := make(chan struct{})
+
+	fetch := func(ctx context.Context) ([]byte, error) {
+		select {
+		case <-release:
+			return []byte("result"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	leaderContext, cancelLeader := context.WithCancel(context.Background())
+	leaderErrors := make(chan error, 1)
+	go func() {
+		_, err := group.do(leaderContext, matchEndpoint, "key", fetch)
+		leaderErrors <- err
+	}()
+	waitForWaiters(t, group, "key", 1)
+
+	followerResults := make(chan string, 1)
+	go func() {
+		body, _ := group.do(context.Background(), matchEndpoint, "key", fetch)
+		followerResults <- string(body)
+	}()
+	waitForWaiters(t, group, "key", 2)
+
+	cancelLeader()
+	if err := <-leaderErrors; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected leader to be cancelled, got: %v", err)
+	}
+
+	close(release)
+	if result := <-followerResults; result != "result" {
+		t.Errorf("Expected follower to receive 'result', got '%s'", result)
+	}
+}
+
+// TestRequestGroup_AllWaitersCancelled tests that the shared request is cancelled once every caller leaves
+func TestRequestGroup_AllWaitersCancelled(t *testing.T) {
+	group := newRequestGroup()
+	fetchCancelled := make(chan struct{})
+
+	fetch := func(ctx context.Context) ([]byte, error) {
+		<-ctx.Done()
+		close(fetchCancelled)
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := group.do(ctx, matchEndpoint, "key", fetch); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+
+	select {
+	case <-fetchCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected shared request to be cancelled")
+	}
+}
+
+// TestMakeRequest_CoalescesConcurrentLookups tests that concurrent identical lookups reach Riot once
+func TestMakeRequest_CoalescesConcurrentLookups(t *testing.T) {
+	release := make(chan struct{})
+	var requestCount int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		<-release
+		json.NewEncoder(writer).Encode(models.Summoner{PUUID: "test-puuid", Name: "Player"})
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	const callers = 5
+	summoners := make([]*models.Summoner, callers)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		waitGroup.Add(1)
+		go func(index int) {
+			defer waitGroup.Done()
+			summoners[index], _ = service.GetSummonerByPUUID(context.Background(), "na", "test-puuid")
+		}(i)
+	}
+
+	waitForWaiters(t, service.requestGroup, summonerByPUUIDEndpoint.key()+" "+server.URL+"/lol/summoner/v4/summoners/by-puuid/test-puuid", callers)
+	close(release)
+	waitGroup.Wait()
+
+	if requestCount != 1 {
+		t.Errorf("Expected 1 upstream request, got %d", requestCount)
+	}
+
+	// Each caller decodes its own copy of the shared response
+	if summoners[0] == summoners[1] || summoners[0].Name != "Player" || summoners[1].Name != "Player" {
+		t.Errorf("Expected separate decoded summoners, got %+v and %+v", summoners[0], summoners[1])
+	}
+
+	if collapsed := service.CoalescedCalls()[summonerByPUUIDEndpoint.key()]; collapsed != callers-1 {
+		t.Errorf("Expected %d collapsed calls, got %d", callers-1, collapsed)
+	}
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed Riot API requests are retried
+type RetryPolicy struct {
+	// Maximum number of attempts per request, including the first (1 disables retries)
+	MaxAttempts int
+	// Initial backoff delay, doubled after each failed attempt
+	BaseDelay time.Duration
+	// Upper bound for a single backoff delay
+	MaxDelay time.Duration
+	// Upper bound for the total time spent on a request including retries (0 for no limit)
+	MaxElapsed time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   250 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		MaxElapsed:  20 * time.Second,
+	}
+}
+
+// backoff returns the jittered exponential delay before the next attempt
+// Half of the delay is fixed and half is random so concurrent retries spread out
+func (policy RetryPolicy) backoff(attempt int) time.Duration {
+	delay := policy.BaseDelay
+	for i := 1; i < attempt && delay < policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	halfDelay := delay / 2
+	return halfDelay + time.Duration(rand.Int63n(int64(delay-halfDelay)+1))
+}
+
+// isRetryableStatus reports whether a Riot API status code is worth retrying
+// Client errors (400, 401, 403, 404, etc.) will fail the same way again and are never retried
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// parseRetryAfter returns the Retry-After header value as a duration (0 if absent or invalid)
+func parseRetryAfter(header http.Header) time.Duration {
+	retryAfterSeconds, err := strconv.Atoi(header.Get(retryAfterHeader))
+	if err != nil || retryAfterSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(retryAfterSeconds) * time.Second
+}
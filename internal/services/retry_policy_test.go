@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFastRetryPolicy returns a retry policy with short delays for testing
+func newFastRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+		MaxElapsed:  time.Second,
+	}
+}
+
+// TestRetryPolicy_Backoff tests that backoff grows exponentially and respects MaxDelay
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 400 * time.Millisecond}
+
+	testCases := []struct {
+		attempt  int
+		minDelay time.Duration
+		maxDelay time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 200 * time.Millisecond, 400 * time.Millisecond},
+	}
+
+	for _, testCase := range testCases {
+		delay := policy.backoff(testCase.attempt)
+		if delay < testCase.minDelay || delay > testCase.maxDelay {
+			t.Errorf("Expected attempt %d delay in [%s, %s], got %s", testCase.attempt, testCase.minDelay, testCase.maxDelay, delay)
+		}
+	}
+}
+
+// TestIsRetryableStatus tests which status codes are retried
+func TestIsRetryableStatus(t *testing.T) {
+	testCases := []struct {
+		statusCode int
+		retryable  bool
+	}{
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusForbidden, false},
+		{http.StatusNotFound, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+	}
+
+	for _, testCase := range testCases {
+		if retryable := isRetryableStatus(testCase.statusCode); retryable != testCase.retryable {
+			t.Errorf("Expected retryable=%v for status %d, got %v", testCase.retryable, testCase.statusCode, retryable)
+		}
+	}
+}
+
+// TestMakeRequest_RetriesServerErrors tests that 5xx responses are retried until success
+func TestMakeRequest_RetriesServerErrors(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if atomic.AddInt32(&requestCount, 1) < 3 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	service := NewRiotService("test-api-key")
+	service.SetRetryPolicy(newFastRetryPolicy(3))
+
+	var result map[string]string
+	if err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result); err != nil {
+		t.Fatalf("Expected success after retries, got: %v", err)
+	}
+
+	if requestCount != 3 {
+		t.Errorf("Expected 3 requests, got %d", requestCount)
+	}
+}
+
+// TestMakeRequest_HonorsRetryAfter tests that 429 responses wait for Retry-After before retrying
+func TestMakeRequest_HonorsRetryAfter(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if atomic.AddInt32(&requestCount, 1) == 1 {
+			writer.Header().Set(retryAfterHeader, "1")
+			writer.Header().Set(rateLimitTypeHeader, "service")
+			writer.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	service := NewRiotService("test-api-key")
+	service.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxElapsed: 5 * time.Second})
+
+	startTime := time.Now()
+	var result map[string]string
+	if err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result); err != nil {
+		t.Fatalf("Expected success after retry, got: %v", err)
+	}
+
+	if elapsed := time.Since(startTime); elapsed < time.Second {
+		t.Errorf("Expected retry to wait for Retry-After of 1s, waited %s", elapsed)
+	}
+}
+
+// TestMakeRequest_DoesNotRetryClientErrors tests that 4xx responses other than 429 are not retried
+func TestMakeRequest_DoesNotRetryClientErrors(t *testing.T) {
+	statusCodes := []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}
+
+	for _, statusCode := range statusCodes {
+		t.Run(http.StatusText(statusCode), func(t *testing.T) {
+			var requestCount int32
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				atomic.AddInt32(&requestCount, 1)
+				writer.WriteHeader(statusCode)
+			}))
+			defer server.Close()
+
+			service := NewRiotService("test-api-key")
+			service.SetRetryPolicy(newFastRetryPolicy(5))
+
+			var result map[string]string
+			if err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result); err == nil {
+				t.Fatal("Expected error for client error status")
+			}
+
+			if requestCount != 1 {
+				t.Errorf("Expected 1 request, got %d", requestCount)
+			}
+		})
+	}
+}
+
+// TestMakeRequest_StopsAtMaxAttempts tests that retries are capped by MaxAttempts
+func TestMakeRequest_StopsAtMaxAttempts(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		writer.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	service := NewRiotService("test-api-key")
+	service.SetRetryPolicy(newFastRetryPolicy(4))
+
+	var result map[string]string
+	if err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result); err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+
+	if requestCount != 4 {
+		t.Errorf("Expected 4 requests, got %d", requestCount)
+	}
+}
+
+// TestMakeRequest_StopsAtMaxElapsed tests that retries stop when the elapsed time budget is spent
+func TestMakeRequest_StopsAtMaxElapsed(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		writer.Header().Set(retryAfterHeader, "10")
+		writer.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	service := NewRiotService("test-api-key")
+	service.SetRetryPolicy(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxElapsed: time.Second})
+
+	var result map[string]string
+	if err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result); err == nil {
+		t.Fatal("Expected error when Retry-After exceeds the elapsed budget")
+	}
+
+	if requestCount != 1 {
+		t.Errorf("Expected 1 request, got %d", requestCount)
+	}
+}
+
+// TestSetRetryPolicy_MinimumAttempts tests that at least one attempt is always made
+func TestSetRetryPolicy_MinimumAttempts(t *testing.T) {
+	service := NewRiotService("test-api-key")
+	service.SetRetryPolicy(RetryPolicy{MaxAttempts: 0})
+
+	if service.retryPolicy.MaxAttempts != 1 {
+		t.Errorf("Expected MaxAttempts to be clamped to 1, got %d", service.retryPolicy.MaxAttempts)
+	}
+}
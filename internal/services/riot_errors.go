@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RiotAPIError is returned when the Riot API responds with a non-200 status
+// Callers can use errors.As to inspect the status and react accordingly
+type RiotAPIError struct {
+	// HTTP status code returned by the Riot API
+	StatusCode int
+	// Riot API service that failed (e.g., account-v1)
+	Service string
+	// Method within the service that failed (e.g., getByRiotId)
+	Endpoint string
+	// Retry-After duration requested by Riot (zero if not provided)
+	RetryAfter time.Duration
+	// Raw response body returned by the Riot API
+	Message string
+}
+
+// Error implements the error interface
+func (riotAPIError *RiotAPIError) Error() string {
+	return fmt.Sprintf("%s %s request failed with status %d: %s",
+		riotAPIError.Service, riotAPIError.Endpoint, riotAPIError.StatusCode, riotAPIError.Message)
+}
+
+// RiotTransportError is returned when a Riot API request gets no usable response:
+// the connection failed, the attempt timed out, or the body could not be read
+type RiotTransportError struct {
+	// Riot API service that failed (e.g., account-v1)
+	Service string
+	// Method within the service that failed (e.g., getByRiotId)
+	Endpoint string
+	// Whether the attempt ran out of time rather than failing to connect
+	Timeout bool
+	// Underlying transport error
+	Err error
+}
+
+// Error implements the error interface
+func (transportError *RiotTransportError) Error() string {
+	return fmt.Sprintf("%s %s request failed: %v", transportError.Service, transportError.Endpoint, transportError.Err)
+}
+
+// Unwrap returns the underlying transport error
+func (transportError *RiotTransportError) Unwrap() error {
+	return transportError.Err
+}
+
+// newRiotTransportError wraps a transport failure for endpoint, noting whether it was a timeout
+func newRiotTransportError(endpoint riotEndpoint, err error) *RiotTransportError {
+	var netError net.Error
+	timeout := errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netError) && netError.Timeout())
+
+	return &RiotTransportError{
+		Service:  endpoint.service,
+		Endpoint: endpoint.method,
+		Timeout:  timeout,
+		Err:      err,
+	}
+}
+
+// Stable failure reasons reported to clients in place of raw error messages,
+// which can carry Riot's response bodies, hosts and URLs
+const (
+	FailureReasonNotFound             = "not_found"
+	FailureReasonRateLimited          = "rate_limited"
+	FailureReasonUpstreamUnauthorized = "upstream_unauthorized"
+	FailureReasonUpstreamBadRequest   = "upstream_bad_request"
+	FailureReasonUpstreamUnavailable  = "upstream_unavailable"
+	FailureReasonUpstreamError        = "upstream_error"
+	FailureReasonUpstreamTimeout      = "upstream_timeout"
+	FailureReasonUpstreamUnreachable  = "upstream_unreachable"
+	FailureReasonCircuitOpen          = "circuit_open"
+	FailureReasonInternalError        = "internal_error"
+)
+
+// FailureReason maps an error to one of the stable FailureReason values
+// Callers should log the full error server-side; only the reason is safe to return to clients
+func FailureReason(err error) string {
+	var circuitOpenError *CircuitOpenError
+	if errors.As(err, &circuitOpenError) {
+		return FailureReasonCircuitOpen
+	}
+
+	var transportError *RiotTransportError
+	if errors.As(err, &transportError) {
+		if transportError.Timeout {
+			return FailureReasonUpstreamTimeout
+		}
+		return FailureReasonUpstreamUnreachable
+	}
+
+	var riotAPIError *RiotAPIError
+	if !errors.As(err, &riotAPIError) {
+		return FailureReasonInternalError
+	}
+
+	switch statusCode := riotAPIError.StatusCode; {
+	case statusCode == http.StatusNotFound:
+		return FailureReasonNotFound
+	case statusCode == http.StatusTooManyRequests:
+		return FailureReasonRateLimited
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return FailureReasonUpstreamUnauthorized
+	case statusCode == http.StatusBadRequest:
+		return FailureReasonUpstreamBadRequest
+	case statusCode == http.StatusServiceUnavailable:
+		return FailureReasonUpstreamUnavailable
+	default:
+		return FailureReasonUpstreamError
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRiotAPIError_Error tests the error message format
+func TestRiotAPIError_Error(t *testing.T) {
+	riotAPIError := &RiotAPIError{
+		StatusCode: http.StatusNotFound,
+		Service:    "account-v1",
+		Endpoint:   "getByRiotId",
+		Message:    "Data not found",
+	}
+
+	expected := "account-v1 getByRiotId request failed with status 404: Data not found"
+	if riotAPIError.Error() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, riotAPIError.Error())
+	}
+}
+
+// TestGetSummonerByRiotID_TypedError tests that Riot failures surface as RiotAPIError through wrapping
+func TestGetSummonerByRiotID_TypedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+		writer.Write([]byte(`{"status":{"message":"Data not found","status_code":404}}`))
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	_, err := service.GetSummonerByRiotID(context.Background(), "na", "NonExistent", "NA1")
+
+	var riotAPIError *RiotAPIError
+	if !errors.As(err, &riotAPIError) {
+		t.Fatalf("Expected RiotAPIError, got: %v", err)
+	}
+
+	if riotAPIError.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", riotAPIError.StatusCode)
+	}
+
+	if riotAPIError.Service != "account-v1" || riotAPIError.Endpoint != "getByRiotId" {
+		t.Errorf("Expected account-v1 getByRiotId, got %s %s", riotAPIError.Service, riotAPIError.Endpoint)
+	}
+
+	if !strings.Contains(riotAPIError.Message, "Data not found") {
+		t.Errorf("Expected raw message to be preserved, got '%s'", riotAPIError.Message)
+	}
+}
+
+// TestMakeRequest_TypedRateLimitError tests that Retry-After is captured on rate limit errors
+func TestMakeRequest_TypedRateLimitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set(retryAfterHeader, "30")
+		writer.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	service := NewRiotService("test-api-key")
+	service.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+	var result map[string]string
+	err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result)
+
+	var riotAPIError *RiotAPIError
+	if !errors.As(err, &riotAPIError) {
+		t.Fatalf("Expected RiotAPIError, got: %v", err)
+	}
+
+	if riotAPIError.RetryAfter != 30*time.Second {
+		t.Errorf("Expected RetryAfter 30s, got %s", riotAPIError.RetryAfter)
+	}
+}
+
+// TestMakeRequest_TransportErrors tests that refused connections and attempt timeouts surface as RiotTransportError
+func TestMakeRequest_TransportErrors(t *testing.T) {
+	slowServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-request.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer slowServer.Close()
+
+	closedServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	closedURL := closedServer.URL
+	closedServer.Close()
+
+	testCases := []struct {
+		name            string
+		baseURL         string
+		expectedTimeout bool
+	}{
+		{"refused", closedURL, false},
+		{"timeout", slowServer.URL, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := NewRiotServiceWithBaseURL("test-api-key", testCase.baseURL, slowServer.Client())
+			service.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+			service.SetRequestTimeout(20 * time.Millisecond)
+
+			_, err := service.GetSummonerByPUUID(context.Background(), "na", "test-puuid")
+
+			var transportError *RiotTransportError
+			if !errors.As(err, &transportError) {
+				t.Fatalf("Expected RiotTransportError, got: %v", err)
+			}
+
+			if transportError.Timeout != testCase.expectedTimeout || transportError.Service != "summoner-v4" {
+				t.Errorf("Expected summoner-v4 error with timeout %v, got %+v", testCase.expectedTimeout, transportError)
+			}
+		})
+	}
+}
+
+// TestFailureReason tests that errors map to stable reasons without leaking their messages
+func TestFailureReason(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"not found", &RiotAPIError{StatusCode: http.StatusNotFound, Message: "Data not found"}, FailureReasonNotFound},
+		{"rate limited", &RiotAPIError{StatusCode: http.StatusTooManyRequests}, FailureReasonRateLimited},
+		{"expired key", &RiotAPIError{StatusCode: http.StatusForbidden}, FailureReasonUpstreamUnauthorized},
+		{"bad request", &RiotAPIError{StatusCode: http.StatusBadRequest}, FailureReasonUpstreamBadRequest},
+		{"unavailable", &RiotAPIError{StatusCode: http.StatusServiceUnavailable}, FailureReasonUpstreamUnavailable},
+		{"server error", fmt.Errorf("failed to get match: %w", &RiotAPIError{StatusCode: http.StatusInternalServerError}), FailureReasonUpstreamError},
+		{"timeout", &RiotTransportError{Timeout: true, Err: context.DeadlineExceeded}, FailureReasonUpstreamTimeout},
+		{"unreachable", &RiotTransportError{Err: errors.New("dial tcp: connection refused")}, FailureReasonUpstreamUnreachable},
+		{"circuit open", &CircuitOpenError{Host: "americas.api.riotgames.com"}, FailureReasonCircuitOpen},
+		{"untyped", errors.New("something broke"), FailureReasonInternalError},
+	}
+
+	for _, testCase := range testCases {
+		if reason := FailureReason(testCase.err); reason != testCase.expected {
+			t.Errorf("%s: expected '%s', got '%s'", testCase.name, testCase.expected, reason)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultRiotIDLookupParallelism is the number of Account-V1 lookups EnrichRiotIDs runs concurrently by default
+const DefaultRiotIDLookupParallelism = 5
+
+// EnrichRiotIDs fills in RiotIDGameName and RiotIDTagline for participants whose Riot ID is missing
+// Each PUUID is looked up once through riotService, with at most parallelism lookups in flight;
+// lookups that fail are logged and leave the participant unchanged
+func EnrichRiotIDs(ctx context.Context, riotService RiotServiceInterface, region string, matches []models.Match, parallelism int) {
+	// Collect each PUUID that needs a lookup once, in first-seen order
+	var puuids []string
+	seen := make(map[string]bool)
+	for _, match := range matches {
+		for _, participant := range match.Participants {
+			if participant.RiotIDGameName != "" || participant.PUUID == "" || seen[participant.PUUID] {
+				continue
+			}
+			seen[participant.PUUID] = true
+			puuids = append(puuids, participant.PUUID)
+		}
+	}
+
+	if len(puuids) == 0 {
+		return
+	}
+
+	accounts := make([]*models.Account, len(puuids))
+	runParallel(ctx, len(puuids), parallelism, func(index int) {
+		account, err := riotService.GetAccountByPUUID(ctx, region, puuids[index])
+		if err != nil {
+			log.Warn().Err(err).Str("puuid", puuids[index]).Msg("Failed to look up Riot ID")
+			return
+		}
+		accounts[index] = account
+	})
+
+	accountsByPUUID := make(map[string]*models.Account, len(puuids))
+	for index, account := range accounts {
+		if account != nil {
+			accountsByPUUID[puuids[index]] = account
+		}
+	}
+
+	for matchIndex := range matches {
+		participants := matches[matchIndex].Participants
+		for participantIndex := range participants {
+			participant := &participants[participantIndex]
+			if account, exists := accountsByPUUID[participant.PUUID]; exists && participant.RiotIDGameName == "" {
+				participant.RiotIDGameName = account.GameName
+				participant.RiotIDTagline = account.TagLine
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
+
+// accountLookupService is a RiotServiceInterface stub that serves Account-V1 lookups from a map
+type accountLookupService struct {
+	countingRiotService
+	mutex    sync.Mutex
+	accounts map[string]models.Account
+	lookups  map[string]int
+}
+
+func (service *accountLookupService) GetAccountByPUUID(ctx context.Context, region string, puuid string) (*models.Account, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	service.lookups[puuid]++
+	account, exists := service.accounts[puuid]
+	if !exists {
+		return nil, &RiotAPIError{StatusCode: 404, Service: "account-v1"}
+	}
+	return &account, nil
+}
+
+// TestEnrichRiotIDs tests that missing Riot IDs are filled in with one lookup per PUUID
+func TestEnrichRiotIDs(t *testing.T) {
+	service := &accountLookupService{
+		accounts: map[string]models.Account{
+			"puuid-a": {PUUID: "puuid-a", GameName: "PlayerA", TagLine: "NA1"},
+			"puuid-b": {PUUID: "puuid-b", GameName: "PlayerB", TagLine: "EUW"},
+		},
+		lookups: make(map[string]int),
+	}
+
+	matches := []models.Match{
+		{MatchID: "NA1_1", Participants: []models.Participant{{PUUID: "puuid-a"}, {PUUID: "puuid-b"}}},
+		{MatchID: "NA1_2", Participants: []models.Participant{
+			{PUUID: "puuid-a"},
+			{PUUID: "puuid-c", RiotIDGameName: "Known", RiotIDTagline: "KR1"},
+			{PUUID: "puuid-missing"},
+		}},
+	}
+
+	EnrichRiotIDs(context.Background(), service, "na", matches, DefaultRiotIDLookupParallelism)
+
+	if participant := matches[1].Participants[0]; participant.RiotIDGameName != "PlayerA" || participant.RiotIDTagline != "NA1" {
+		t.Errorf("Expected PlayerA#NA1, got %s#%s", participant.RiotIDGameName, participant.RiotIDTagline)
+	}
+
+	if participant := matches[0].Participants[1]; participant.RiotIDGameName != "PlayerB" {
+		t.Errorf("Expected PlayerB, got '%s'", participant.RiotIDGameName)
+	}
+
+	if participant := matches[1].Participants[1]; participant.RiotIDGameName != "Known" {
+		t.Errorf("Expected existing Riot ID to be kept, got '%s'", participant.RiotIDGameName)
+	}
+
+	if participant := matches[1].Participants[2]; participant.RiotIDGameName != "" {
+		t.Errorf("Expected failed lookup to leave participant unchanged, got '%s'", participant.RiotIDGameName)
+	}
+
+	if service.lookups["puuid-a"] != 1 {
+		t.Errorf("Expected puuid-a to be looked up once, got %d", service.lookups["puuid-a"])
+	}
+
+	if service.lookups["puuid-c"] != 0 {
+		t.Errorf("Expected participant with a Riot ID not to be looked up, got %d", service.lookups["puuid-c"])
+	}
+}
+
+// TestEnrichRiotIDs_Cancelled tests that enrichment stops when the context is cancelled
+func TestEnrichRiotIDs_Cancelled(t *testing.T) {
+	service := &accountLookupService{accounts: map[string]models.Account{}, lookups: make(map[string]int)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	matches := []models.Match{{Participants: []models.Participant{{PUUID: "puuid-a"}}}}
+	EnrichRiotIDs(ctx, service, "na", matches, DefaultRiotIDLookupParallelism)
+
+	if matches[0].Participants[0].RiotIDGameName != "" {
+		t.Error("Expected no enrichment after cancellation")
+	}
+}
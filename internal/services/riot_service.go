@@ -1,15 +1,22 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/OPGLOL/opgl-data-service/internal/models"
+	"github.com/rs/zerolog/log"
 )
 
+// defaultRequestTimeout is the deadline for a single Riot API request attempt
+const defaultRequestTimeout = 10 * time.Second
+
 // RiotService handles all interactions with the Riot Games API
 type RiotService struct {
 	// Riot Games API key for authentication
@@ -18,83 +25,234 @@ type RiotService struct {
 	httpClient *http.Client
 	// Base URL override for testing (optional)
 	baseURLOverride string
+	// Rate limiter enforcing Riot's app and method limits
+	rateLimiter *RateLimiter
+	// Policy for retrying failed requests
+	retryPolicy RetryPolicy
+	// Deadline for a single Riot API request attempt
+	requestTimeout time.Duration
+	// Number of match details fetched concurrently by GetMatchHistory
+	matchFetchParallelism int
+	// Collapses concurrent identical requests into one upstream call
+	requestGroup *requestGroup
+	// Fails requests fast while a routing host is unhealthy
+	circuitBreaker *CircuitBreaker
+	// Account-V1 host used for every region (empty means each region's nearest cluster)
+	accountHostOverride string
 }
 
 // NewRiotService creates a new RiotService with the provided API key
 func NewRiotService(apiKey string) *RiotService {
 	return &RiotService{
 		apiKey: apiKey,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		baseURLOverride: "",
+		// No client-level timeout: each attempt carries its own deadline (see SetRequestTimeout)
+		httpClient:            &http.Client{},
+		baseURLOverride:       "",
+		rateLimiter:           NewRateLimiter(),
+		retryPolicy:           DefaultRetryPolicy(),
+		requestTimeout:        defaultRequestTimeout,
+		matchFetchParallelism: defaultMatchFetchParallelism,
+		requestGroup:          newRequestGroup(),
+		circuitBreaker:        NewCircuitBreaker(DefaultCircuitBreakerPolicy()),
 	}
 }
 
 // NewRiotServiceWithBaseURL creates a RiotService with a custom base URL (for testing)
 func NewRiotServiceWithBaseURL(apiKey string, baseURL string, httpClient *http.Client) *RiotService {
 	return &RiotService{
-		apiKey:          apiKey,
-		httpClient:      httpClient,
-		baseURLOverride: baseURL,
+		apiKey:                apiKey,
+		httpClient:            httpClient,
+		baseURLOverride:       baseURL,
+		rateLimiter:           NewRateLimiter(),
+		retryPolicy:           DefaultRetryPolicy(),
+		requestTimeout:        defaultRequestTimeout,
+		matchFetchParallelism: defaultMatchFetchParallelism,
+		requestGroup:          newRequestGroup(),
+		circuitBreaker:        NewCircuitBreaker(DefaultCircuitBreakerPolicy()),
+	}
+}
+
+// SetRetryPolicy replaces the policy used to retry failed Riot API requests
+func (riotService *RiotService) SetRetryPolicy(policy RetryPolicy) {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	riotService.retryPolicy = policy
+}
+
+// SetRequestTimeout sets the deadline for a single Riot API request attempt
+// The caller's context deadline still applies if it is shorter
+func (riotService *RiotService) SetRequestTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	riotService.requestTimeout = timeout
+}
+
+// SetMatchFetchParallelism sets how many match details GetMatchHistory fetches concurrently
+func (riotService *RiotService) SetMatchFetchParallelism(parallelism int) {
+	if parallelism < 1 {
+		parallelism = 1
 	}
+	riotService.matchFetchParallelism = parallelism
 }
 
-// getRegionalURL returns the correct API URL based on region
-func (riotService *RiotService) getRegionalURL(region string) string {
-	// Map region codes to Riot API regional routing values
-	regionalRouting := map[string]string{
-		"na":  "na1.api.riotgames.com",
-		"euw": "euw1.api.riotgames.com",
-		"eune": "eun1.api.riotgames.com",
-		"kr":  "kr.api.riotgames.com",
-		"br":  "br1.api.riotgames.com",
-		"jp":  "jp1.api.riotgames.com",
-		"ru":  "ru.api.riotgames.com",
-		"oce": "oc1.api.riotgames.com",
-		"tr":  "tr1.api.riotgames.com",
-		"lan": "la1.api.riotgames.com",
-		"las": "la2.api.riotgames.com",
-	}
-
-	if url, exists := regionalRouting[region]; exists {
-		return url
-	}
-
-	// Default to NA if region is not recognized
-	return regionalRouting["na"]
+// getRegionalURL returns the platform routing host for a region
+func (riotService *RiotService) getRegionalURL(region string) (string, error) {
+	resolvedRegion, err := resolveRegion(region)
+	if err != nil {
+		return "", err
+	}
+	return resolvedRegion.PlatformHost, nil
 }
 
-// getMatchRegionalURL returns the correct match API URL based on region
-func (riotService *RiotService) getMatchRegionalURL(region string) string {
-	// Match API uses continental routing
-	continentalRouting := map[string]string{
-		"na":  "americas.api.riotgames.com",
-		"br":  "americas.api.riotgames.com",
-		"lan": "americas.api.riotgames.com",
-		"las": "americas.api.riotgames.com",
-		"euw": "europe.api.riotgames.com",
-		"eune": "europe.api.riotgames.com",
-		"tr":  "europe.api.riotgames.com",
-		"ru":  "europe.api.riotgames.com",
-		"kr":  "asia.api.riotgames.com",
-		"jp":  "asia.api.riotgames.com",
-		"oce": "sea.api.riotgames.com",
-	}
-
-	if url, exists := continentalRouting[region]; exists {
-		return url
-	}
-
-	// Default to Americas if region is not recognized
-	return continentalRouting["na"]
+// getMatchRegionalURL returns the regional routing host serving match-v5 for a region
+func (riotService *RiotService) getMatchRegionalURL(region string) (string, error) {
+	resolvedRegion, err := resolveRegion(region)
+	if err != nil {
+		return "", err
+	}
+	return resolvedRegion.RegionalHost, nil
 }
 
-// makeRequest performs an HTTP GET request to the Riot API
-func (riotService *RiotService) makeRequest(url string, target interface{}) error {
-	request, err := http.NewRequest("GET", url, nil)
+// getAccountURL returns the regional routing host serving Account-V1 for a region
+// Account-V1 is served by americas, europe and asia only, so SEA platforms use asia
+func (riotService *RiotService) getAccountURL(region string) (string, error) {
+	resolvedRegion, err := resolveRegion(region)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", err
+	}
+
+	if riotService.accountHostOverride != "" {
+		return riotService.accountHostOverride, nil
+	}
+	return resolvedRegion.AccountHost, nil
+}
+
+// SetAccountCluster routes every Account-V1 lookup to one cluster ("americas", "europe" or "asia")
+// An empty cluster or "nearest" restores routing each region to its nearest cluster
+func (riotService *RiotService) SetAccountCluster(cluster string) error {
+	cluster = strings.ToLower(strings.TrimSpace(cluster))
+	if cluster == "" || cluster == "nearest" {
+		riotService.accountHostOverride = ""
+		return nil
+	}
+
+	host, exists := accountClusters[cluster]
+	if !exists {
+		return fmt.Errorf("unknown account cluster %q", cluster)
+	}
+
+	riotService.accountHostOverride = host
+	return nil
+}
+
+// SetCircuitBreakerPolicy replaces the per-host circuit breaker, resetting every host to closed
+func (riotService *RiotService) SetCircuitBreakerPolicy(policy CircuitBreakerPolicy) {
+	riotService.circuitBreaker = NewCircuitBreaker(policy)
+}
+
+// CircuitBreakerStatus returns the circuit breaker state of every Riot routing host contacted so far
+func (riotService *RiotService) CircuitBreakerStatus() map[string]CircuitBreakerStatus {
+	return riotService.circuitBreaker.Status()
+}
+
+// CoalescedCalls returns how many calls were served by an identical in-flight request, keyed by endpoint
+func (riotService *RiotService) CoalescedCalls() map[string]int64 {
+	return riotService.requestGroup.stats()
+}
+
+// makeRequest performs an HTTP GET request to the Riot API and decodes the JSON response into target
+// Concurrent identical requests (same endpoint and URL, and so the same region and arguments) share one upstream call
+func (riotService *RiotService) makeRequest(ctx context.Context, endpoint riotEndpoint, url string, target interface{}) error {
+	body, err := riotService.requestGroup.do(ctx, endpoint, endpoint.key()+" "+url, func(ctx context.Context) ([]byte, error) {
+		return riotService.fetchWithRetry(ctx, endpoint, url)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// fetchWithRetry performs an HTTP GET request to the Riot API and returns the response body
+// Failed attempts are retried according to the service's retry policy until ctx is done
+func (riotService *RiotService) fetchWithRetry(ctx context.Context, endpoint riotEndpoint, url string) ([]byte, error) {
+	policy := riotService.retryPolicy
+	startTime := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		body, retryable, retryAfter, err := riotService.attemptRequest(ctx, endpoint, url)
+		if err == nil {
+			return body, nil
+		}
+
+		// Never retry once the caller has gone away or its deadline has passed
+		if !retryable || attempt >= policy.MaxAttempts || ctx.Err() != nil {
+			return nil, err
+		}
+
+		// Honor Retry-After when Riot provides it, otherwise back off exponentially
+		delay := retryAfter
+		if delay <= 0 {
+			delay = policy.backoff(attempt)
+		}
+
+		if policy.MaxElapsed > 0 && time.Since(startTime)+delay > policy.MaxElapsed {
+			log.Warn().
+				Err(err).
+				Str("endpoint", endpoint.key()).
+				Int("attempt", attempt).
+				Dur("elapsed", time.Since(startTime)).
+				Msg("Riot API retry budget exhausted")
+			return nil, err
+		}
+
+		log.Warn().
+			Err(err).
+			Str("endpoint", endpoint.key()).
+			Int("attempt", attempt).
+			Dur("delay", delay).
+			Msg("Retrying Riot API request")
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, fmt.Errorf("retry cancelled: %w", err)
+		}
+	}
+}
+
+// attemptRequest performs a single HTTP GET request to the Riot API
+// Requests fail fast while the host's circuit is open, then wait for capacity in the host's app and method rate limits
+// Returns the response body, or whether a failure is retryable and the Retry-After duration Riot asked for, if any
+func (riotService *RiotService) attemptRequest(ctx context.Context, endpoint riotEndpoint, requestURL string) ([]byte, bool, time.Duration, error) {
+	parsedURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, false, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	host := parsedURL.Host
+	if err := riotService.circuitBreaker.Allow(host); err != nil {
+		return nil, false, 0, err
+	}
+
+	if err := riotService.rateLimiter.Wait(ctx, host, endpoint.key()); err != nil {
+		riotService.circuitBreaker.Release(host)
+		return nil, false, 0, fmt.Errorf("rate limit wait cancelled: %w", err)
+	}
+
+	// The attempt deadline starts only once the request can be sent, so time spent waiting
+	// for rate limit capacity does not eat into it
+	attemptContext, cancel := context.WithTimeout(ctx, riotService.requestTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(attemptContext, "GET", requestURL, nil)
+	if err != nil {
+		riotService.circuitBreaker.Release(host)
+		return nil, false, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add API key to request header
@@ -102,20 +260,51 @@ func (riotService *RiotService) makeRequest(url string, target interface{}) erro
 
 	response, err := riotService.httpClient.Do(request)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		// A caller going away says nothing about the host's health; anything else happened to a request
+		// that was actually sent (the attempt deadline only starts after the rate limit wait)
+		if ctx.Err() != nil {
+			riotService.circuitBreaker.Release(host)
+		} else {
+			riotService.circuitBreaker.Record(host, false)
+		}
+		// Transport errors (timeouts, connection resets) are usually transient
+		return nil, true, 0, newRiotTransportError(endpoint, err)
 	}
 	defer response.Body.Close()
 
+	riotService.rateLimiter.Update(host, endpoint.key(), response)
+
+	// Any response below 500 (including 404 and 429) shows the host is up
+	riotService.circuitBreaker.Record(host, response.StatusCode < http.StatusInternalServerError)
+
 	if response.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(response.Body)
-		return fmt.Errorf("API request failed with status %d: %s", response.StatusCode, string(body))
+		riotAPIError := &RiotAPIError{
+			StatusCode: response.StatusCode,
+			Service:    endpoint.service,
+			Endpoint:   endpoint.method,
+			RetryAfter: parseRetryAfter(response.Header),
+			Message:    string(body),
+		}
+		return nil, isRetryableStatus(response.StatusCode), riotAPIError.RetryAfter, riotAPIError
 	}
 
-	if err := json.NewDecoder(response.Body).Decode(target); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		// The connection dropped mid-body, which is as transient as a failed request
+		return nil, true, 0, newRiotTransportError(endpoint, err)
 	}
 
-	return nil
+	return body, false, 0, nil
+}
+
+// optionalUnixMilli converts Riot epoch milliseconds to a time, or nil when Riot sent none
+func optionalUnixMilli(milliseconds int64) *time.Time {
+	if milliseconds == 0 {
+		return nil
+	}
+	timestamp := time.UnixMilli(milliseconds)
+	return &timestamp
 }
 
 // buildURL creates the full URL, using baseURLOverride if set (for testing)
@@ -128,69 +317,114 @@ func (riotService *RiotService) buildURL(baseURL string, path string) string {
 
 // GetSummonerByRiotID retrieves summoner information using Riot ID (gameName#tagLine)
 // This is the new Riot API method that replaced the deprecated by-name endpoint
-func (riotService *RiotService) GetSummonerByRiotID(region string, gameName string, tagLine string) (*models.Summoner, error) {
+func (riotService *RiotService) GetSummonerByRiotID(ctx context.Context, region string, gameName string, tagLine string) (*models.Summoner, error) {
 	// Step 1: Get account info (PUUID) using Riot Account API
-	accountURL := riotService.getMatchRegionalURL(region)
-	accountPath := fmt.Sprintf("/riot/account/v1/accounts/by-riot-id/%s/%s", gameName, tagLine)
-	accountEndpoint := riotService.buildURL(accountURL, accountPath)
+	account, err := riotService.GetAccountByRiotID(ctx, region, gameName, tagLine)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Get summoner details using PUUID
+	return riotService.GetSummonerByPUUID(ctx, region, account.PUUID)
+}
 
-	var accountInfo struct {
-		PUUID    string `json:"puuid"`
-		GameName string `json:"gameName"`
-		TagLine  string `json:"tagLine"`
+// GetAccountByRiotID retrieves an account (PUUID and canonical Riot ID) by Riot ID (gameName#tagLine)
+// Callers that only need the PUUID should use this instead of GetSummonerByRiotID to skip the summoner-v4 call
+func (riotService *RiotService) GetAccountByRiotID(ctx context.Context, region string, gameName string, tagLine string) (*models.Account, error) {
+	baseURL, err := riotService.getAccountURL(region)
+	if err != nil {
+		return nil, err
 	}
+	path := fmt.Sprintf("/riot/account/v1/accounts/by-riot-id/%s/%s", url.PathEscape(gameName), url.PathEscape(tagLine))
+	url := riotService.buildURL(baseURL, path)
 
-	if err := riotService.makeRequest(accountEndpoint, &accountInfo); err != nil {
+	var account models.Account
+	if err := riotService.makeRequest(ctx, accountByRiotIDEndpoint, url, &account); err != nil {
 		return nil, fmt.Errorf("failed to get account info: %w", err)
 	}
 
-	// Step 2: Get summoner details using PUUID
-	return riotService.GetSummonerByPUUID(region, accountInfo.PUUID)
+	return &account, nil
 }
 
 // GetSummonerByPUUID retrieves summoner information by PUUID
-func (riotService *RiotService) GetSummonerByPUUID(region string, puuid string) (*models.Summoner, error) {
-	baseURL := riotService.getRegionalURL(region)
-	path := fmt.Sprintf("/lol/summoner/v4/summoners/by-puuid/%s", puuid)
+func (riotService *RiotService) GetSummonerByPUUID(ctx context.Context, region string, puuid string) (*models.Summoner, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/summoner/v4/summoners/by-puuid/%s", url.PathEscape(puuid))
 	url := riotService.buildURL(baseURL, path)
 
 	var summoner models.Summoner
-	if err := riotService.makeRequest(url, &summoner); err != nil {
+	if err := riotService.makeRequest(ctx, summonerByPUUIDEndpoint, url, &summoner); err != nil {
 		return nil, fmt.Errorf("failed to get summoner: %w", err)
 	}
 
 	return &summoner, nil
 }
 
-// GetMatchHistory retrieves recent match IDs for a player and fetches full match details
-func (riotService *RiotService) GetMatchHistory(region string, puuid string, count int) ([]models.Match, error) {
-	baseURL := riotService.getMatchRegionalURL(region)
-	path := fmt.Sprintf("/lol/match/v5/matches/by-puuid/%s/ids?start=0&count=%d", puuid, count)
+// GetAccountByPUUID retrieves the Riot ID (gameName#tagLine) of an account by PUUID
+func (riotService *RiotService) GetAccountByPUUID(ctx context.Context, region string, puuid string) (*models.Account, error) {
+	baseURL, err := riotService.getAccountURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/riot/account/v1/accounts/by-puuid/%s", url.PathEscape(puuid))
+	url := riotService.buildURL(baseURL, path)
+
+	var account models.Account
+	if err := riotService.makeRequest(ctx, accountByPUUIDEndpoint, url, &account); err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	return &account, nil
+}
+
+// GetMatchIDs retrieves a page of a player's match IDs matching options, newest first
+func (riotService *RiotService) GetMatchIDs(ctx context.Context, region string, puuid string, options MatchListOptions) ([]string, error) {
+	baseURL, err := riotService.getMatchRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/match/v5/matches/by-puuid/%s/ids?%s", url.PathEscape(puuid), options.query())
 	matchListURL := riotService.buildURL(baseURL, path)
 
 	var matchIDs []string
-	if err := riotService.makeRequest(matchListURL, &matchIDs); err != nil {
+	if err := riotService.makeRequest(ctx, matchIDsByPUUIDEndpoint, matchListURL, &matchIDs); err != nil {
 		return nil, fmt.Errorf("failed to get match list: %w", err)
 	}
 
-	// Fetch details for each match
-	matches := make([]models.Match, 0, len(matchIDs))
-	for _, matchID := range matchIDs {
-		match, err := riotService.GetMatchDetails(region, matchID)
-		if err != nil {
-			// Log error but continue processing other matches
-			continue
-		}
-		matches = append(matches, *match)
+	return matchIDs, nil
+}
+
+// GetMatchHistory retrieves a page of match IDs for a player and fetches full match details
+// Matches whose details cannot be fetched are reported in the result's Failures
+func (riotService *RiotService) GetMatchHistory(ctx context.Context, region string, puuid string, options MatchListOptions) (*models.MatchHistory, error) {
+	matchIDs, err := riotService.GetMatchIDs(ctx, region, puuid, options)
+	if err != nil {
+		return nil, err
 	}
 
-	return matches, nil
+	// Fetch match details concurrently, preserving match ID order
+	matchHistory, err := fetchMatchDetails(ctx, matchIDs, riotService.matchFetchParallelism, func(ctx context.Context, matchID string) (*models.Match, error) {
+		return riotService.GetMatchDetails(ctx, region, matchID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matchHistory.NextCursor = nextMatchCursor(options, len(matchIDs))
+	return matchHistory, nil
 }
 
 // GetMatchDetails retrieves detailed information for a specific match
-func (riotService *RiotService) GetMatchDetails(region string, matchID string) (*models.Match, error) {
-	baseURL := riotService.getMatchRegionalURL(region)
-	path := fmt.Sprintf("/lol/match/v5/matches/%s", matchID)
+func (riotService *RiotService) GetMatchDetails(ctx context.Context, region string, matchID string) (*models.Match, error) {
+	baseURL, err := riotService.getMatchRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	// Match IDs come from clients, so escape them to keep them inside a single path segment
+	path := fmt.Sprintf("/lol/match/v5/matches/%s", url.PathEscape(matchID))
 	url := riotService.buildURL(baseURL, path)
 
 	var rawMatch struct {
@@ -202,26 +436,19 @@ func (riotService *RiotService) GetMatchDetails(region string, matchID string) (
 			GameDuration int    `json:"gameDuration"`
 			GameMode     string `json:"gameMode"`
 			GameType     string `json:"gameType"`
-			Participants []struct {
-				PUUID                       string `json:"puuid"`
-				SummonerName                string `json:"summonerName"`
-				ChampionID                  int    `json:"championId"`
-				ChampionName                string `json:"championName"`
-				Kills                       int    `json:"kills"`
-				Deaths                      int    `json:"deaths"`
-				Assists                     int    `json:"assists"`
-				GoldEarned                  int    `json:"goldEarned"`
-				TotalDamageDealtToChampions int    `json:"totalDamageDealtToChampions"`
-				TotalDamageTaken            int    `json:"totalDamageTaken"`
-				VisionScore                 int    `json:"visionScore"`
-				TotalMinionsKilled          int    `json:"totalMinionsKilled"`
-				Win                         bool   `json:"win"`
-				TeamPosition                string `json:"teamPosition"`
-			} `json:"participants"`
+			QueueID      int    `json:"queueId"`
+			GameVersion  string `json:"gameVersion"`
+			MapID        int    `json:"mapId"`
+			PlatformID   string `json:"platformId"`
+			// Start and end timestamps are only present for matches played since patch 11.20
+			GameStartTimestamp int64            `json:"gameStartTimestamp"`
+			GameEndTimestamp   int64            `json:"gameEndTimestamp"`
+			Participants       []rawParticipant `json:"participants"`
+			Teams              []rawTeam        `json:"teams"`
 		} `json:"info"`
 	}
 
-	if err := riotService.makeRequest(url, &rawMatch); err != nil {
+	if err := riotService.makeRequest(ctx, matchEndpoint, url, &rawMatch); err != nil {
 		return nil, fmt.Errorf("failed to get match details: %w", err)
 	}
 
@@ -232,64 +459,81 @@ func (riotService *RiotService) GetMatchDetails(region string, matchID string) (
 		GameDuration: rawMatch.Info.GameDuration,
 		GameMode:     rawMatch.Info.GameMode,
 		GameType:     rawMatch.Info.GameType,
+		QueueID:      rawMatch.Info.QueueID,
+		QueueName:    QueueName(rawMatch.Info.QueueID),
+		GameVersion:  rawMatch.Info.GameVersion,
+		Patch:        parsePatch(rawMatch.Info.GameVersion),
+		MapID:        rawMatch.Info.MapID,
+		PlatformID:   rawMatch.Info.PlatformID,
 		Participants: make([]models.Participant, len(rawMatch.Info.Participants)),
+		Teams:        make([]models.Team, len(rawMatch.Info.Teams)),
 	}
 
+	// Older matches have no start or end timestamps; leave them unset rather than reporting the Unix epoch
+	match.GameStartTimestamp = optionalUnixMilli(rawMatch.Info.GameStartTimestamp)
+	match.GameEndTimestamp = optionalUnixMilli(rawMatch.Info.GameEndTimestamp)
+
 	for i, participant := range rawMatch.Info.Participants {
-		match.Participants[i] = models.Participant{
-			PUUID:                       participant.PUUID,
-			SummonerName:                participant.SummonerName,
-			ChampionID:                  participant.ChampionID,
-			ChampionName:                participant.ChampionName,
-			Kills:                       participant.Kills,
-			Deaths:                      participant.Deaths,
-			Assists:                     participant.Assists,
-			GoldEarned:                  participant.GoldEarned,
-			TotalDamageDealtToChampions: participant.TotalDamageDealtToChampions,
-			TotalDamageTaken:            participant.TotalDamageTaken,
-			VisionScore:                 participant.VisionScore,
-			TotalMinionsKilled:          participant.TotalMinionsKilled,
-			Win:                         participant.Win,
-			TeamPosition:                participant.TeamPosition,
-		}
+		match.Participants[i] = convertParticipant(participant)
+	}
+
+	for i, team := range rawMatch.Info.Teams {
+		match.Teams[i] = convertTeam(team)
 	}
 
 	return match, nil
 }
 
+// GetMatchTimeline retrieves the frame-by-frame timeline of a match
+func (riotService *RiotService) GetMatchTimeline(ctx context.Context, region string, matchID string) (*models.MatchTimeline, error) {
+	baseURL, err := riotService.getMatchRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/match/v5/matches/%s/timeline", url.PathEscape(matchID))
+	url := riotService.buildURL(baseURL, path)
+
+	var rawTimeline rawMatchTimeline
+	if err := riotService.makeRequest(ctx, matchTimelineEndpoint, url, &rawTimeline); err != nil {
+		return nil, fmt.Errorf("failed to get match timeline: %w", err)
+	}
+
+	return convertMatchTimeline(&rawTimeline), nil
+}
+
 // GetRankedStats retrieves ranked statistics for a summoner using their encrypted summoner ID
 // Returns stats for all ranked queues (Solo/Duo, Flex, etc.)
-func (riotService *RiotService) GetRankedStats(region string, encryptedSummonerID string) ([]models.RankedStats, error) {
-	baseURL := riotService.getRegionalURL(region)
-	path := fmt.Sprintf("/lol/league/v4/entries/by-summoner/%s", encryptedSummonerID)
+func (riotService *RiotService) GetRankedStats(ctx context.Context, region string, encryptedSummonerID string) ([]models.RankedStats, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/lol/league/v4/entries/by-summoner/%s", url.PathEscape(encryptedSummonerID))
 	url := riotService.buildURL(baseURL, path)
 
 	// Riot API returns an array of ranked entries (one per queue type)
-	var rawStats []struct {
-		QueueType    string `json:"queueType"`
-		Tier         string `json:"tier"`
-		Rank         string `json:"rank"`
-		LeaguePoints int    `json:"leaguePoints"`
-		Wins         int    `json:"wins"`
-		Losses       int    `json:"losses"`
+	var rawEntries []rawLeagueEntry
+	if err := riotService.makeRequest(ctx, leagueEntriesBySummonerEndpoint, url, &rawEntries); err != nil {
+		return nil, fmt.Errorf("failed to get ranked stats: %w", err)
 	}
 
-	if err := riotService.makeRequest(url, &rawStats); err != nil {
-		return nil, fmt.Errorf("failed to get ranked stats: %w", err)
+	return convertRankedStats(rawEntries), nil
+}
+
+// GetRankedStatsByPUUID retrieves ranked statistics for a player using their PUUID
+// Unlike GetRankedStats, this needs no summoner lookup first
+func (riotService *RiotService) GetRankedStatsByPUUID(ctx context.Context, region string, puuid string) ([]models.RankedStats, error) {
+	baseURL, err := riotService.getRegionalURL(region)
+	if err != nil {
+		return nil, err
 	}
+	path := fmt.Sprintf("/lol/league/v4/entries/by-puuid/%s", url.PathEscape(puuid))
+	url := riotService.buildURL(baseURL, path)
 
-	// Convert raw stats to our model
-	rankedStats := make([]models.RankedStats, len(rawStats))
-	for i, stat := range rawStats {
-		rankedStats[i] = models.RankedStats{
-			QueueType:    stat.QueueType,
-			Tier:         stat.Tier,
-			Rank:         stat.Rank,
-			LeaguePoints: stat.LeaguePoints,
-			Wins:         stat.Wins,
-			Losses:       stat.Losses,
-		}
+	var rawEntries []rawLeagueEntry
+	if err := riotService.makeRequest(ctx, leagueEntriesByPUUIDEndpoint, url, &rawEntries); err != nil {
+		return nil, fmt.Errorf("failed to get ranked stats: %w", err)
 	}
 
-	return rankedStats, nil
+	return convertRankedStats(rawEntries), nil
 }
@@ -1,15 +1,35 @@
 package services
 
-import "github.com/OPGLOL/opgl-data-service/internal/models"
+import (
+	"context"
+
+	"github.com/OPGLOL/opgl-data-service/internal/models"
+)
 
 // RiotServiceInterface defines the interface for Riot API operations
 // This allows for easy mocking in tests
+// Every method takes a context so cancellation and deadlines reach the Riot API
 type RiotServiceInterface interface {
-	GetSummonerByRiotID(region string, gameName string, tagLine string) (*models.Summoner, error)
-	GetSummonerByPUUID(region string, puuid string) (*models.Summoner, error)
-	GetMatchHistory(region string, puuid string, count int) ([]models.Match, error)
-	GetMatchDetails(region string, matchID string) (*models.Match, error)
-	GetRankedStats(region string, encryptedSummonerID string) ([]models.RankedStats, error)
+	GetSummonerByRiotID(ctx context.Context, region string, gameName string, tagLine string) (*models.Summoner, error)
+	GetSummonerByPUUID(ctx context.Context, region string, puuid string) (*models.Summoner, error)
+	GetAccountByPUUID(ctx context.Context, region string, puuid string) (*models.Account, error)
+	GetAccountByRiotID(ctx context.Context, region string, gameName string, tagLine string) (*models.Account, error)
+	GetMatchIDs(ctx context.Context, region string, puuid string, options MatchListOptions) ([]string, error)
+	GetMatchHistory(ctx context.Context, region string, puuid string, options MatchListOptions) (*models.MatchHistory, error)
+	GetMatchDetails(ctx context.Context, region string, matchID string) (*models.Match, error)
+	GetMatchTimeline(ctx context.Context, region string, matchID string) (*models.MatchTimeline, error)
+	GetRankedStats(ctx context.Context, region string, encryptedSummonerID string) ([]models.RankedStats, error)
+	GetRankedStatsByPUUID(ctx context.Context, region string, puuid string) ([]models.RankedStats, error)
+	GetChampionMasteries(ctx context.Context, region string, puuid string) ([]models.ChampionMastery, error)
+	GetTopChampionMasteries(ctx context.Context, region string, puuid string, count int) ([]models.ChampionMastery, error)
+	GetChampionMastery(ctx context.Context, region string, puuid string, championID int) (*models.ChampionMastery, error)
+	GetMasteryScore(ctx context.Context, region string, puuid string) (int, error)
+	GetActiveGame(ctx context.Context, region string, puuid string) (*models.ActiveGame, error)
+	GetApexLeague(ctx context.Context, region string, queue string, tier string) (*models.League, error)
+	GetLeagueEntries(ctx context.Context, region string, queue string, tier string, division string, page int) ([]models.LeagueEntry, error)
+	GetPlayerChallenges(ctx context.Context, region string, puuid string) (*models.PlayerChallenges, error)
+	GetChallengeConfigs(ctx context.Context, region string) ([]models.ChallengeConfig, error)
+	GetChallengeLeaderboard(ctx context.Context, region string, challengeID int64, level string, limit int) ([]models.ChallengeLeaderboardEntry, error)
 }
 
 // Verify RiotService implements RiotServiceInterface
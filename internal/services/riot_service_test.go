@@ -1,11 +1,15 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestNewRiotService tests the RiotService constructor
@@ -23,6 +27,11 @@ func TestNewRiotService(t *testing.T) {
 	if service.httpClient == nil {
 		t.Error("Expected httpClient to not be nil")
 	}
+
+	// A client-level timeout would cap SetRequestTimeout
+	if service.httpClient.Timeout != 0 {
+		t.Errorf("Expected no client-level timeout, got %s", service.httpClient.Timeout)
+	}
 }
 
 // TestGetRegionalURL tests regional URL mapping
@@ -44,12 +53,22 @@ func TestGetRegionalURL(t *testing.T) {
 		{"tr", "tr1.api.riotgames.com"},
 		{"lan", "la1.api.riotgames.com"},
 		{"las", "la2.api.riotgames.com"},
-		{"unknown", "na1.api.riotgames.com"}, // Default to NA
+		{"me", "me1.api.riotgames.com"},
+		{"ph", "ph2.api.riotgames.com"},
+		{"sg", "sg2.api.riotgames.com"},
+		{"th", "th2.api.riotgames.com"},
+		{"tw", "tw2.api.riotgames.com"},
+		{"vn", "vn2.api.riotgames.com"},
+		{"EUW", "euw1.api.riotgames.com"},
+		{"euw1", "euw1.api.riotgames.com"},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.region, func(t *testing.T) {
-			url := service.getRegionalURL(testCase.region)
+			url, err := service.getRegionalURL(testCase.region)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
 			if url != testCase.expectedURL {
 				t.Errorf("Expected URL '%s' for region '%s', got '%s'", testCase.expectedURL, testCase.region, url)
 			}
@@ -76,12 +95,20 @@ func TestGetMatchRegionalURL(t *testing.T) {
 		{"kr", "asia.api.riotgames.com"},
 		{"jp", "asia.api.riotgames.com"},
 		{"oce", "sea.api.riotgames.com"},
-		{"unknown", "americas.api.riotgames.com"}, // Default to Americas
+		{"me", "europe.api.riotgames.com"},
+		{"ph", "sea.api.riotgames.com"},
+		{"sg", "sea.api.riotgames.com"},
+		{"th", "sea.api.riotgames.com"},
+		{"tw", "sea.api.riotgames.com"},
+		{"vn", "sea.api.riotgames.com"},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.region, func(t *testing.T) {
-			url := service.getMatchRegionalURL(testCase.region)
+			url, err := service.getMatchRegionalURL(testCase.region)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
 			if url != testCase.expectedURL {
 				t.Errorf("Expected URL '%s' for region '%s', got '%s'", testCase.expectedURL, testCase.region, url)
 			}
@@ -108,7 +135,7 @@ func TestMakeRequest_Success(t *testing.T) {
 	service := NewRiotService("test-api-key")
 
 	var result map[string]string
-	err := service.makeRequest(server.URL, &result)
+	err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
@@ -130,7 +157,7 @@ func TestMakeRequest_NonOKStatus(t *testing.T) {
 	service := NewRiotService("test-api-key")
 
 	var result map[string]string
-	err := service.makeRequest(server.URL, &result)
+	err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result)
 
 	if err == nil {
 		t.Fatal("Expected error for non-OK status")
@@ -152,7 +179,7 @@ func TestMakeRequest_InvalidJSON(t *testing.T) {
 	service := NewRiotService("test-api-key")
 
 	var result map[string]string
-	err := service.makeRequest(server.URL, &result)
+	err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result)
 
 	if err == nil {
 		t.Fatal("Expected error for invalid JSON")
@@ -168,7 +195,7 @@ func TestMakeRequest_InvalidURL(t *testing.T) {
 	service := NewRiotService("test-api-key")
 
 	var result map[string]string
-	err := service.makeRequest("http://invalid-url-that-will-fail:99999", &result)
+	err := service.makeRequest(context.Background(), matchEndpoint, "http://invalid-url-that-will-fail:99999", &result)
 
 	if err == nil {
 		t.Fatal("Expected error for invalid URL")
@@ -187,13 +214,13 @@ func TestGetMatchHistory_EmptyMatches(t *testing.T) {
 
 	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
 
-	matches, err := service.GetMatchHistory("na", "test-puuid", 10)
+	matchHistory, err := service.GetMatchHistory(context.Background(), "na", "test-puuid", MatchListOptions{Count: 10})
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
 
-	if len(matches) != 0 {
-		t.Errorf("Expected 0 matches, got %d", len(matches))
+	if len(matchHistory.Matches) != 0 {
+		t.Errorf("Expected 0 matches, got %d", len(matchHistory.Matches))
 	}
 }
 
@@ -269,7 +296,7 @@ func TestGetSummonerByRiotID_Success(t *testing.T) {
 
 	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
 
-	summoner, err := service.GetSummonerByRiotID("na", "TestPlayer", "NA1")
+	summoner, err := service.GetSummonerByRiotID(context.Background(), "na", "TestPlayer", "NA1")
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -289,7 +316,7 @@ func TestGetSummonerByRiotID_AccountError(t *testing.T) {
 
 	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
 
-	_, err := service.GetSummonerByRiotID("na", "NonExistent", "NA1")
+	_, err := service.GetSummonerByRiotID(context.Background(), "na", "NonExistent", "NA1")
 	if err == nil {
 		t.Fatal("Expected error, got nil")
 	}
@@ -312,7 +339,7 @@ func TestGetSummonerByPUUID_Success(t *testing.T) {
 
 	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
 
-	summoner, err := service.GetSummonerByPUUID("na", "test-puuid-123")
+	summoner, err := service.GetSummonerByPUUID(context.Background(), "na", "test-puuid-123")
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -322,6 +349,113 @@ func TestGetSummonerByPUUID_Success(t *testing.T) {
 	}
 }
 
+// TestGetAccountByPUUID_Success tests successful Riot ID lookup by PUUID
+func TestGetAccountByPUUID_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/riot/account/v1/accounts/by-puuid/test-puuid-123" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(map[string]string{
+			"puuid":    "test-puuid-123",
+			"gameName": "TestPlayer",
+			"tagLine":  "NA1",
+		})
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	account, err := service.GetAccountByPUUID(context.Background(), "na", "test-puuid-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if account.GameName != "TestPlayer" || account.TagLine != "NA1" {
+		t.Errorf("Expected Riot ID 'TestPlayer#NA1', got '%s#%s'", account.GameName, account.TagLine)
+	}
+}
+
+// TestGetAccountByRiotID_Success tests that a Riot ID is resolved through account-v1 alone
+func TestGetAccountByRiotID_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/riot/account/v1/accounts/by-riot-id/TestPlayer/NA1" {
+			t.Errorf("Unexpected path: %s", request.URL.Path)
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(map[string]string{
+			"puuid":    "test-puuid-123",
+			"gameName": "TestPlayer",
+			"tagLine":  "NA1",
+		})
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	account, err := service.GetAccountByRiotID(context.Background(), "na", "TestPlayer", "NA1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if account.PUUID != "test-puuid-123" {
+		t.Errorf("Expected PUUID 'test-puuid-123', got '%s'", account.PUUID)
+	}
+}
+
+// TestRiotService_EscapesPathSegments tests that client-supplied PUUIDs and Riot IDs cannot change the upstream path
+func TestRiotService_EscapesPathSegments(t *testing.T) {
+	var escapedPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		escapedPaths = append(escapedPaths, request.URL.EscapedPath())
+		writer.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+	service.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+	ctx := context.Background()
+	service.GetAccountByPUUID(ctx, "na", "../../lol/summoner?x")
+	service.GetAccountByRiotID(ctx, "na", "Test Player/..", "NA1?")
+	service.GetSummonerByPUUID(ctx, "na", "a/b")
+	service.GetMatchIDs(ctx, "na", "a/b", MatchListOptions{Count: 20})
+	service.GetRankedStatsByPUUID(ctx, "na", "a/b")
+
+	expectedPaths := []string{
+		"/riot/account/v1/accounts/by-puuid/..%2F..%2Flol%2Fsummoner%3Fx",
+		"/riot/account/v1/accounts/by-riot-id/Test%20Player%2F../NA1%3F",
+		"/lol/summoner/v4/summoners/by-puuid/a%2Fb",
+		"/lol/match/v5/matches/by-puuid/a%2Fb/ids",
+		"/lol/league/v4/entries/by-puuid/a%2Fb",
+	}
+	if len(escapedPaths) != len(expectedPaths) {
+		t.Fatalf("Expected %d requests, got %v", len(expectedPaths), escapedPaths)
+	}
+	for i, expectedPath := range expectedPaths {
+		if escapedPaths[i] != expectedPath {
+			t.Errorf("Expected path %s, got %s", expectedPath, escapedPaths[i])
+		}
+	}
+}
+
+// TestGetAccountByPUUID_NotFound tests that an unknown PUUID returns a 404 RiotAPIError
+func TestGetAccountByPUUID_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+
+	_, err := service.GetAccountByPUUID(context.Background(), "na", "unknown-puuid")
+
+	var riotAPIError *RiotAPIError
+	if !errors.As(err, &riotAPIError) || riotAPIError.StatusCode != http.StatusNotFound || riotAPIError.Service != "account-v1" {
+		t.Errorf("Expected account-v1 404 RiotAPIError, got: %v", err)
+	}
+}
+
 // TestGetSummonerByPUUID_Error tests error handling for summoner lookup
 func TestGetSummonerByPUUID_Error(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
@@ -332,7 +466,7 @@ func TestGetSummonerByPUUID_Error(t *testing.T) {
 
 	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
 
-	_, err := service.GetSummonerByPUUID("na", "invalid-puuid")
+	_, err := service.GetSummonerByPUUID(context.Background(), "na", "invalid-puuid")
 	if err == nil {
 		t.Fatal("Expected error, got nil")
 	}
@@ -364,13 +498,13 @@ func TestGetMatchHistory_Success(t *testing.T) {
 
 	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
 
-	matches, err := service.GetMatchHistory("na", "test-puuid", 10)
+	matchHistory, err := service.GetMatchHistory(context.Background(), "na", "test-puuid", MatchListOptions{Count: 10})
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
 
-	if len(matches) != 2 {
-		t.Errorf("Expected 2 matches, got %d", len(matches))
+	if len(matchHistory.Matches) != 2 {
+		t.Errorf("Expected 2 matches, got %d", len(matchHistory.Matches))
 	}
 }
 
@@ -384,7 +518,7 @@ func TestGetMatchHistory_Error(t *testing.T) {
 
 	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
 
-	_, err := service.GetMatchHistory("na", "test-puuid", 10)
+	_, err := service.GetMatchHistory(context.Background(), "na", "test-puuid", MatchListOptions{Count: 10})
 	if err == nil {
 		t.Fatal("Expected error, got nil")
 	}
@@ -418,14 +552,19 @@ func TestGetMatchHistory_PartialFailure(t *testing.T) {
 
 	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
 
-	matches, err := service.GetMatchHistory("na", "test-puuid", 10)
+	matchHistory, err := service.GetMatchHistory(context.Background(), "na", "test-puuid", MatchListOptions{Count: 10})
 	if err != nil {
 		t.Fatalf("Expected no error even with partial failures, got: %v", err)
 	}
 
 	// Should only have 1 match since the second one failed
-	if len(matches) != 1 {
-		t.Errorf("Expected 1 match (partial success), got %d", len(matches))
+	if len(matchHistory.Matches) != 1 {
+		t.Errorf("Expected 1 match (partial success), got %d", len(matchHistory.Matches))
+	}
+
+	// The failed match should be reported rather than silently dropped
+	if len(matchHistory.Failures) != 1 || matchHistory.Failures[0].MatchID != "NA1_124" {
+		t.Errorf("Expected NA1_124 to be reported as failed, got %+v", matchHistory.Failures)
 	}
 }
 
@@ -467,7 +606,7 @@ func TestGetMatchDetails_Success(t *testing.T) {
 
 	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
 
-	match, err := service.GetMatchDetails("na", "NA1_123")
+	match, err := service.GetMatchDetails(context.Background(), "na", "NA1_123")
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -485,6 +624,35 @@ func TestGetMatchDetails_Success(t *testing.T) {
 	}
 }
 
+// TestGetMatchDetails_EscapesMatchID tests that a client-supplied match ID cannot change the upstream path
+func TestGetMatchDetails_EscapesMatchID(t *testing.T) {
+	var escapedPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		escapedPaths = append(escapedPaths, request.URL.EscapedPath())
+		writer.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+	service.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+	service.GetMatchDetails(context.Background(), "na", "NA1_1/../../summoner?x=%3F")
+	service.GetMatchTimeline(context.Background(), "na", "NA1_1/../../summoner?x=%3F")
+
+	expectedPaths := []string{
+		"/lol/match/v5/matches/NA1_1%2F..%2F..%2Fsummoner%3Fx=%253F",
+		"/lol/match/v5/matches/NA1_1%2F..%2F..%2Fsummoner%3Fx=%253F/timeline",
+	}
+	if len(escapedPaths) != len(expectedPaths) {
+		t.Fatalf("Expected %d requests, got %v", len(expectedPaths), escapedPaths)
+	}
+	for i, expectedPath := range expectedPaths {
+		if escapedPaths[i] != expectedPath {
+			t.Errorf("Expected path %s, got %s", expectedPath, escapedPaths[i])
+		}
+	}
+}
+
 // TestGetMatchDetails_Error tests error handling for match details
 func TestGetMatchDetails_Error(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
@@ -495,7 +663,7 @@ func TestGetMatchDetails_Error(t *testing.T) {
 
 	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
 
-	_, err := service.GetMatchDetails("na", "invalid-match-id")
+	_, err := service.GetMatchDetails(context.Background(), "na", "invalid-match-id")
 	if err == nil {
 		t.Fatal("Expected error, got nil")
 	}
@@ -512,7 +680,7 @@ func TestMakeRequest_ServerError(t *testing.T) {
 	service := NewRiotService("test-api-key")
 
 	var result map[string]string
-	err := service.makeRequest(server.URL, &result)
+	err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result)
 
 	if err == nil {
 		t.Fatal("Expected error for server error")
@@ -534,7 +702,7 @@ func TestMakeRequest_Unauthorized(t *testing.T) {
 	service := NewRiotService("invalid-api-key")
 
 	var result map[string]string
-	err := service.makeRequest(server.URL, &result)
+	err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result)
 
 	if err == nil {
 		t.Fatal("Expected error for unauthorized")
@@ -556,7 +724,7 @@ func TestMakeRequest_RateLimited(t *testing.T) {
 	service := NewRiotService("test-api-key")
 
 	var result map[string]string
-	err := service.makeRequest(server.URL, &result)
+	err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result)
 
 	if err == nil {
 		t.Fatal("Expected error for rate limit")
@@ -566,3 +734,117 @@ func TestMakeRequest_RateLimited(t *testing.T) {
 		t.Errorf("Expected error to contain '429', got: %v", err)
 	}
 }
+
+// TestMakeRequest_ContextCancelled tests that a cancelled context aborts the request without retrying
+func TestMakeRequest_ContextCancelled(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestCount++
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	service := NewRiotService("test-api-key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var result map[string]string
+	err := service.makeRequest(ctx, matchEndpoint, server.URL, &result)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+
+	if requestCount != 0 {
+		t.Errorf("Expected no requests to reach the server, got %d", requestCount)
+	}
+}
+
+// TestMakeRequest_RequestTimeout tests that a slow response is abandoned after the request timeout
+func TestMakeRequest_RequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-request.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	service := NewRiotService("test-api-key")
+	service.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+	service.SetRequestTimeout(20 * time.Millisecond)
+
+	startTime := time.Now()
+	var result map[string]string
+	if err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result); err == nil {
+		t.Fatal("Expected timeout error")
+	}
+
+	if elapsed := time.Since(startTime); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected request to time out quickly, took %s", elapsed)
+	}
+}
+
+// TestMakeRequest_RateLimitWaitExcludedFromTimeout tests that waiting for rate limit capacity does not use up the request timeout
+func TestMakeRequest_RateLimitWaitExcludedFromTimeout(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestCount++
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	service := NewRiotService("test-api-key")
+	service.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+	service.SetRequestTimeout(50 * time.Millisecond)
+
+	serverURL, _ := url.Parse(server.URL)
+	service.rateLimiter.appScopes[serverURL.Host] = &rateScope{blockedUntil: time.Now().Add(200 * time.Millisecond)}
+
+	var result map[string]string
+	if err := service.makeRequest(context.Background(), matchEndpoint, server.URL, &result); err != nil {
+		t.Fatalf("Expected the request to succeed after the rate limit wait, got: %v", err)
+	}
+
+	if requestCount != 1 {
+		t.Errorf("Expected 1 request to reach the server, got %d", requestCount)
+	}
+}
+
+// TestGetMatchHistory_StopsOnCancellation tests that match details stop being fetched once the context is cancelled
+func TestGetMatchHistory_StopsOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	detailRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+
+		if strings.Contains(request.URL.Path, "/ids") {
+			json.NewEncoder(writer).Encode([]string{"NA1_1", "NA1_2", "NA1_3", "NA1_4"})
+			return
+		}
+
+		// Simulate the client disconnecting after the first match detail
+		detailRequests++
+		cancel()
+		json.NewEncoder(writer).Encode(map[string]interface{}{
+			"metadata": map[string]interface{}{"matchId": "NA1_1"},
+			"info":     map[string]interface{}{"participants": []map[string]interface{}{}},
+		})
+	}))
+	defer server.Close()
+
+	service := NewRiotServiceWithBaseURL("test-api-key", server.URL, server.Client())
+	service.SetMatchFetchParallelism(1)
+
+	_, err := service.GetMatchHistory(ctx, "na", "test-puuid", MatchListOptions{Count: 4})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+
+	if detailRequests != 1 {
+		t.Errorf("Expected 1 match detail request before cancellation, got %d", detailRequests)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/OPGLOL/opgl-data-service/internal/api"
+	"github.com/OPGLOL/opgl-data-service/internal/cache"
 	"github.com/OPGLOL/opgl-data-service/internal/config"
 	"github.com/OPGLOL/opgl-data-service/internal/middleware"
 	"github.com/OPGLOL/opgl-data-service/internal/services"
@@ -36,15 +37,56 @@ func main() {
 
 	// Initialize Riot service
 	riotService := services.NewRiotService(configuration.RiotAPIKey)
+	riotService.SetRetryPolicy(services.RetryPolicy{
+		MaxAttempts: configuration.RiotRetryMaxAttempts,
+		BaseDelay:   configuration.RiotRetryBaseDelay,
+		MaxDelay:    configuration.RiotRetryMaxDelay,
+		MaxElapsed:  configuration.RiotRetryMaxElapsed,
+	})
+	riotService.SetRequestTimeout(configuration.RiotRequestTimeout)
+	riotService.SetMatchFetchParallelism(configuration.MatchFetchParallelism)
+	if err := riotService.SetAccountCluster(configuration.RiotAccountCluster); err != nil {
+		log.Warn().Err(err).Msg("Invalid RIOT_ACCOUNT_CLUSTER, routing account lookups to each region's nearest cluster")
+	}
+	riotService.SetCircuitBreakerPolicy(services.CircuitBreakerPolicy{
+		FailureThreshold:    configuration.CircuitBreakerFailureThreshold,
+		OpenDuration:        configuration.CircuitBreakerOpenDuration,
+		HalfOpenMaxRequests: 1,
+	})
+
+	// Cache Riot API lookups in front of the Riot service unless disabled
+	var dataService services.RiotServiceInterface = riotService
+	var cachedService *services.CachedRiotService
+	if configuration.CacheEnabled {
+		cachedService = services.NewCachedRiotService(riotService, newCacheBackend(configuration), services.CacheOptions{
+			SummonerTTL:           configuration.CacheSummonerTTL,
+			RankedTTL:             configuration.CacheRankedTTL,
+			MatchTTL:              configuration.CacheMatchTTL,
+			MasteryTTL:            configuration.CacheMasteryTTL,
+			LiveGameTTL:           configuration.CacheLiveGameTTL,
+			LeagueTTL:             configuration.CacheLeagueTTL,
+			ChallengeTTL:          configuration.CacheChallengeTTL,
+			ChallengeConfigTTL:    configuration.CacheChallengeConfigTTL,
+			MatchFetchParallelism: configuration.MatchFetchParallelism,
+		})
+		dataService = cachedService
+	}
 
 	// Initialize HTTP handler
-	handler := api.NewHandler(riotService)
+	handler := api.NewHandler(dataService)
+	handler.SetCircuitBreakerReporter(riotService)
+	handler.SetCoalescedCallReporter(riotService)
+	if cachedService != nil {
+		handler.SetCacheStatsReporter(cachedService)
+	}
+	handler.SetMatchFetchParallelism(configuration.MatchFetchParallelism)
+	handler.SetRiotIDLookupParallelism(configuration.RiotIDLookupParallelism)
 
 	// Set up router
 	router := api.SetupRouter(handler)
 
-	// Wrap router with logging middleware
-	loggedRouter := middleware.LoggingMiddleware(router)
+	// Wrap router with logging middleware, assigning request IDs first so they appear in logs
+	loggedRouter := middleware.RequestIDMiddleware(middleware.LoggingMiddleware(router))
 
 	// Start server
 	serverAddress := fmt.Sprintf(":%s", configuration.ServerPort)
@@ -57,3 +99,23 @@ func main() {
 		log.Fatal().Err(err).Msg("Server failed to start")
 	}
 }
+
+// newCacheBackend creates the cache backend selected by configuration
+func newCacheBackend(configuration *config.Config) cache.Cache {
+	switch configuration.CacheBackend {
+	case "redis":
+		redisOptions := cache.DefaultRedisOptions()
+		redisOptions.Address = configuration.RedisAddress
+		redisOptions.Password = configuration.RedisPassword
+		redisOptions.DB = configuration.RedisDB
+
+		log.Info().Str("address", configuration.RedisAddress).Msg("Using Redis cache backend")
+		return cache.NewRedisCache(redisOptions)
+	case "memory":
+		log.Info().Int("max_entries", configuration.CacheMaxEntries).Msg("Using in-memory cache backend")
+	default:
+		log.Warn().Str("backend", configuration.CacheBackend).Msg("Unknown cache backend, using in-memory cache")
+	}
+
+	return cache.NewMemoryCache(configuration.CacheMaxEntries)
+}